@@ -17,7 +17,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"net/http"
 	"os"
 	"path/filepath"
 	"time"
@@ -27,20 +26,14 @@ import (
 	"github.com/notaryproject/notation-go/log"
 	"github.com/notaryproject/notation/cmd/notation/internal/cmdutil"
 	"github.com/notaryproject/notation/cmd/notation/internal/signer"
+	"github.com/notaryproject/notation/cmd/notation/internal/timestamp"
 	"github.com/notaryproject/notation/internal/cmd"
 	"github.com/notaryproject/notation/internal/envelope"
-	"github.com/notaryproject/notation/internal/httputil"
 	"github.com/notaryproject/notation/internal/osutil"
 	clirev "github.com/notaryproject/notation/internal/revocation"
-	nx509 "github.com/notaryproject/notation/internal/x509"
-	"github.com/notaryproject/tspclient-go"
 	"github.com/spf13/cobra"
 )
 
-// timestampingTimeout is the timeout when requesting timestamp countersignature
-// from a TSA
-const timestampingTimeout = 15 * time.Second
-
 type blobSignOpts struct {
 	cmd.LoggingFlagOpts
 	cmd.SignerFlagOpts
@@ -50,8 +43,11 @@ type blobSignOpts struct {
 	blobPath               string
 	blobMediaType          string
 	signatureDirectory     string
+	timestamp              bool
 	tsaServerURL           string
 	tsaRootCertificatePath string
+	tsaTrustStore          string
+	tsaTimeout             time.Duration
 	force                  bool
 }
 
@@ -94,6 +90,18 @@ Example - Sign a blob artifact and specify the signature expiry duration, for ex
 
 Example - Sign a blob artifact with timestamping:
   notation blob sign --timestamp-url <TSA_url> --timestamp-root-cert <TSA_root_certificate_filepath> <blob_path>
+
+Example - Sign a blob artifact with timestamping and trust the TSA root certificates from the system root certificate bundle:
+  notation blob sign --timestamp-url <TSA_url> --timestamp-trust-store system <blob_path>
+
+Example - Sign a blob artifact with timestamping and trust the TSA root certificates in the trust store "tsa/<store_name>":
+  notation blob sign --timestamp-url <TSA_url> --timestamp-trust-store <store_name> <blob_path>
+
+Example - Sign a blob artifact with timestamping by the TSA servers configured in config.json, trying them in order:
+  notation blob sign <blob_path>
+
+Example - Sign a blob artifact without timestamping even if TSA servers are configured in config.json:
+  notation blob sign --timestamp=false <blob_path>
 `
 
 	command := &cobra.Command{
@@ -109,13 +117,8 @@ Example - Sign a blob artifact with timestamping:
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// timestamping
-			if cmd.Flags().Changed("timestamp-url") {
-				if opts.tsaServerURL == "" {
-					return errors.New("timestamping: tsa url cannot be empty")
-				}
-				if opts.tsaRootCertificatePath == "" {
-					return errors.New("timestamping: tsa root certificate path cannot be empty")
-				}
+			if err := timestamp.ValidateFlags(cmd.Flags(), opts.tsaServerURL, opts.tsaRootCertificatePath, opts.tsaTrustStore); err != nil {
+				return err
 			}
 			return runBlobSign(cmd, opts)
 		},
@@ -127,10 +130,13 @@ Example - Sign a blob artifact with timestamping:
 	cmd.SetPflagUserMetadata(command.Flags(), &opts.userMetadata, cmd.PflagUserMetadataSignUsage)
 	command.Flags().StringVar(&opts.blobMediaType, "media-type", "application/octet-stream", "media type of the blob")
 	command.Flags().StringVar(&opts.signatureDirectory, "signature-directory", ".", "directory where the blob signature needs to be placed")
-	command.Flags().StringVar(&opts.tsaServerURL, "timestamp-url", "", "RFC 3161 Timestamping Authority (TSA) server URL")
+	command.Flags().BoolVar(&opts.timestamp, "timestamp", true, "timestamp the signature. Use --timestamp=false to sign without timestamping by the TSA servers configured in config.json")
+	command.Flags().StringVar(&opts.tsaServerURL, "timestamp-url", "", "RFC 3161 Timestamping Authority (TSA) server URL. If not specified, the TSA servers configured in config.json are tried in order")
 	command.Flags().StringVar(&opts.tsaRootCertificatePath, "timestamp-root-cert", "", "filepath of timestamp authority root certificate")
+	command.Flags().StringVar(&opts.tsaTrustStore, "timestamp-trust-store", "", "name of the trust store of type tsa containing the timestamp authority root certificates, for example \"myTSA\" for trust store \"tsa/myTSA\", or \"system\" to use the system root certificate bundle")
+	cmd.SetPflagTimestampTimeout(command.Flags(), &opts.tsaTimeout)
 	command.Flags().BoolVar(&opts.force, "force", false, "override the existing signature file, never prompt")
-	command.MarkFlagsRequiredTogether("timestamp-url", "timestamp-root-cert")
+	command.MarkFlagsMutuallyExclusive("timestamp-root-cert", "timestamp-trust-store")
 	return command
 }
 
@@ -209,14 +215,16 @@ func prepareBlobSigningOpts(ctx context.Context, opts *blobSignOpts) (notation.S
 		ContentMediaType: opts.blobMediaType,
 		UserMetadata:     userMetadata,
 	}
-	if opts.tsaServerURL != "" {
+	tsaServers, err := timestamp.Servers(opts.timestamp, opts.tsaServerURL, opts.tsaRootCertificatePath, opts.tsaTrustStore)
+	if err != nil {
+		return notation.SignBlobOptions{}, err
+	}
+	if len(tsaServers) > 0 {
 		// timestamping
-		logger.Infof("Configured to timestamp with TSA %q", opts.tsaServerURL)
-		signBlobOpts.Timestamper, err = tspclient.NewHTTPTimestamper(httputil.NewClient(ctx, &http.Client{Timeout: timestampingTimeout}), opts.tsaServerURL)
-		if err != nil {
-			return notation.SignBlobOptions{}, fmt.Errorf("cannot get http timestamper for timestamping: %w", err)
+		for _, server := range tsaServers {
+			logger.Infof("Configured to timestamp with TSA %q", server.URL)
 		}
-		signBlobOpts.TSARootCAs, err = nx509.NewRootCertPool(opts.tsaRootCertificatePath)
+		signBlobOpts.Timestamper, signBlobOpts.TSARootCAs, err = timestamp.New(ctx, tsaServers, opts.tsaTimeout)
 		if err != nil {
 			return notation.SignBlobOptions{}, err
 		}
@@ -21,6 +21,7 @@ import (
 
 	"github.com/notaryproject/notation/internal/cmd"
 	"github.com/notaryproject/notation/internal/envelope"
+	"github.com/notaryproject/notation/pkg/configutil"
 )
 
 func TestBlobSignCommand_BasicArgs(t *testing.T) {
@@ -34,6 +35,8 @@ func TestBlobSignCommand_BasicArgs(t *testing.T) {
 		},
 		signatureDirectory: ".",
 		blobMediaType:      "application/octet-stream",
+		timestamp:          true,
+		tsaTimeout:         configutil.DefaultTimestampTimeout,
 	}
 	if err := command.ParseFlags([]string{
 		expected.blobPath,
@@ -60,6 +63,8 @@ func TestBlobSignCommand_MoreArgs(t *testing.T) {
 		expiry:             24 * time.Hour,
 		signatureDirectory: ".",
 		blobMediaType:      "application/octet-stream",
+		timestamp:          true,
+		tsaTimeout:         configutil.DefaultTimestampTimeout,
 	}
 	if err := command.ParseFlags([]string{
 		expected.blobPath,
@@ -89,6 +94,8 @@ func TestBlobSignCommand_CorrectConfig(t *testing.T) {
 		pluginConfig:       []string{"key0=val0", "key1=val1"},
 		signatureDirectory: ".",
 		blobMediaType:      "application/octet-stream",
+		timestamp:          true,
+		tsaTimeout:         configutil.DefaultTimestampTimeout,
 	}
 	if err := command.ParseFlags([]string{
 		expected.blobPath,
@@ -136,6 +143,8 @@ func TestBlobSignCommand_OnDemandKeyOptions(t *testing.T) {
 		},
 		signatureDirectory: ".",
 		blobMediaType:      "application/octet-stream",
+		timestamp:          true,
+		tsaTimeout:         configutil.DefaultTimestampTimeout,
 	}
 	if err := command.ParseFlags([]string{
 		expected.blobPath,
@@ -165,6 +174,8 @@ func TestBlobSignCommand_OnDemandKeyBadOptions(t *testing.T) {
 			},
 			signatureDirectory: ".",
 			blobMediaType:      "application/octet-stream",
+			timestamp:          true,
+			tsaTimeout:         configutil.DefaultTimestampTimeout,
 		}
 		if err := command.ParseFlags([]string{
 			expected.blobPath,
@@ -196,6 +207,8 @@ func TestBlobSignCommand_OnDemandKeyBadOptions(t *testing.T) {
 			},
 			signatureDirectory: ".",
 			blobMediaType:      "application/octet-stream",
+			timestamp:          true,
+			tsaTimeout:         configutil.DefaultTimestampTimeout,
 		}
 		if err := command.ParseFlags([]string{
 			expected.blobPath,
@@ -226,6 +239,8 @@ func TestBlobSignCommand_OnDemandKeyBadOptions(t *testing.T) {
 			},
 			signatureDirectory: ".",
 			blobMediaType:      "application/octet-stream",
+			timestamp:          true,
+			tsaTimeout:         configutil.DefaultTimestampTimeout,
 		}
 		if err := command.ParseFlags([]string{
 			expected.blobPath,
@@ -255,6 +270,8 @@ func TestBlobSignCommand_OnDemandKeyBadOptions(t *testing.T) {
 			},
 			signatureDirectory: ".",
 			blobMediaType:      "application/octet-stream",
+			timestamp:          true,
+			tsaTimeout:         configutil.DefaultTimestampTimeout,
 		}
 		if err := command.ParseFlags([]string{
 			expected.blobPath,
@@ -283,6 +300,8 @@ func TestBlobSignCommand_OnDemandKeyBadOptions(t *testing.T) {
 			},
 			signatureDirectory: ".",
 			blobMediaType:      "application/octet-stream",
+			timestamp:          true,
+			tsaTimeout:         configutil.DefaultTimestampTimeout,
 		}
 		if err := command.ParseFlags([]string{
 			expected.blobPath,
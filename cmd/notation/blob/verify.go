@@ -19,14 +19,18 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/notaryproject/notation-go"
+	"github.com/notaryproject/notation-go/log"
 	"github.com/notaryproject/notation/cmd/notation/internal/display"
 	"github.com/notaryproject/notation/cmd/notation/internal/option"
 	"github.com/notaryproject/notation/cmd/notation/internal/verifier"
 	"github.com/notaryproject/notation/internal/cmd"
 	"github.com/notaryproject/notation/internal/envelope"
 	"github.com/notaryproject/notation/internal/ioutil"
+	clirev "github.com/notaryproject/notation/internal/revocation"
+	clicrl "github.com/notaryproject/notation/internal/revocation/crl"
 	"github.com/spf13/cobra"
 )
 
@@ -39,6 +43,10 @@ type blobVerifyOpts struct {
 	userMetadata        []string
 	policyStatementName string
 	blobMediaType       string
+	revocationMode      string
+	ocspTimeout         time.Duration
+	crlTimeout          time.Duration
+	at                  string
 }
 
 func verifyCommand(opts *blobVerifyOpts) *cobra.Command {
@@ -60,6 +68,12 @@ Example - Verify the signature on a blob artifact with media type:
  
 Example - Verify the signature on a blob artifact using a policy statement name:
   notation blob verify --policy-name <policy_name> --signature <signature_path> <blob_path>
+
+Example - Verify the signature on a blob artifact checking revocation only against the local CRL cache:
+  notation blob verify --revocation-mode offline --signature <signature_path> <blob_path>
+
+Example - Verify whether the signature on a blob artifact would have been verified at a point in time in the past:
+  notation blob verify --at 2024-01-02T15:04:05Z --signature <signature_path> <blob_path>
 `
 	command := &cobra.Command{
 		Use:   "verify [flags] --signature <signature_path> <blob_path>",
@@ -92,6 +106,10 @@ Example - Verify the signature on a blob artifact using a policy statement name:
 	command.Flags().StringVar(&opts.blobMediaType, "media-type", "", "media type of the blob to verify")
 	command.Flags().StringVar(&opts.policyStatementName, "policy-name", "", "policy name to verify against. If not provided, the global policy is used if exists")
 	cmd.SetPflagUserMetadata(command.Flags(), &opts.userMetadata, cmd.PflagUserMetadataVerifyUsage)
+	cmd.SetPflagRevocationMode(command.Flags(), &opts.revocationMode)
+	cmd.SetPflagOCSPTimeout(command.Flags(), &opts.ocspTimeout)
+	cmd.SetPflagCRLTimeout(command.Flags(), &opts.crlTimeout)
+	cmd.SetPflagVerifyAt(command.Flags(), &opts.at)
 	command.MarkFlagRequired("signature")
 	return command
 }
@@ -102,6 +120,14 @@ func runVerify(command *cobra.Command, cmdOpts *blobVerifyOpts) error {
 
 	// initialize
 	displayHandler := display.NewBlobVerifyHandler(cmdOpts.Printer)
+	var at time.Time
+	if command.Flags().Changed(cmd.PflagVerifyAt.Name) {
+		var err error
+		if at, err = cmd.ParseVerifyAt(cmdOpts.at); err != nil {
+			return err
+		}
+		displayHandler.OnHistoricalEvaluation(at)
+	}
 	blobFile, err := os.Open(cmdOpts.blobPath)
 	if err != nil {
 		return err
@@ -112,7 +138,31 @@ func runVerify(command *cobra.Command, cmdOpts *blobVerifyOpts) error {
 	if err != nil {
 		return err
 	}
-	blobVerifier, err := verifier.GetBlobVerifier(ctx)
+	revocationMode, err := clirev.ParseMode(cmdOpts.revocationMode)
+	if err != nil {
+		return err
+	}
+	revocationOpts := clirev.Options{
+		Mode:          revocationMode,
+		OCSPTimeout:   cmdOpts.ocspTimeout,
+		CRLTimeout:    cmdOpts.crlTimeout,
+		CRLCacheStats: &clicrl.Stats{},
+	}
+	defer func() {
+		log.GetLogger(ctx).Infof("CRL cache statistics: %s", revocationOpts.CRLCacheStats)
+	}()
+	if revocationMode == clirev.ModeOffline {
+		revocationOpts.Recorder = &clirev.Recorder{}
+		defer func() {
+			ioutil.PrintUncheckedCerts(os.Stderr, revocationOpts.Recorder.UncheckedCerts())
+		}()
+	}
+	var blobVerifier verifier.Verifier
+	if at.IsZero() {
+		blobVerifier, err = verifier.GetBlobVerifier(ctx, revocationOpts)
+	} else {
+		blobVerifier, err = verifier.GetHistoricalBlobVerifier(ctx, revocationOpts, at)
+	}
 	if err != nil {
 		return err
 	}
@@ -145,6 +195,9 @@ func runVerify(command *cobra.Command, cmdOpts *blobVerifyOpts) error {
 	outcomes := []*notation.VerificationOutcome{outcome}
 	err = ioutil.ComposeBlobVerificationFailurePrintout(outcomes, cmdOpts.blobPath, err)
 	if err != nil {
+		if !at.IsZero() {
+			return fmt.Errorf("historical evaluation at %s: %w", at.Format(time.RFC3339), err)
+		}
 		return err
 	}
 	displayHandler.OnVerifySucceeded(outcomes, cmdOpts.blobPath)
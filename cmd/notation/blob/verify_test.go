@@ -16,14 +16,19 @@ package blob
 import (
 	"reflect"
 	"testing"
+
+	"github.com/notaryproject/notation/pkg/configutil"
 )
 
 func TestVerifyCommand_BasicArgs(t *testing.T) {
 	opts := &blobVerifyOpts{}
 	command := verifyCommand(opts)
 	expected := &blobVerifyOpts{
-		blobPath:      "blob_path",
-		signaturePath: "sig_path",
+		blobPath:       "blob_path",
+		signaturePath:  "sig_path",
+		revocationMode: "online",
+		ocspTimeout:    configutil.DefaultOCSPTimeout,
+		crlTimeout:     configutil.DefaultCRLTimeout,
 	}
 	if err := command.ParseFlags([]string{
 		expected.blobPath,
@@ -42,15 +47,21 @@ func TestVerifyCommand_MoreArgs(t *testing.T) {
 	opts := &blobVerifyOpts{}
 	command := verifyCommand(opts)
 	expected := &blobVerifyOpts{
-		blobPath:      "blob_path",
-		signaturePath: "sig_path",
-		pluginConfig:  []string{"key1=val1", "key2=val2"},
+		blobPath:       "blob_path",
+		signaturePath:  "sig_path",
+		pluginConfig:   []string{"key1=val1", "key2=val2"},
+		revocationMode: "offline",
+		ocspTimeout:    configutil.DefaultOCSPTimeout,
+		crlTimeout:     configutil.DefaultCRLTimeout,
+		at:             "2024-01-02T15:04:05Z",
 	}
 	if err := command.ParseFlags([]string{
 		expected.blobPath,
 		"--signature", expected.signaturePath,
 		"--plugin-config", "key1=val1",
 		"--plugin-config", "key2=val2",
+		"--revocation-mode", "offline",
+		"--at", expected.at,
 	}); err != nil {
 		t.Fatalf("Parse Flag failed: %v", err)
 	}
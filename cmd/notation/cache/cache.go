@@ -0,0 +1,100 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/notaryproject/notation-go/log"
+	"github.com/notaryproject/notation/cmd/notation/internal/cmdutil"
+)
+
+// cacheEntry is an entry of a file cache.
+type cacheEntry interface {
+	// IsStale returns true if the entry cannot be read or has expired at
+	// time now.
+	IsStale(now time.Time) bool
+}
+
+// listCache lists the entries of a file cache with list, and prints them
+// with print if there is any. kind describes a cache entry in the output,
+// such as "CRL".
+func listCache[E cacheEntry](ctx context.Context, kind string, list func() ([]E, error), print func(entries []E, now time.Time) error) error {
+	logger := log.GetLogger(ctx)
+
+	entries, err := list()
+	if err != nil {
+		return err
+	}
+	logger.Debugf("Found %d cached %s(s)", len(entries), kind)
+
+	// write out
+	if len(entries) == 0 {
+		return nil
+	}
+	return print(entries, time.Now())
+}
+
+// clearCache lists the entries of a file cache with list, and deletes them by
+// their names with deleteEntry upon confirmation. Only the stale entries are
+// deleted if staleOnly is set. kind describes a cache entry in the output,
+// such as "CRL".
+func clearCache[E cacheEntry](ctx context.Context, kind string, list func() ([]E, error), name func(E) string, deleteEntry func(name string) error, staleOnly, confirmed bool) error {
+	logger := log.GetLogger(ctx)
+
+	entries, err := list()
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	var names []string
+	for _, entry := range entries {
+		if !staleOnly || entry.IsStale(now) {
+			names = append(names, name(entry))
+		}
+	}
+	if len(names) == 0 {
+		fmt.Printf("No cached %s to clear\n", kind)
+		return nil
+	}
+	prompt := fmt.Sprintf("Are you sure you want to clear %d cached %s(s)?", len(names), kind)
+	confirmed, err = cmdutil.AskForConfirmation(os.Stdin, prompt, confirmed)
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		return nil
+	}
+	for _, name := range names {
+		logger.Debugf("Clearing cached %s %s", kind, name)
+		if err := deleteEntry(name); err != nil {
+			return fmt.Errorf("failed to clear cached %s %s: %w", kind, name, err)
+		}
+	}
+
+	// write out
+	fmt.Printf("Successfully cleared %d cached %s(s)\n", len(names), kind)
+	return nil
+}
+
+// formatTime formats t or returns "-" if t is zero.
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Format(time.RFC3339)
+}
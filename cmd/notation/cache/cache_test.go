@@ -0,0 +1,173 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/notaryproject/notation-core-go/testhelper"
+	"github.com/notaryproject/notation-go/dir"
+)
+
+func TestCRLClearCommand(t *testing.T) {
+	opts := &crlClearOpts{}
+	cmd := crlClearCommand(opts)
+	expected := &crlClearOpts{
+		stale:     true,
+		confirmed: true,
+	}
+	if err := cmd.ParseFlags([]string{
+		"--stale",
+		"-y"}); err != nil {
+		t.Fatalf("Parse Flag failed: %v", err)
+	}
+	if !reflect.DeepEqual(*expected, *opts) {
+		t.Fatalf("Expect crl clear opts: %v, got: %v", expected, opts)
+	}
+}
+
+func TestOCSPClearCommand(t *testing.T) {
+	opts := &ocspClearOpts{}
+	cmd := ocspClearCommand(opts)
+	expected := &ocspClearOpts{
+		stale:     true,
+		confirmed: true,
+	}
+	if err := cmd.ParseFlags([]string{
+		"--stale",
+		"--yes"}); err != nil {
+		t.Fatalf("Parse Flag failed: %v", err)
+	}
+	if !reflect.DeepEqual(*expected, *opts) {
+		t.Fatalf("Expect ocsp clear opts: %v, got: %v", expected, opts)
+	}
+}
+
+type mockEntry struct {
+	name  string
+	stale bool
+}
+
+func (e mockEntry) IsStale(time.Time) bool {
+	return e.stale
+}
+
+func TestClearCache(t *testing.T) {
+	entries := []mockEntry{{name: "a"}, {name: "b", stale: true}, {name: "c", stale: true}}
+	list := func() ([]mockEntry, error) {
+		return entries, nil
+	}
+	entryName := func(entry mockEntry) string { return entry.name }
+
+	t.Run("stale only", func(t *testing.T) {
+		var deleted []string
+		deleteEntry := func(name string) error {
+			deleted = append(deleted, name)
+			return nil
+		}
+		if err := clearCache(context.Background(), "entry", list, entryName, deleteEntry, true, true); err != nil {
+			t.Fatal(err)
+		}
+		if expected := []string{"b", "c"}; !reflect.DeepEqual(deleted, expected) {
+			t.Fatalf("expected deleted entries %v, but got %v", expected, deleted)
+		}
+	})
+
+	t.Run("all", func(t *testing.T) {
+		var deleted []string
+		deleteEntry := func(name string) error {
+			deleted = append(deleted, name)
+			return nil
+		}
+		if err := clearCache(context.Background(), "entry", list, entryName, deleteEntry, false, true); err != nil {
+			t.Fatal(err)
+		}
+		if expected := []string{"a", "b", "c"}; !reflect.DeepEqual(deleted, expected) {
+			t.Fatalf("expected deleted entries %v, but got %v", expected, deleted)
+		}
+	})
+
+	t.Run("delete error", func(t *testing.T) {
+		deleteEntry := func(name string) error {
+			return errors.New("permission denied")
+		}
+		expectedErrMsg := "failed to clear cached entry a: permission denied"
+		if err := clearCache(context.Background(), "entry", list, entryName, deleteEntry, false, true); err == nil || err.Error() != expectedErrMsg {
+			t.Fatalf("expected error %q, but got %v", expectedErrMsg, err)
+		}
+	})
+
+	t.Run("list error", func(t *testing.T) {
+		list := func() ([]mockEntry, error) {
+			return nil, errors.New("list error")
+		}
+		deleteEntry := func(name string) error {
+			t.Fatal("expected no entry to be deleted")
+			return nil
+		}
+		if err := clearCache(context.Background(), "entry", list, entryName, deleteEntry, false, true); err == nil {
+			t.Fatal("expected list error, but got nil")
+		}
+	})
+}
+
+func TestCRLShowCommand_MissingArgs(t *testing.T) {
+	cmd := crlShowCommand(nil)
+	if err := cmd.ParseFlags([]string{}); err != nil {
+		t.Fatalf("Parse Flag failed: %v", err)
+	}
+	if err := cmd.Args(cmd, cmd.Flags().Args()); err == nil {
+		t.Fatal("Parse Args expected error, but ok")
+	}
+}
+
+func TestTrustStoreCRLURLs(t *testing.T) {
+	defer func(oldDir string) {
+		dir.UserConfigDir = oldDir
+	}(dir.UserConfigDir)
+	dir.UserConfigDir = t.TempDir()
+
+	urls, err := trustStoreCRLURLs(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(urls) != 0 {
+		t.Fatalf("expected no CRL URL for empty trust store, but got %v", urls)
+	}
+
+	storeDir := filepath.Join(dir.UserConfigDir, dir.TrustStoreDir, "x509", "ca", "test")
+	if err := os.MkdirAll(storeDir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	chain := testhelper.GetRevokableRSAChainWithRevocations(2, false, true)
+	// the same certificate stored twice yields a single URL
+	for _, name := range []string{"a.crt", "b.crt"} {
+		if err := os.WriteFile(filepath.Join(storeDir, name), chain[0].Cert.Raw, 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	urls, err = trustStoreCRLURLs(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(urls, chain[0].Cert.CRLDistributionPoints) {
+		t.Fatalf("expected CRL URLs %v, but got %v", chain[0].Cert.CRLDistributionPoints, urls)
+	}
+}
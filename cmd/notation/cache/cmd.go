@@ -0,0 +1,63 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import "github.com/spf13/cobra"
+
+func Cmd() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "cache [command]",
+		Short: "Manage the local cache of notation",
+		Long:  "Manage the local cache of notation, such as the cached certificate revocation lists (CRLs) and OCSP responses.",
+	}
+
+	command.AddCommand(
+		crlCommand(),
+		ocspCommand(),
+	)
+
+	return command
+}
+
+func crlCommand() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "crl [command]",
+		Short: "Manage the certificate revocation list (CRL) cache",
+		Long:  "Manage the certificate revocation list (CRL) cache used for revocation checks during signing and verification.",
+	}
+
+	command.AddCommand(
+		crlListCommand(nil),
+		crlShowCommand(nil),
+		crlClearCommand(nil),
+		crlPrefetchCommand(nil),
+	)
+
+	return command
+}
+
+func ocspCommand() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "ocsp [command]",
+		Short: "Manage the OCSP response cache",
+		Long:  "Manage the OCSP response cache used for revocation checks during signing and verification.",
+	}
+
+	command.AddCommand(
+		ocspListCommand(nil),
+		ocspClearCommand(nil),
+	)
+
+	return command
+}
@@ -0,0 +1,67 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+
+	"github.com/notaryproject/notation/internal/cmd"
+	clirev "github.com/notaryproject/notation/internal/revocation"
+	clicrl "github.com/notaryproject/notation/internal/revocation/crl"
+	"github.com/spf13/cobra"
+)
+
+type crlClearOpts struct {
+	cmd.LoggingFlagOpts
+	stale     bool
+	confirmed bool
+}
+
+func crlClearCommand(opts *crlClearOpts) *cobra.Command {
+	if opts == nil {
+		opts = &crlClearOpts{}
+	}
+	command := &cobra.Command{
+		Use:   "clear [flags]",
+		Short: "Clear cached certificate revocation lists",
+		Long: `Clear cached certificate revocation lists (CRLs)
+
+Example - Clear all cached CRLs:
+  notation cache crl clear
+
+Example - Clear cached CRLs that are expired or cannot be parsed, without prompt:
+  notation cache crl clear --stale --yes
+`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return clearCRLs(cmd.Context(), opts)
+		},
+	}
+	opts.LoggingFlagOpts.ApplyFlags(command.Flags())
+	command.Flags().BoolVar(&opts.stale, "stale", false, "only clear cached CRLs that are expired or cannot be parsed")
+	command.Flags().BoolVarP(&opts.confirmed, "yes", "y", false, "do not prompt for confirmation")
+	return command
+}
+
+func clearCRLs(ctx context.Context, opts *crlClearOpts) error {
+	// set log level
+	ctx = opts.LoggingFlagOpts.InitializeLogger(ctx)
+
+	fileCache, err := clirev.NewCRLFileCache()
+	if err != nil {
+		return err
+	}
+	entryName := func(entry *clicrl.Entry) string { return entry.Name }
+	return clearCache(ctx, "CRL", fileCache.List, entryName, fileCache.Delete, opts.stale, opts.confirmed)
+}
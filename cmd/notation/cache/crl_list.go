@@ -0,0 +1,91 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/notaryproject/notation/internal/cmd"
+	clirev "github.com/notaryproject/notation/internal/revocation"
+	clicrl "github.com/notaryproject/notation/internal/revocation/crl"
+	"github.com/spf13/cobra"
+)
+
+type crlListOpts struct {
+	cmd.LoggingFlagOpts
+}
+
+func crlListCommand(opts *crlListOpts) *cobra.Command {
+	if opts == nil {
+		opts = &crlListOpts{}
+	}
+	command := &cobra.Command{
+		Use:     "list [flags]",
+		Aliases: []string{"ls"},
+		Short:   "List cached certificate revocation lists",
+		Long: `List cached certificate revocation lists (CRLs) with their URLs, issuers, this update and next update times
+
+Entries cached by earlier versions of notation have no URL recorded. Entries that are expired or cannot be parsed are reported as stale.
+
+Example - List cached CRLs:
+  notation cache crl ls
+`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return listCRLs(cmd.Context(), opts)
+		},
+	}
+	opts.LoggingFlagOpts.ApplyFlags(command.Flags())
+	return command
+}
+
+func listCRLs(ctx context.Context, opts *crlListOpts) error {
+	// set log level
+	ctx = opts.LoggingFlagOpts.InitializeLogger(ctx)
+
+	fileCache, err := clirev.NewCRLFileCache()
+	if err != nil {
+		return err
+	}
+	return listCache(ctx, "CRL", fileCache.List, printCRLEntries)
+}
+
+func printCRLEntries(entries []*clicrl.Entry, now time.Time) error {
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "URL\tISSUER\tTHIS UPDATE\tNEXT UPDATE\tSTATUS\t")
+	for _, entry := range entries {
+		url := entry.URL
+		if url == "" {
+			url = "<unknown> (" + entry.Name + ")"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t\n", url, entry.Issuer, formatTime(entry.ThisUpdate), formatTime(entry.NextUpdate), crlStatus(entry, now))
+	}
+	return tw.Flush()
+}
+
+// crlStatus returns the status of a cache entry at time now.
+func crlStatus(entry *clicrl.Entry, now time.Time) string {
+	switch {
+	case entry.Err != nil:
+		return "invalid"
+	case entry.IsStale(now):
+		return "stale"
+	default:
+		return "valid"
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"slices"
+
+	corecrl "github.com/notaryproject/notation-core-go/revocation/crl"
+	corex509 "github.com/notaryproject/notation-core-go/x509"
+	"github.com/notaryproject/notation-go/dir"
+	"github.com/notaryproject/notation-go/log"
+	"github.com/notaryproject/notation/cmd/notation/internal/truststore"
+	"github.com/notaryproject/notation/internal/cmd"
+	clirev "github.com/notaryproject/notation/internal/revocation"
+	"github.com/notaryproject/notation/pkg/configutil"
+	"github.com/spf13/cobra"
+)
+
+type crlPrefetchOpts struct {
+	cmd.LoggingFlagOpts
+}
+
+func crlPrefetchCommand(opts *crlPrefetchOpts) *cobra.Command {
+	if opts == nil {
+		opts = &crlPrefetchOpts{}
+	}
+	command := &cobra.Command{
+		Use:   "prefetch [flags]",
+		Short: "Prefetch certificate revocation lists for the trust store",
+		Long: `Prefetch certificate revocation lists (CRLs) for every certificate in the trust store
+
+The CRL distribution points of all certificates in the trust store are downloaded and stored in the CRL cache, replacing any cached CRL of the same URL. Verification on nodes without network access can then check revocation against the warmed cache until the CRLs reach their next update times.
+
+Example - Prefetch CRLs for every certificate in the trust store:
+  notation cache crl prefetch
+`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return prefetchCRLs(cmd.Context(), opts)
+		},
+	}
+	opts.LoggingFlagOpts.ApplyFlags(command.Flags())
+	return command
+}
+
+func prefetchCRLs(ctx context.Context, opts *crlPrefetchOpts) error {
+	// set log level
+	ctx = opts.LoggingFlagOpts.InitializeLogger(ctx)
+	logger := log.GetLogger(ctx)
+
+	urls, err := trustStoreCRLURLs(ctx)
+	if err != nil {
+		return err
+	}
+	if len(urls) == 0 {
+		fmt.Println("No CRL distribution point found in the trust store")
+		return nil
+	}
+	fileCache, err := clirev.NewCRLFileCache()
+	if err != nil {
+		return err
+	}
+	settings, err := configutil.LoadSettingsOnce()
+	if err != nil {
+		return err
+	}
+	httpClient, err := clirev.NewCRLHTTPClient(ctx, settings.Timeouts.CRLTimeout())
+	if err != nil {
+		return err
+	}
+	// the cache is not set to the fetcher so that CRLs are always downloaded
+	fetcher, err := corecrl.NewHTTPFetcher(httpClient)
+	if err != nil {
+		return err
+	}
+
+	var failed int
+	for _, url := range urls {
+		logger.Debugln("Prefetching CRL:", url)
+		bundle, err := fetcher.Fetch(ctx, url)
+		if err == nil {
+			err = fileCache.Set(ctx, url, bundle)
+		}
+		if err != nil {
+			failed++
+			fmt.Printf("Failed to prefetch %s: %v\n", url, err)
+			continue
+		}
+		fmt.Printf("Prefetched %s, next update at %s\n", url, formatTime(bundle.BaseCRL.NextUpdate))
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to prefetch %d of %d CRL(s)", failed, len(urls))
+	}
+	return nil
+}
+
+// trustStoreCRLURLs returns the unique CRL distribution points of all
+// certificates in the trust store in the order they are found.
+func trustStoreCRLURLs(ctx context.Context) ([]string, error) {
+	logger := log.GetLogger(ctx)
+
+	root, err := dir.ConfigFS().SysPath(dir.TrustStoreDir, "x509")
+	if err != nil {
+		return nil, err
+	}
+	certPaths, err := truststore.ListCerts(root, 2)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to list certificates in the trust store: %w", err)
+	}
+	var urls []string
+	for _, path := range certPaths {
+		certs, err := corex509.ReadCertificateFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read certificate file %s: %w", path, err)
+		}
+		for _, cert := range certs {
+			for _, url := range cert.CRLDistributionPoints {
+				if !slices.Contains(urls, url) {
+					logger.Debugf("Found CRL distribution point %s of certificate %q in %s", url, cert.Subject, path)
+					urls = append(urls, url)
+				}
+			}
+		}
+	}
+	return urls, nil
+}
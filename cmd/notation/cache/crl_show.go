@@ -0,0 +1,94 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	corecrl "github.com/notaryproject/notation-core-go/revocation/crl"
+	"github.com/notaryproject/notation-go/log"
+	"github.com/notaryproject/notation/internal/cmd"
+	clirev "github.com/notaryproject/notation/internal/revocation"
+	"github.com/spf13/cobra"
+)
+
+type crlShowOpts struct {
+	cmd.LoggingFlagOpts
+	url string
+}
+
+func crlShowCommand(opts *crlShowOpts) *cobra.Command {
+	if opts == nil {
+		opts = &crlShowOpts{}
+	}
+	command := &cobra.Command{
+		Use:   "show [flags] <url>",
+		Short: "Show details of a cached certificate revocation list",
+		Long: `Show details of a cached certificate revocation list (CRL) given its URL
+
+Example - Show details of the cached CRL of "http://crl.example.com/ca.crl":
+  notation cache crl show http://crl.example.com/ca.crl
+`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return errors.New("show requires exactly one CRL URL")
+			}
+			opts.url = args[0]
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return showCRL(cmd.Context(), opts)
+		},
+	}
+	opts.LoggingFlagOpts.ApplyFlags(command.Flags())
+	return command
+}
+
+func showCRL(ctx context.Context, opts *crlShowOpts) error {
+	// set log level
+	ctx = opts.LoggingFlagOpts.InitializeLogger(ctx)
+	logger := log.GetLogger(ctx)
+
+	fileCache, err := clirev.NewCRLFileCache()
+	if err != nil {
+		return err
+	}
+	logger.Debugf("Looking up the cached CRL for %s", opts.url)
+	entry, err := fileCache.Entry(opts.url)
+	if err != nil {
+		if errors.Is(err, corecrl.ErrCacheMiss) {
+			return fmt.Errorf("no cached CRL found for %s", opts.url)
+		}
+		return err
+	}
+
+	// write out
+	fmt.Println("URL:", entry.URL)
+	fmt.Println("Cache file:", entry.Name)
+	if entry.Err != nil {
+		fmt.Println("Status:", crlStatus(entry, time.Now()))
+		fmt.Println("Error:", entry.Err)
+		return nil
+	}
+	fmt.Println("Issuer:", entry.Issuer)
+	fmt.Println("This update:", formatTime(entry.ThisUpdate))
+	fmt.Println("Next update:", formatTime(entry.NextUpdate))
+	fmt.Println("Revoked certificates:", entry.RevokedCount)
+	fmt.Println("Delta CRL:", entry.HasDeltaCRL)
+	fmt.Println("Status:", crlStatus(entry, time.Now()))
+	return nil
+}
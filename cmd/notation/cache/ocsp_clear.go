@@ -0,0 +1,66 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package cache
+
+import (
+	"context"
+
+	"github.com/notaryproject/notation/internal/cmd"
+	clirev "github.com/notaryproject/notation/internal/revocation"
+	cliocsp "github.com/notaryproject/notation/internal/revocation/ocsp"
+	"github.com/spf13/cobra"
+)
+
+type ocspClearOpts struct {
+	cmd.LoggingFlagOpts
+	stale     bool
+	confirmed bool
+}
+
+func ocspClearCommand(opts *ocspClearOpts) *cobra.Command {
+	if opts == nil {
+		opts = &ocspClearOpts{}
+	}
+	command := &cobra.Command{
+		Use:   "clear [flags]",
+		Short: "Clear cached OCSP responses",
+		Long: `Clear cached OCSP responses
+
+Example - Clear all cached OCSP responses:
+  notation cache ocsp clear
+
+Example - Clear cached OCSP responses that are expired or cannot be read, without prompt:
+  notation cache ocsp clear --stale --yes
+`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return clearOCSPResponses(cmd.Context(), opts)
+		},
+	}
+	opts.LoggingFlagOpts.ApplyFlags(command.Flags())
+	command.Flags().BoolVar(&opts.stale, "stale", false, "only clear cached OCSP responses that are expired or cannot be read")
+	command.Flags().BoolVarP(&opts.confirmed, "yes", "y", false, "do not prompt for confirmation")
+	return command
+}
+
+func clearOCSPResponses(ctx context.Context, opts *ocspClearOpts) error {
+	// set log level
+	ctx = opts.LoggingFlagOpts.InitializeLogger(ctx)
+
+	fileCache, err := clirev.NewOCSPFileCache()
+	if err != nil {
+		return err
+	}
+	entryName := func(entry *cliocsp.Entry) string { return entry.Name }
+	return clearCache(ctx, "OCSP response", fileCache.List, entryName, fileCache.Delete, opts.stale, opts.confirmed)
+}
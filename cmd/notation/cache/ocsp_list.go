@@ -0,0 +1,82 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package cache
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/notaryproject/notation/internal/cmd"
+	clirev "github.com/notaryproject/notation/internal/revocation"
+	cliocsp "github.com/notaryproject/notation/internal/revocation/ocsp"
+	"github.com/spf13/cobra"
+)
+
+type ocspListOpts struct {
+	cmd.LoggingFlagOpts
+}
+
+func ocspListCommand(opts *ocspListOpts) *cobra.Command {
+	if opts == nil {
+		opts = &ocspListOpts{}
+	}
+	command := &cobra.Command{
+		Use:     "list [flags]",
+		Aliases: []string{"ls"},
+		Short:   "List cached OCSP responses",
+		Long: `List cached OCSP responses with their responder URLs, certificate serial numbers, revocation statuses and next update times
+
+Cached responses are used until their next update times. Responses that are expired or cannot be read are reported as stale.
+
+Example - List cached OCSP responses:
+  notation cache ocsp ls
+`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return listOCSPResponses(cmd.Context(), opts)
+		},
+	}
+	opts.LoggingFlagOpts.ApplyFlags(command.Flags())
+	return command
+}
+
+func listOCSPResponses(ctx context.Context, opts *ocspListOpts) error {
+	// set log level
+	ctx = opts.LoggingFlagOpts.InitializeLogger(ctx)
+
+	fileCache, err := clirev.NewOCSPFileCache()
+	if err != nil {
+		return err
+	}
+	return listCache(ctx, "OCSP response", fileCache.List, printOCSPEntries)
+}
+
+func printOCSPEntries(entries []*cliocsp.Entry, now time.Time) error {
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "RESPONDER\tSERIAL NUMBER\tCERT STATUS\tTHIS UPDATE\tNEXT UPDATE\tCACHED UNTIL\tSTATUS\t")
+	for _, entry := range entries {
+		if entry.Err != nil {
+			fmt.Fprintf(tw, "<unknown> (%s)\t-\t-\t-\t-\t-\tinvalid\t\n", entry.Name)
+			continue
+		}
+		status := "valid"
+		if entry.IsStale(now) {
+			status = "stale"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t\n", entry.URL, entry.SerialNumber, entry.Status, formatTime(entry.ThisUpdate), formatTime(entry.NextUpdate), formatTime(entry.Expiry), status)
+	}
+	return tw.Flush()
+}
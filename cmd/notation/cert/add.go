@@ -14,17 +14,28 @@
 package cert
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"os"
 
+	"github.com/notaryproject/notation/cmd/notation/internal/option"
 	"github.com/notaryproject/notation/cmd/notation/internal/truststore"
+	"github.com/notaryproject/notation/internal/cmd"
 	"github.com/spf13/cobra"
 )
 
 type certAddOpts struct {
-	storeType  string
-	namedStore string
-	path       []string
+	cmd.LoggingFlagOpts
+	option.Secure
+	storeType     string
+	namedStore    string
+	path          []string
+	isURL         bool
+	fromRegistry  bool
+	inputChecksum string
+	split         bool
+	name          string
 }
 
 func certAddCommand(opts *certAddOpts) *cobra.Command {
@@ -36,7 +47,20 @@ func certAddCommand(opts *certAddOpts) *cobra.Command {
 		Short: "Add certificates to the trust store.",
 		Args: func(cmd *cobra.Command, args []string) error {
 			if len(args) == 0 {
-				return errors.New("missing certificate path")
+				switch {
+				case opts.isURL:
+					return errors.New("missing certificate URL")
+				case opts.fromRegistry:
+					return errors.New("missing certificate bundle reference")
+				default:
+					return errors.New("missing certificate path")
+				}
+			}
+			if opts.name != "" && len(args) != 1 {
+				return fmt.Errorf("only one certificate file is allowed when \"--name\" is set, but got %d", len(args))
+			}
+			if (opts.isURL || opts.fromRegistry) && len(args) != 1 {
+				return fmt.Errorf("only one certificate source is allowed when \"--url\" or \"--from-registry\" is set, but got %d", len(args))
 			}
 			opts.path = args
 			return nil
@@ -51,19 +75,44 @@ Example - Add a certificate to the "signingAuthority" type of a named store "wab
 
 Example - Add a certificate to the "tsa" type of a named store "timestamp":
   notation cert add --type tsa --store timestamp wabbit-networks-timestamp.pem
+
+Example - Add a certificate as "acme-rockets-root.pem" to the "ca" type of a named store "acme-rockets":
+  notation cert add --type ca --store acme-rockets --name acme-rockets-root.pem root.pem
+
+Example - Add each certificate of a PEM bundle as its own file to the "ca" type of a named store "acme-rockets":
+  notation cert add --type ca --store acme-rockets --split acme-rockets-bundle.pem
+
+Example - Add a certificate from an HTTPS URL to the "ca" type of a named store "acme-rockets", SHA256 checksum is required:
+  notation cert add --type ca --store acme-rockets --url https://acme-rockets.io/certs/acme-rockets.crt --sha256sum 113062a462674a0e35cb5cad75a0bb2ea16e9537025531c0fd705018fcdbc17e
+
+Example - Add certificates of a certificate bundle artifact in a registry to the "ca" type of a named store "acme-rockets":
+  notation cert add --type ca --store acme-rockets --from-registry registry.acme-rockets.io/certs@sha256:113062a462674a0e35cb5cad75a0bb2ea16e9537025531c0fd705018fcdbc17e
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return addCerts(opts)
+			return addCerts(cmd.Context(), opts)
 		},
 	}
+	opts.LoggingFlagOpts.ApplyFlags(command.Flags())
+	opts.Secure.ApplyFlags(command.Flags())
 	command.Flags().StringVarP(&opts.storeType, "type", "t", "", "specify trust store type, options: ca, signingAuthority")
 	command.Flags().StringVarP(&opts.namedStore, "store", "s", "", "specify named store")
+	command.Flags().BoolVar(&opts.isURL, "url", false, fmt.Sprintf("add the certificate file from an HTTPS URL. The download timeout is %s", downloadCertFromURLTimeout))
+	command.Flags().StringVar(&opts.inputChecksum, "sha256sum", "", "must match SHA256 of the certificate file, required when \"--url\" flag is set")
+	command.Flags().BoolVar(&opts.fromRegistry, "from-registry", false, "add the certificate files of a certificate bundle artifact in a registry. Each layer of the artifact with the \"org.opencontainers.image.title\" annotation is a certificate file")
+	command.Flags().BoolVar(&opts.split, "split", false, "add each certificate as its own file named by the subject common name and the SHA256 fingerprint, skipping certificates already in the named store")
+	command.Flags().StringVar(&opts.name, "name", "", "file name of the certificate stored in the named store, default to the name of the certificate file")
 	command.MarkFlagRequired("type")
 	command.MarkFlagRequired("store")
+	command.MarkFlagsMutuallyExclusive("url", "from-registry")
+	command.MarkFlagsMutuallyExclusive("name", "split")
+	command.MarkFlagsMutuallyExclusive("name", "from-registry")
 	return command
 }
 
-func addCerts(opts *certAddOpts) error {
+func addCerts(ctx context.Context, opts *certAddOpts) error {
+	// set log level
+	ctx = opts.LoggingFlagOpts.InitializeLogger(ctx)
+
 	storeType := opts.storeType
 	if storeType == "" {
 		return errors.New("store type cannot be empty")
@@ -75,16 +124,61 @@ func addCerts(opts *certAddOpts) error {
 	if !truststore.IsValidFileName(namedStore) {
 		return errors.New("named store name needs to follow [a-zA-Z0-9_.-]+ format")
 	}
+	if opts.inputChecksum != "" && !opts.isURL {
+		return errors.New("\"--sha256sum\" is only supported when \"--url\" flag is set")
+	}
+
+	// fetch remote certificate files into a temporary directory. sources
+	// are the names of the certificate files displayed to the user.
+	certPaths, sources := opts.path, opts.path
+	if opts.isURL || opts.fromRegistry {
+		tmpDir, err := os.MkdirTemp("", notationCertDownloadTmpDir)
+		if err != nil {
+			return fmt.Errorf("failed to create temporary directory required for downloading certificates: %w", err)
+		}
+		defer os.RemoveAll(tmpDir)
+		if opts.isURL {
+			if opts.inputChecksum == "" {
+				return errors.New("adding certificate from URL requires non-empty SHA256 checksum of the certificate file")
+			}
+			certPath, err := downloadCertFromURL(ctx, opts.path[0], opts.inputChecksum, tmpDir)
+			if err != nil {
+				return err
+			}
+			certPaths = []string{certPath}
+		} else {
+			certPaths, sources, err = pullCertsFromRegistry(ctx, &opts.Secure, opts.path[0], tmpDir)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
 	var success []string
+	var skipped []string
 	var failure []string
 	var errorSlice []error
-	for _, p := range opts.path {
-		err := truststore.AddCert(p, storeType, namedStore, false)
+	for i, p := range certPaths {
+		if opts.split {
+			added, duplicates, err := truststore.AddCertSplit(p, storeType, namedStore)
+			for _, fileName := range added {
+				success = append(success, fmt.Sprintf("%s: %s", sources[i], fileName))
+			}
+			for _, cert := range duplicates {
+				skipped = append(skipped, fmt.Sprintf("%s: %q with SHA256 fingerprint %s", sources[i], cert.Subject, truststore.CertFingerprint(cert)))
+			}
+			if err != nil {
+				failure = append(failure, sources[i])
+				errorSlice = append(errorSlice, err)
+			}
+			continue
+		}
+		err := truststore.AddCertWithName(p, storeType, namedStore, opts.name, false)
 		if err != nil {
-			failure = append(failure, p)
+			failure = append(failure, sources[i])
 			errorSlice = append(errorSlice, err)
 		} else {
-			success = append(success, p)
+			success = append(success, sources[i])
 		}
 	}
 
@@ -95,6 +189,12 @@ func addCerts(opts *certAddOpts) error {
 			fmt.Println(p)
 		}
 	}
+	if len(skipped) != 0 {
+		fmt.Printf("Skipped following certificates already in named store %s of type %s:\n", namedStore, storeType)
+		for _, p := range skipped {
+			fmt.Println(p)
+		}
+	}
 	if len(failure) != 0 {
 		errStr := fmt.Sprintf("Failed to add following certificates to named store %s of type %s:\n", namedStore, storeType)
 
@@ -49,3 +49,116 @@ func TestCertAddCommand_MissingArgs(t *testing.T) {
 		t.Fatal("Parse Args expected error, but ok")
 	}
 }
+
+func TestCertAddCommand_URL(t *testing.T) {
+	opts := &certAddOpts{}
+	cmd := certAddCommand(opts)
+	expected := &certAddOpts{
+		storeType:     "ca",
+		namedStore:    "test",
+		path:          []string{"https://acme-rockets.io/certs/root.crt"},
+		isURL:         true,
+		inputChecksum: "abcd",
+	}
+	if err := cmd.ParseFlags([]string{
+		"-t", "ca",
+		"-s", "test",
+		"--url", "https://acme-rockets.io/certs/root.crt",
+		"--sha256sum", "abcd"}); err != nil {
+		t.Fatalf("Parse Flag failed: %v", err)
+	}
+	if err := cmd.Args(cmd, cmd.Flags().Args()); err != nil {
+		t.Fatalf("Parse Args failed: %v", err)
+	}
+	if !reflect.DeepEqual(*expected, *opts) {
+		t.Fatalf("Expect cert add opts: %v, got: %v", expected, opts)
+	}
+}
+
+func TestCertAddCommand_FromRegistry(t *testing.T) {
+	opts := &certAddOpts{}
+	cmd := certAddCommand(opts)
+	expected := &certAddOpts{
+		storeType:    "ca",
+		namedStore:   "test",
+		path:         []string{"registry.acme-rockets.io/certs:v1"},
+		fromRegistry: true,
+	}
+	if err := cmd.ParseFlags([]string{
+		"-t", "ca",
+		"-s", "test",
+		"--from-registry", "registry.acme-rockets.io/certs:v1"}); err != nil {
+		t.Fatalf("Parse Flag failed: %v", err)
+	}
+	if err := cmd.Args(cmd, cmd.Flags().Args()); err != nil {
+		t.Fatalf("Parse Args failed: %v", err)
+	}
+	if !reflect.DeepEqual(*expected, *opts) {
+		t.Fatalf("Expect cert add opts: %v, got: %v", expected, opts)
+	}
+}
+
+func TestCertAddCommand_MultipleRemoteSources(t *testing.T) {
+	cmd := certAddCommand(nil)
+	if err := cmd.ParseFlags([]string{
+		"-t", "ca",
+		"-s", "test",
+		"--from-registry", "registry.acme-rockets.io/certs:v1", "registry.acme-rockets.io/certs:v2"}); err != nil {
+		t.Fatalf("Parse Flag failed: %v", err)
+	}
+	if err := cmd.Args(cmd, cmd.Flags().Args()); err == nil {
+		t.Fatal("Parse Args expected error, but ok")
+	}
+}
+
+func TestCertAddCommand_Split(t *testing.T) {
+	opts := &certAddOpts{}
+	cmd := certAddCommand(opts)
+	expected := &certAddOpts{
+		storeType:  "ca",
+		namedStore: "test",
+		path:       []string{"bundle.pem"},
+		split:      true,
+	}
+	if err := cmd.ParseFlags([]string{
+		"bundle.pem",
+		"-t", "ca",
+		"-s", "test",
+		"--split"}); err != nil {
+		t.Fatalf("Parse Flag failed: %v", err)
+	}
+	if err := cmd.Args(cmd, cmd.Flags().Args()); err != nil {
+		t.Fatalf("Parse Args failed: %v", err)
+	}
+	if !reflect.DeepEqual(*expected, *opts) {
+		t.Fatalf("Expect cert add opts: %v, got: %v", expected, opts)
+	}
+}
+
+func TestCertAddCommand_Name(t *testing.T) {
+	opts := &certAddOpts{}
+	cmd := certAddCommand(opts)
+	expected := &certAddOpts{
+		storeType:  "ca",
+		namedStore: "test",
+		path:       []string{"root.pem"},
+		name:       "acme-rockets-root.pem",
+	}
+	if err := cmd.ParseFlags([]string{
+		"root.pem",
+		"-t", "ca",
+		"-s", "test",
+		"--name", "acme-rockets-root.pem"}); err != nil {
+		t.Fatalf("Parse Flag failed: %v", err)
+	}
+	if err := cmd.Args(cmd, cmd.Flags().Args()); err != nil {
+		t.Fatalf("Parse Args failed: %v", err)
+	}
+	if !reflect.DeepEqual(*expected, *opts) {
+		t.Fatalf("Expect cert add opts: %v, got: %v", expected, opts)
+	}
+
+	if err := cmd.Args(cmd, []string{"root.pem", "intermediate.pem"}); err == nil {
+		t.Fatal("Parse Args expected error for multiple certificate files with --name, but ok")
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cert
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+	"time"
+
+	"github.com/notaryproject/notation/cmd/notation/internal/truststore"
+	"github.com/notaryproject/notation/internal/cmd"
+	"github.com/spf13/cobra"
+)
+
+// defaultExpiringWithin is the default duration within which certificates are
+// reported as expiring by the cert check command.
+const defaultExpiringWithin = "30d"
+
+type certCheckOpts struct {
+	cmd.LoggingFlagOpts
+	storeType      string
+	namedStore     string
+	expiringWithin string
+}
+
+func certCheckCommand(opts *certCheckOpts) *cobra.Command {
+	if opts == nil {
+		opts = &certCheckOpts{}
+	}
+	command := &cobra.Command{
+		Use:   "check [flags]",
+		Short: "Check certificates in the trust store for expiry",
+		Long: `Check certificates in the trust store for expiry
+
+All certificates in the trust store are parsed, and the certificates expired or expiring within the duration are reported with the store type, the store name and the certificate file. The command fails if any certificate is expired or expiring, so that it can be used to alert before signature verification starts failing.
+
+Example - Check all certificates in the trust store for expiry within 30 days:
+  notation cert check
+
+Example - Check all certificates in the trust store for expiry within 90 days:
+  notation cert check --expiring-within 90d
+
+Example - Check certificates of trust store "acme-rockets" of type "ca" for expiry within 30 days:
+  notation cert check --type ca --store acme-rockets
+`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return checkCerts(cmd.Context(), opts)
+		},
+	}
+	opts.LoggingFlagOpts.ApplyFlags(command.Flags())
+	command.Flags().StringVarP(&opts.storeType, "type", "t", "", "specify trust store type, options: ca, signingAuthority, tsa")
+	command.Flags().StringVarP(&opts.namedStore, "store", "s", "", "specify named store")
+	command.Flags().StringVar(&opts.expiringWithin, "expiring-within", defaultExpiringWithin, "report certificates expired or expiring within the duration, in days(d), hours(h) and/or minutes(m). For example: 30d, 12h")
+	return command
+}
+
+func checkCerts(ctx context.Context, opts *certCheckOpts) error {
+	// set log level
+	ctx = opts.LoggingFlagOpts.InitializeLogger(ctx)
+
+	expiringWithin, err := cmd.ParseDuration(opts.expiringWithin)
+	if err != nil {
+		return fmt.Errorf("invalid value for --expiring-within: %w", err)
+	}
+
+	// core process
+	// unreadable certificate files are reported without failing the check
+	// of the other files
+	files, err := listTrustStoreFiles(ctx, opts.storeType, opts.namedStore, truststore.ListCertFiles)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	expiringCerts := truststore.FindExpiringCerts(files, now, expiringWithin)
+
+	// write out
+	if len(expiringCerts) == 0 {
+		fmt.Printf("No certificate in the trust store expires within %s\n", opts.expiringWithin)
+		return nil
+	}
+	if err := printExpiringCerts(expiringCerts, now); err != nil {
+		return err
+	}
+	var numErrors int
+	for _, expiringCert := range expiringCerts {
+		if expiringCert.Err != nil {
+			numErrors++
+		}
+	}
+	if numErrors == 0 {
+		return fmt.Errorf("found %d certificate(s) expired or expiring within %s in the trust store", len(expiringCerts), opts.expiringWithin)
+	}
+	return fmt.Errorf("found %d certificate(s) expired or expiring within %s and %d unreadable certificate file(s) in the trust store", len(expiringCerts)-numErrors, opts.expiringWithin, numErrors)
+}
+
+func printExpiringCerts(expiringCerts []truststore.ExpiringCert, now time.Time) error {
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "STORE TYPE\tSTORE NAME\tCERTIFICATE\tSUBJECT\tEXPIRY\tSTATUS\t")
+	for _, expiringCert := range expiringCerts {
+		fileName := filepath.Base(expiringCert.Path)
+		storeDir := filepath.Dir(expiringCert.Path)
+		namedStore := filepath.Base(storeDir)
+		storeType := filepath.Base(filepath.Dir(storeDir))
+		if expiringCert.Err != nil {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t\n", storeType, namedStore, fileName, "-", "-", fmt.Sprintf("error: %v", errors.Unwrap(expiringCert.Err)))
+			continue
+		}
+		notAfter := expiringCert.Cert.NotAfter
+		status := "expired"
+		if notAfter.After(now) {
+			status = fmt.Sprintf("expires in %d days", int(notAfter.Sub(now).Hours()/24))
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t\n", storeType, namedStore, fileName, expiringCert.Cert.Subject, notAfter.Format(time.ANSIC), status)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,62 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cert
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestCertCheckCommand(t *testing.T) {
+	opts := &certCheckOpts{}
+	cmd := certCheckCommand(opts)
+	expected := &certCheckOpts{
+		storeType:      "ca",
+		namedStore:     "test",
+		expiringWithin: "90d",
+	}
+	if err := cmd.ParseFlags([]string{
+		"-t", "ca",
+		"-s", "test",
+		"--expiring-within", "90d"}); err != nil {
+		t.Fatalf("Parse Flag failed: %v", err)
+	}
+	if err := cmd.Args(cmd, cmd.Flags().Args()); err != nil {
+		t.Fatalf("Parse Args failed: %v", err)
+	}
+	if !reflect.DeepEqual(*expected, *opts) {
+		t.Fatalf("Expect cert check opts: %v, got: %v", expected, opts)
+	}
+}
+
+func TestCertCheckCommand_Default(t *testing.T) {
+	opts := &certCheckOpts{}
+	cmd := certCheckCommand(opts)
+	if err := cmd.ParseFlags(nil); err != nil {
+		t.Fatalf("Parse Flag failed: %v", err)
+	}
+	if opts.expiringWithin != defaultExpiringWithin {
+		t.Fatalf("Expect expiring within %q, got: %q", defaultExpiringWithin, opts.expiringWithin)
+	}
+}
+
+func TestCheckCerts_InvalidDuration(t *testing.T) {
+	opts := &certCheckOpts{
+		expiringWithin: "30x",
+	}
+	if err := checkCerts(context.Background(), opts); err == nil {
+		t.Fatal("expected error for invalid duration, but ok")
+	}
+}
@@ -28,6 +28,11 @@ func Cmd() *cobra.Command {
 		certListCommand(nil),
 		certShowCommand(nil),
 		certDeleteCommand(nil),
+		certVerifyCommand(nil),
+		certCheckCommand(nil),
+		certRevocationCheckCommand(nil),
+		certExportCommand(nil),
+		certImportBundleCommand(nil),
 		certGenerateTestCommand(nil),
 	)
 
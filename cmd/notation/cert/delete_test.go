@@ -51,3 +51,39 @@ func TestCertDeleteCommand_MissingArgs(t *testing.T) {
 		t.Fatal("Parse Args expected error, but ok")
 	}
 }
+
+func TestCertDeleteCommand_Fingerprint(t *testing.T) {
+	opts := &certDeleteOpts{}
+	cmd := certDeleteCommand(opts)
+	expected := &certDeleteOpts{
+		storeType:   "ca",
+		namedStore:  "test",
+		fingerprint: "01937612e3e24e6496b325fb899fdb3b7045350ab2d91141c6310a917d5cebe2",
+	}
+	if err := cmd.ParseFlags([]string{
+		"-t", "ca",
+		"-s", "test",
+		"--fingerprint", "01937612e3e24e6496b325fb899fdb3b7045350ab2d91141c6310a917d5cebe2"}); err != nil {
+		t.Fatalf("Parse Flag failed: %v", err)
+	}
+	if err := cmd.Args(cmd, cmd.Flags().Args()); err != nil {
+		t.Fatalf("Parse Args failed: %v", err)
+	}
+	if !reflect.DeepEqual(*expected, *opts) {
+		t.Fatalf("Expect cert delete opts: %v, got: %v", expected, opts)
+	}
+}
+
+func TestCertDeleteCommand_FingerprintWithFileName(t *testing.T) {
+	cmd := certDeleteCommand(nil)
+	if err := cmd.ParseFlags([]string{
+		"test.crt",
+		"-t", "ca",
+		"-s", "test",
+		"--fingerprint", "01937612e3e24e6496b325fb899fdb3b7045350ab2d91141c6310a917d5cebe2"}); err != nil {
+		t.Fatalf("Parse Flag failed: %v", err)
+	}
+	if err := cmd.Args(cmd, cmd.Flags().Args()); err == nil {
+		t.Fatal("Parse Args expected error, but ok")
+	}
+}
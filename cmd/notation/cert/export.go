@@ -0,0 +1,209 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cert
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/notaryproject/notation-go/dir"
+	"github.com/notaryproject/notation/cmd/notation/internal/truststore"
+	"github.com/notaryproject/notation/internal/cmd"
+	"github.com/spf13/cobra"
+)
+
+// bundleFile is a file of the trust configuration in a trust store bundle.
+type bundleFile struct {
+	// name is the slash-separated path of the file relative to the notation
+	// configuration directory, which is also the entry name in the bundle.
+	name string
+
+	// path is the path of the file on the file system.
+	path string
+}
+
+type certExportOpts struct {
+	cmd.LoggingFlagOpts
+	storeType          string
+	namedStore         string
+	output             string
+	includeTrustPolicy bool
+}
+
+func certExportCommand(opts *certExportOpts) *cobra.Command {
+	if opts == nil {
+		opts = &certExportOpts{}
+	}
+	command := &cobra.Command{
+		Use:   "export --output <bundle_path> [flags]",
+		Short: "Export certificates in the trust store to a bundle",
+		Long: `Export certificates in the trust store to a bundle
+
+The certificate files are archived into a gzip compressed tar file, keeping the layout of the "truststore/x509" directory, so that the bundle can be imported on another machine with "notation cert import-bundle". The OCI and blob trust policies are archived as well if "--include-trust-policy" is set.
+
+Example - Export all certificates in the trust store to "bundle.tar.gz":
+  notation cert export --output bundle.tar.gz
+
+Example - Export all certificates and the trust policies to "bundle.tar.gz":
+  notation cert export --include-trust-policy --output bundle.tar.gz
+
+Example - Export certificates of trust store "acme-rockets" of type "ca" to "bundle.tar.gz":
+  notation cert export --type ca --store acme-rockets --output bundle.tar.gz
+`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return exportCerts(cmd.Context(), opts)
+		},
+	}
+	opts.LoggingFlagOpts.ApplyFlags(command.Flags())
+	command.Flags().StringVarP(&opts.storeType, "type", "t", "", "specify trust store type, options: ca, signingAuthority, tsa")
+	command.Flags().StringVarP(&opts.namedStore, "store", "s", "", "specify named store")
+	command.Flags().StringVar(&opts.output, "output", "", "path of the bundle file to create")
+	command.Flags().BoolVar(&opts.includeTrustPolicy, "include-trust-policy", false, "include the OCI and blob trust policies in the bundle")
+	command.MarkFlagRequired("output")
+	return command
+}
+
+func exportCerts(ctx context.Context, opts *certExportOpts) error {
+	// set log level
+	ctx = opts.LoggingFlagOpts.InitializeLogger(ctx)
+
+	if opts.storeType != "" && !truststore.IsValidStoreType(opts.storeType) {
+		return fmt.Errorf("unsupported store type: %s", opts.storeType)
+	}
+
+	// core process
+	certPaths, err := listCertPaths(ctx, opts.storeType, opts.namedStore)
+	if err != nil {
+		return err
+	}
+	var files []bundleFile
+	for _, certPath := range certPaths {
+		storeDir := filepath.Dir(certPath)
+		namedStore := filepath.Base(storeDir)
+		storeType := filepath.Base(filepath.Dir(storeDir))
+		files = append(files, bundleFile{
+			name: path.Join(dir.TrustStoreDir, "x509", storeType, namedStore, filepath.Base(certPath)),
+			path: certPath,
+		})
+	}
+	certCount := len(files)
+	if opts.includeTrustPolicy {
+		policyFiles, err := trustPolicyFiles()
+		if err != nil {
+			return err
+		}
+		files = append(files, policyFiles...)
+	}
+	if len(files) == 0 {
+		return errors.New("no certificate or trust policy found to export")
+	}
+	if err := writeBundle(opts.output, files); err != nil {
+		return fmt.Errorf("failed to export to %s: %w", opts.output, err)
+	}
+
+	// write out
+	fmt.Printf("Successfully exported %d certificate file(s) and %d trust policy file(s) to %s\n", certCount, len(files)-certCount, opts.output)
+	return nil
+}
+
+// trustPolicyFiles returns the OCI and blob trust policy files that exist.
+// The old OCI trust policy file "trustpolicy.json" is exported as
+// "trustpolicy.oci.json" if the latter does not exist.
+func trustPolicyFiles() ([]bundleFile, error) {
+	var files []bundleFile
+	for _, candidates := range [][]string{
+		{dir.PathOCITrustPolicy, dir.PathTrustPolicy},
+		{dir.PathBlobTrustPolicy},
+	} {
+		for _, name := range candidates {
+			policyPath, err := dir.ConfigFS().SysPath(name)
+			if err != nil {
+				return nil, err
+			}
+			info, err := os.Stat(policyPath)
+			if err != nil {
+				if errors.Is(err, fs.ErrNotExist) {
+					continue
+				}
+				return nil, err
+			}
+			if !info.Mode().IsRegular() {
+				return nil, fmt.Errorf("trust policy %s is not a regular file", policyPath)
+			}
+			files = append(files, bundleFile{
+				name: candidates[0],
+				path: policyPath,
+			})
+			break
+		}
+	}
+	return files, nil
+}
+
+// writeBundle writes files into a new gzip compressed tar file at
+// bundlePath. The bundle is removed on failure.
+func writeBundle(bundlePath string, files []bundleFile) (err error) {
+	out, err := os.OpenFile(bundlePath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		if errors.Is(err, fs.ErrExist) {
+			return errors.New("file already exists")
+		}
+		return err
+	}
+	defer func() {
+		if closeErr := out.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			os.Remove(bundlePath)
+		}
+	}()
+
+	gzipWriter := gzip.NewWriter(out)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for _, file := range files {
+		data, err := os.ReadFile(file.path)
+		if err != nil {
+			return err
+		}
+		info, err := os.Stat(file.path)
+		if err != nil {
+			return err
+		}
+		if err := tarWriter.WriteHeader(&tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     file.name,
+			Mode:     0600,
+			Size:     int64(len(data)),
+			ModTime:  info.ModTime(),
+		}); err != nil {
+			return err
+		}
+		if _, err := tarWriter.Write(data); err != nil {
+			return err
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		return err
+	}
+	return gzipWriter.Close()
+}
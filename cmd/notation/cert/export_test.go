@@ -0,0 +1,153 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cert
+
+import (
+	"context"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/notaryproject/notation-core-go/testhelper"
+	"github.com/notaryproject/notation-go/dir"
+)
+
+const testOCITrustPolicy = `{"version":"1.0","trustPolicies":[{"name":"test","registryScopes":["*"],"signatureVerification":{"level":"strict"},"trustStores":["ca:test"],"trustedIdentities":["*"]}]}`
+
+func TestCertExportCommand(t *testing.T) {
+	opts := &certExportOpts{}
+	cmd := certExportCommand(opts)
+	expected := &certExportOpts{
+		storeType:          "ca",
+		namedStore:         "test",
+		output:             "bundle.tar.gz",
+		includeTrustPolicy: true,
+	}
+	if err := cmd.ParseFlags([]string{
+		"-t", "ca",
+		"-s", "test",
+		"--output", "bundle.tar.gz",
+		"--include-trust-policy"}); err != nil {
+		t.Fatalf("Parse Flag failed: %v", err)
+	}
+	if err := cmd.Args(cmd, cmd.Flags().Args()); err != nil {
+		t.Fatalf("Parse Args failed: %v", err)
+	}
+	if !reflect.DeepEqual(*expected, *opts) {
+		t.Fatalf("Expect cert export opts: %v, got: %v", expected, opts)
+	}
+}
+
+func TestExportCerts(t *testing.T) {
+	defer func(oldDir string) {
+		dir.UserConfigDir = oldDir
+	}(dir.UserConfigDir)
+	dir.UserConfigDir = t.TempDir()
+	writeTestTrustStore(t)
+
+	t.Run("invalid store type", func(t *testing.T) {
+		opts := &certExportOpts{
+			storeType: "invalid",
+			output:    filepath.Join(t.TempDir(), "bundle.tar.gz"),
+		}
+		expectedErrMsg := "unsupported store type: invalid"
+		if err := exportCerts(context.Background(), opts); err == nil || err.Error() != expectedErrMsg {
+			t.Fatalf("expected error %q, but got %v", expectedErrMsg, err)
+		}
+	})
+
+	t.Run("nothing to export", func(t *testing.T) {
+		opts := &certExportOpts{
+			namedStore: "non-existent",
+			output:     filepath.Join(t.TempDir(), "bundle.tar.gz"),
+		}
+		expectedErrMsg := "no certificate or trust policy found to export"
+		if err := exportCerts(context.Background(), opts); err == nil || err.Error() != expectedErrMsg {
+			t.Fatalf("expected error %q, but got %v", expectedErrMsg, err)
+		}
+		if _, err := os.Stat(opts.output); !os.IsNotExist(err) {
+			t.Fatalf("expected no bundle to be created, but got %v", err)
+		}
+	})
+
+	t.Run("output already exists", func(t *testing.T) {
+		output := filepath.Join(t.TempDir(), "bundle.tar.gz")
+		if err := os.WriteFile(output, []byte("existing"), 0600); err != nil {
+			t.Fatal(err)
+		}
+		opts := &certExportOpts{
+			output: output,
+		}
+		if err := exportCerts(context.Background(), opts); err == nil {
+			t.Fatal("expected error for existing output file, but ok")
+		}
+		data, err := os.ReadFile(output)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != "existing" {
+			t.Fatal("expected existing output file to be kept")
+		}
+	})
+
+	t.Run("filter by store type", func(t *testing.T) {
+		output := filepath.Join(t.TempDir(), "bundle.tar.gz")
+		opts := &certExportOpts{
+			storeType: "tsa",
+			output:    output,
+		}
+		if err := exportCerts(context.Background(), opts); err != nil {
+			t.Fatalf("exportCerts() failed: %v", err)
+		}
+		entries, err := readBundle(output)
+		if err != nil {
+			t.Fatalf("readBundle() failed: %v", err)
+		}
+		if len(entries) != 1 || entries[0].name != "truststore/x509/tsa/test/tsa.pem" {
+			t.Fatalf("expected only the tsa certificate to be exported, but got %v", entryNames(entries))
+		}
+	})
+}
+
+// writeTestTrustStore writes a ca certificate, a tsa certificate and an OCI
+// trust policy to the notation configuration directory.
+func writeTestTrustStore(t *testing.T) {
+	t.Helper()
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: testhelper.GetRSARootCertificate().Cert.Raw})
+	for _, elems := range [][]string{
+		{dir.TrustStoreDir, "x509", "ca", "test", "ca.pem"},
+		{dir.TrustStoreDir, "x509", "tsa", "test", "tsa.pem"},
+	} {
+		certPath := filepath.Join(append([]string{dir.UserConfigDir}, elems...)...)
+		if err := os.MkdirAll(filepath.Dir(certPath), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(certPath, certPEM, 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir.UserConfigDir, dir.PathOCITrustPolicy), []byte(testOCITrustPolicy), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func entryNames(entries []bundleEntry) []string {
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.name)
+	}
+	return names
+}
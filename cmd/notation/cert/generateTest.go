@@ -14,23 +14,46 @@
 package cert
 
 import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
 	"encoding/pem"
 	"errors"
 	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
-	"github.com/notaryproject/notation-core-go/testhelper"
 	"github.com/notaryproject/notation-go/config"
 	"github.com/notaryproject/notation-go/dir"
 	"github.com/notaryproject/notation/cmd/notation/internal/truststore"
+	"github.com/notaryproject/notation/internal/cmd"
 	"github.com/notaryproject/notation/internal/osutil"
+	"github.com/notaryproject/notation/internal/tsa"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 )
 
+const (
+	// algorithmRSA is the RSA key algorithm
+	algorithmRSA = "rsa"
+
+	// algorithmECDSA is the ECDSA key algorithm
+	algorithmECDSA = "ecdsa"
+)
+
+var (
+	oidExtensionExtendedKeyUsage = asn1.ObjectIdentifier{2, 5, 29, 37}
+	oidExtKeyUsageTimeStamping   = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 3, 8}
+)
+
 var (
 	keyDefaultFlag = &pflag.Flag{
 		Name:  "default",
@@ -45,6 +68,11 @@ type certGenerateTestOpts struct {
 	name      string
 	bits      int
 	isDefault bool
+	algorithm string
+	validity  string
+	subject   string
+	chain     bool
+	tsa       bool
 }
 
 func certGenerateTestCommand(opts *certGenerateTestOpts) *cobra.Command {
@@ -53,7 +81,7 @@ func certGenerateTestCommand(opts *certGenerateTestOpts) *cobra.Command {
 	}
 	command := &cobra.Command{
 		Use:   "generate-test [flags] <common_name>",
-		Short: "Generate a test RSA key and a corresponding self-signed certificate.",
+		Short: "Generate a test key and a corresponding self-signed certificate or certificate chain, or a test timestamping authority.",
 		Args: func(cmd *cobra.Command, args []string) error {
 			if len(args) == 0 {
 				return errors.New("missing certificate common_name")
@@ -61,21 +89,43 @@ func certGenerateTestCommand(opts *certGenerateTestOpts) *cobra.Command {
 			opts.name = args[0]
 			return nil
 		},
-		Long: `Generate a test RSA key and a corresponding self-signed certificate
+		Long: `Generate a test key and a corresponding self-signed certificate or certificate chain, or a test timestamping authority
 
 Example - Generate a test RSA key and a corresponding self-signed certificate named "wabbit-networks.io":
   notation cert generate-test "wabbit-networks.io"
 
 Example - Generate a test RSA key and a corresponding self-signed certificate, set RSA key as a default signing key:
   notation cert generate-test --default "wabbit-networks.io"
+
+Example - Generate a test ECDSA P-384 key and a corresponding self-signed certificate valid for 30 days:
+  notation cert generate-test --algorithm ecdsa --bits 384 --validity 30d "wabbit-networks.io"
+
+Example - Generate a test RSA key and a corresponding self-signed certificate with a full subject:
+  notation cert generate-test --subject "CN=wabbit-networks.io,O=Wabbit Networks,OU=Engineering,L=Seattle,ST=WA,C=US" "wabbit-networks.io"
+
+Example - Generate a test root CA, intermediate CA and leaf certificate, and add the root CA certificate to the trust store:
+  notation cert generate-test --chain "wabbit-networks.io"
+
+Example - Generate a test timestamping authority, and add its root CA certificate to the trust store of type tsa:
+  notation cert generate-test --tsa "wabbit-networks-tsa"
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if !cmd.Flags().Changed("bits") && strings.ToLower(opts.algorithm) == algorithmECDSA {
+				opts.bits = 384
+			}
 			return generateTestCert(opts)
 		},
 	}
 
-	command.Flags().IntVarP(&opts.bits, "bits", "b", 2048, "RSA key bits")
+	command.Flags().IntVarP(&opts.bits, "bits", "b", 2048, "key size in bits, options for RSA: 2048, 3072, 4096; options for ECDSA: 256, 384, 521 (default to 384 for ECDSA)")
+	command.Flags().StringVar(&opts.algorithm, "algorithm", algorithmRSA, "key algorithm, options: \"rsa\", \"ecdsa\"")
+	command.Flags().StringVar(&opts.validity, "validity", "1d", "validity period of the generated certificates, in days(d), hours(h) and/or minutes(m). For example: 30d, 1d12h, 3h20m")
+	command.Flags().StringVar(&opts.subject, "subject", "", "distinguished name of the leaf certificate, for example: \"CN=wabbit-networks.io,O=Notary,L=Seattle,ST=WA,C=US\". Supported attributes: C, ST, L, O, OU, CN. The common name defaults to <common_name>")
+	command.Flags().BoolVar(&opts.chain, "chain", false, "generate a root CA, an intermediate CA and a leaf certificate instead of a self-signed certificate")
+	command.Flags().BoolVar(&opts.tsa, "tsa", false, "generate a root CA and a timestamping certificate for a local test timestamping authority served by \"notation tsa serve\"")
 	setKeyDefaultFlag(command.Flags(), &opts.isDefault)
+	command.MarkFlagsMutuallyExclusive("tsa", "chain")
+	command.MarkFlagsMutuallyExclusive("tsa", "default")
 	return command
 }
 
@@ -85,20 +135,44 @@ func generateTestCert(opts *certGenerateTestOpts) error {
 	if !truststore.IsValidFileName(name) {
 		return errors.New("name needs to follow [a-zA-Z0-9_.-]+ format")
 	}
+	algorithm := strings.ToLower(opts.algorithm)
+	validity, err := cmd.ParseDuration(opts.validity)
+	if err != nil {
+		return err
+	}
+	if validity <= 0 {
+		return errors.New("validity must be a positive duration")
+	}
+	subject, err := parseSubject(opts.subject, name)
+	if err != nil {
+		return err
+	}
 
-	// generate RSA private key
+	// generate private key
 	bits := opts.bits
-	fmt.Println("generating RSA Key with", bits, "bits")
-	key, keyBytes, err := generateTestKey(bits)
+	fmt.Printf("generating %s Key with %d bits\n", strings.ToUpper(algorithm), bits)
+	key, keyBytes, err := generateTestKey(algorithm, bits)
 	if err != nil {
 		return err
 	}
 
-	rsaCertTuple, certBytes, err := generateSelfSignedCert(key, name)
+	if opts.tsa {
+		return generateTestTSA(name, key, keyBytes, algorithm, bits, subject, validity)
+	}
+
+	// generate certificates
+	var certChain []*x509.Certificate
+	if opts.chain {
+		certChain, err = generateCertChain(key, algorithm, bits, subject, validity)
+	} else {
+		var cert *x509.Certificate
+		cert, err = generateSelfSignedCert(key, subject, validity)
+		certChain = []*x509.Certificate{cert}
+	}
 	if err != nil {
 		return err
 	}
-	fmt.Println("generated certificate expiring on", rsaCertTuple.Cert.NotAfter.Format(time.RFC3339))
+	fmt.Println("generated certificate expiring on", certChain[0].NotAfter.Format(time.RFC3339))
 
 	// write private key
 	relativeKeyPath, relativeCertPath := dir.LocalKeyPath(name)
@@ -116,8 +190,8 @@ func generateTestCert(opts *certGenerateTestOpts) error {
 	}
 	fmt.Println("wrote key:", keyPath)
 
-	// write the self-signed certificate
-	if err := osutil.WriteFileWithPermission(certPath, certBytes, 0644, false); err != nil {
+	// write the certificate or the certificate chain
+	if err := osutil.WriteFileWithPermission(certPath, generateCertPEM(certChain...), 0644, false); err != nil {
 		return fmt.Errorf("failed to write certificate file: %v", err)
 	}
 	fmt.Println("wrote certificate:", certPath)
@@ -131,8 +205,14 @@ func generateTestCert(opts *certGenerateTestOpts) error {
 	}
 
 	// Add to the trust store
-	if err := truststore.AddCert(certPath, "ca", name, true); err != nil {
-		return err
+	if opts.chain {
+		if err := addRootCert(certChain[len(certChain)-1], "ca", name); err != nil {
+			return err
+		}
+	} else {
+		if err := truststore.AddCert(certPath, "ca", name, true); err != nil {
+			return err
+		}
 	}
 
 	// write out
@@ -143,8 +223,108 @@ func generateTestCert(opts *certGenerateTestOpts) error {
 	return nil
 }
 
-func generateTestKey(bits int) (*rsa.PrivateKey, []byte, error) {
-	key, err := rsa.GenerateKey(rand.Reader, bits)
+// generateTestTSA generates a root CA certificate and a timestamping
+// certificate for a local test TSA, and adds the root CA certificate to the
+// named store name of type tsa.
+func generateTestTSA(name string, key crypto.Signer, keyBytes []byte, algorithm string, bits int, subject pkix.Name, validity time.Duration) error {
+	certChain, err := generateTSACertChain(key, algorithm, bits, subject, validity)
+	if err != nil {
+		return err
+	}
+	fmt.Println("generated timestamping certificate expiring on", certChain[0].NotAfter.Format(time.RFC3339))
+
+	relativeKeyPath, relativeCertPath := tsa.LocalTSAPath(name)
+	configFS := dir.ConfigFS()
+	keyPath, err := configFS.SysPath(relativeKeyPath)
+	if err != nil {
+		return err
+	}
+	certPath, err := configFS.SysPath(relativeCertPath)
+	if err != nil {
+		return err
+	}
+	if err := osutil.WriteFileWithPermission(keyPath, keyBytes, 0600, false); err != nil {
+		return fmt.Errorf("failed to write key file: %v", err)
+	}
+	fmt.Println("wrote key:", keyPath)
+	if err := osutil.WriteFileWithPermission(certPath, generateCertPEM(certChain...), 0644, false); err != nil {
+		return fmt.Errorf("failed to write certificate file: %v", err)
+	}
+	fmt.Println("wrote certificate:", certPath)
+
+	if err := addRootCert(certChain[len(certChain)-1], "tsa", name); err != nil {
+		return err
+	}
+	rootCertPath, err := configFS.SysPath(dir.TrustStoreDir, "x509", "tsa", name, name+dir.LocalCertificateExtension)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s: use %q as the timestamp root certificate\n", name, rootCertPath)
+	fmt.Printf("%s: run \"notation tsa serve %s\" to start the timestamping authority\n", name, name)
+	return nil
+}
+
+// generateTSACertChain generates a root CA certificate and a timestamping
+// certificate for tsaKey. The returned chain is ordered from the timestamping
+// certificate to the root certificate.
+func generateTSACertChain(tsaKey crypto.Signer, algorithm string, bits int, subject pkix.Name, validity time.Duration) ([]*x509.Certificate, error) {
+	rootKey, _, err := generateTestKey(algorithm, bits)
+	if err != nil {
+		return nil, err
+	}
+	rootTemplate, err := newCertTemplate(caSubject(subject, "Root CA"), validity)
+	if err != nil {
+		return nil, err
+	}
+	setCACertConstraints(rootTemplate, 0)
+	rootCert, err := createCert(rootTemplate, rootTemplate, rootKey.Public(), rootKey)
+	if err != nil {
+		return nil, err
+	}
+
+	tsaTemplate, err := newCertTemplate(subject, validity)
+	if err != nil {
+		return nil, err
+	}
+	if err := setTSACertConstraints(tsaTemplate); err != nil {
+		return nil, err
+	}
+	tsaCert, err := createCert(tsaTemplate, rootCert, tsaKey.Public(), rootKey)
+	if err != nil {
+		return nil, err
+	}
+	return []*x509.Certificate{tsaCert, rootCert}, nil
+}
+
+// generateTestKey generates a private key given the algorithm and the key
+// size, and returns the key along with its PEM encoding.
+func generateTestKey(algorithm string, bits int) (crypto.Signer, []byte, error) {
+	var key crypto.Signer
+	var err error
+	switch algorithm {
+	case algorithmRSA:
+		switch bits {
+		case 2048, 3072, 4096:
+		default:
+			return nil, nil, fmt.Errorf("unsupported RSA key size %d, options: 2048, 3072, 4096", bits)
+		}
+		key, err = rsa.GenerateKey(rand.Reader, bits)
+	case algorithmECDSA:
+		var curve elliptic.Curve
+		switch bits {
+		case 256:
+			curve = elliptic.P256()
+		case 384:
+			curve = elliptic.P384()
+		case 521:
+			curve = elliptic.P521()
+		default:
+			return nil, nil, fmt.Errorf("unsupported ECDSA key size %d, options: 256, 384, 521", bits)
+		}
+		key, err = ecdsa.GenerateKey(curve, rand.Reader)
+	default:
+		return nil, nil, fmt.Errorf("unsupported key algorithm %q, options: %q, %q", algorithm, algorithmRSA, algorithmECDSA)
+	}
 	if err != nil {
 		return nil, nil, err
 	}
@@ -156,12 +336,221 @@ func generateTestKey(bits int) (*rsa.PrivateKey, []byte, error) {
 	return key, keyPEM, nil
 }
 
-func generateCertPEM(rsaCertTuple *testhelper.RSACertTuple) []byte {
-	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: rsaCertTuple.Cert.Raw})
+// generateCertPEM encodes certificates in PEM format
+func generateCertPEM(certs ...*x509.Certificate) []byte {
+	var certPEM []byte
+	for _, cert := range certs {
+		certPEM = append(certPEM, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})...)
+	}
+	return certPEM
+}
+
+// generateSelfSignedCert generates a self-signed non-CA certificate
+func generateSelfSignedCert(key crypto.Signer, subject pkix.Name, validity time.Duration) (*x509.Certificate, error) {
+	template, err := newCertTemplate(subject, validity)
+	if err != nil {
+		return nil, err
+	}
+	setLeafCertConstraints(template)
+	return createCert(template, template, key.Public(), key)
+}
+
+// generateCertChain generates a root CA certificate, an intermediate CA
+// certificate and a leaf certificate for leafKey. The returned chain is
+// ordered from the leaf certificate to the root certificate.
+func generateCertChain(leafKey crypto.Signer, algorithm string, bits int, subject pkix.Name, validity time.Duration) ([]*x509.Certificate, error) {
+	// root CA
+	rootKey, _, err := generateTestKey(algorithm, bits)
+	if err != nil {
+		return nil, err
+	}
+	rootTemplate, err := newCertTemplate(caSubject(subject, "Root CA"), validity)
+	if err != nil {
+		return nil, err
+	}
+	setCACertConstraints(rootTemplate, 1)
+	rootCert, err := createCert(rootTemplate, rootTemplate, rootKey.Public(), rootKey)
+	if err != nil {
+		return nil, err
+	}
+
+	// intermediate CA
+	intermediateKey, _, err := generateTestKey(algorithm, bits)
+	if err != nil {
+		return nil, err
+	}
+	intermediateTemplate, err := newCertTemplate(caSubject(subject, "Intermediate CA"), validity)
+	if err != nil {
+		return nil, err
+	}
+	setCACertConstraints(intermediateTemplate, 0)
+	intermediateCert, err := createCert(intermediateTemplate, rootCert, intermediateKey.Public(), rootKey)
+	if err != nil {
+		return nil, err
+	}
+
+	// leaf
+	leafTemplate, err := newCertTemplate(subject, validity)
+	if err != nil {
+		return nil, err
+	}
+	setLeafCertConstraints(leafTemplate)
+	leafTemplate.BasicConstraintsValid = true
+	leafCert, err := createCert(leafTemplate, intermediateCert, leafKey.Public(), intermediateKey)
+	if err != nil {
+		return nil, err
+	}
+	return []*x509.Certificate{leafCert, intermediateCert, rootCert}, nil
+}
+
+// newCertTemplate returns a certificate template with a random serial number
+// and the validity period starting from now.
+func newCertTemplate(subject pkix.Name, validity time.Duration) (*x509.Certificate, error) {
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	return &x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject:      subject,
+		NotBefore:    now,
+		NotAfter:     now.Add(validity),
+	}, nil
+}
+
+// setLeafCertConstraints sets the key usage and extended key usage required
+// for a code signing certificate.
+func setLeafCertConstraints(template *x509.Certificate) {
+	template.KeyUsage = x509.KeyUsageDigitalSignature
+	template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning}
+}
+
+// setTSACertConstraints sets the key usage and the extended key usage
+// required for a timestamping certificate. RFC 3161 2.3 requires the extended
+// key usage extension to be critical and to contain only timeStamping, which
+// is not supported by the ExtKeyUsage field.
+func setTSACertConstraints(template *x509.Certificate) error {
+	ekuValue, err := asn1.Marshal([]asn1.ObjectIdentifier{oidExtKeyUsageTimeStamping})
+	if err != nil {
+		return err
+	}
+	template.KeyUsage = x509.KeyUsageDigitalSignature
+	template.BasicConstraintsValid = true
+	template.ExtraExtensions = []pkix.Extension{{
+		Id:       oidExtensionExtendedKeyUsage,
+		Critical: true,
+		Value:    ekuValue,
+	}}
+	return nil
+}
+
+// setCACertConstraints sets the basic constraints and key usage required for
+// a CA certificate.
+func setCACertConstraints(template *x509.Certificate, maxPathLen int) {
+	template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign
+	template.BasicConstraintsValid = true
+	template.IsCA = true
+	template.MaxPathLen = maxPathLen
+	template.MaxPathLenZero = maxPathLen == 0
+}
+
+func createCert(template, parent *x509.Certificate, pub crypto.PublicKey, parentKey crypto.Signer) (*x509.Certificate, error) {
+	certBytes, err := x509.CreateCertificate(rand.Reader, template, parent, pub, parentKey)
+	if err != nil {
+		return nil, err
+	}
+	return x509.ParseCertificate(certBytes)
 }
 
-// generateTestCert generates a self-signed non-CA certificate
-func generateSelfSignedCert(privateKey *rsa.PrivateKey, name string) (testhelper.RSACertTuple, []byte, error) {
-	rsaCertTuple := testhelper.GetRSASelfSignedCertTupleWithPK(privateKey, name)
-	return rsaCertTuple, generateCertPEM(&rsaCertTuple), nil
+// caSubject derives the subject of a CA certificate from the leaf subject.
+func caSubject(subject pkix.Name, suffix string) pkix.Name {
+	caSubject := subject
+	caSubject.CommonName = subject.CommonName + " " + suffix
+	return caSubject
+}
+
+// parseSubject parses a distinguished name in the form of
+// "CN=<common name>,O=<organization>,...". Commas within a value are escaped
+// with a backslash. If subject is empty, the default test subject is returned.
+// If the common name is not specified, commonName is used.
+func parseSubject(subject, commonName string) (pkix.Name, error) {
+	if subject == "" {
+		return pkix.Name{
+			Country:      []string{"US"},
+			Province:     []string{"WA"},
+			Locality:     []string{"Seattle"},
+			Organization: []string{"Notary"},
+			CommonName:   commonName,
+		}, nil
+	}
+	var name pkix.Name
+	for _, attribute := range splitEscaped(subject, ',') {
+		key, value, found := strings.Cut(attribute, "=")
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+		if !found || key == "" || value == "" {
+			return pkix.Name{}, fmt.Errorf("invalid subject %q: attribute %q must be in the form of <key>=<value>", subject, attribute)
+		}
+		switch strings.ToUpper(key) {
+		case "C":
+			name.Country = append(name.Country, value)
+		case "ST":
+			name.Province = append(name.Province, value)
+		case "L":
+			name.Locality = append(name.Locality, value)
+		case "O":
+			name.Organization = append(name.Organization, value)
+		case "OU":
+			name.OrganizationalUnit = append(name.OrganizationalUnit, value)
+		case "CN":
+			if name.CommonName != "" {
+				return pkix.Name{}, fmt.Errorf("invalid subject %q: multiple CN attributes", subject)
+			}
+			name.CommonName = value
+		default:
+			return pkix.Name{}, fmt.Errorf("invalid subject %q: unsupported attribute %q, options: C, ST, L, O, OU, CN", subject, key)
+		}
+	}
+	if name.CommonName == "" {
+		name.CommonName = commonName
+	}
+	return name, nil
+}
+
+// splitEscaped splits s by sep, ignoring separators escaped by a backslash.
+func splitEscaped(s string, sep rune) []string {
+	var parts []string
+	var current strings.Builder
+	escaped := false
+	for _, r := range s {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == sep:
+			parts = append(parts, current.String())
+			current.Reset()
+		default:
+			current.WriteRune(r)
+		}
+	}
+	return append(parts, current.String())
+}
+
+// addRootCert adds the root certificate of a generated certificate chain to
+// the named store name of type storeType.
+func addRootCert(rootCert *x509.Certificate, storeType, name string) error {
+	tmpDir, err := os.MkdirTemp("", "notation-generate-test")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+	rootCertPath := filepath.Join(tmpDir, name+dir.LocalCertificateExtension)
+	if err := osutil.WriteFile(rootCertPath, generateCertPEM(rootCert)); err != nil {
+		return err
+	}
+	return truststore.AddCert(rootCertPath, storeType, name, true)
 }
@@ -14,9 +14,14 @@
 package cert
 
 import (
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
+
+	corex509 "github.com/notaryproject/notation-core-go/x509"
 )
 
 func TestCertGenerateCommand(t *testing.T) {
@@ -26,6 +31,8 @@ func TestCertGenerateCommand(t *testing.T) {
 		name:      "name",
 		bits:      2048,
 		isDefault: true,
+		algorithm: "rsa",
+		validity:  "1d",
 	}
 	if err := cmd.ParseFlags([]string{
 		"name",
@@ -50,3 +57,168 @@ func TestCertGenerateTestCommand_MissingArgs(t *testing.T) {
 		t.Fatal("Parse Args expected error, but ok")
 	}
 }
+
+func TestCertGenerateCommand_AllFlags(t *testing.T) {
+	opts := &certGenerateTestOpts{}
+	cmd := certGenerateTestCommand(opts)
+	expected := &certGenerateTestOpts{
+		name:      "name",
+		bits:      256,
+		algorithm: "ecdsa",
+		validity:  "30d",
+		subject:   "CN=test,O=Notary",
+		chain:     true,
+	}
+	if err := cmd.ParseFlags([]string{
+		"name",
+		"--bits", fmt.Sprint(expected.bits),
+		"--algorithm", expected.algorithm,
+		"--validity", expected.validity,
+		"--subject", expected.subject,
+		"--chain"}); err != nil {
+		t.Fatalf("Parse Flag failed: %v", err)
+	}
+	if err := cmd.Args(cmd, cmd.Flags().Args()); err != nil {
+		t.Fatalf("Parse Args failed: %v", err)
+	}
+	if !reflect.DeepEqual(*expected, *opts) {
+		t.Fatalf("Expect cert generate-test opts: %v, got: %v", expected, opts)
+	}
+}
+
+func TestParseSubject(t *testing.T) {
+	t.Run("default subject", func(t *testing.T) {
+		name, err := parseSubject("", "wabbit-networks.io")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if name.String() != "CN=wabbit-networks.io,O=Notary,L=Seattle,ST=WA,C=US" {
+			t.Fatalf("unexpected subject %s", name)
+		}
+	})
+
+	t.Run("full subject", func(t *testing.T) {
+		name, err := parseSubject(`CN=acme,O=Acme\, Inc.,OU=Dev,OU=Ops,L=Seattle,ST=WA,C=US`, "wabbit-networks.io")
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := pkix.Name{
+			CommonName:         "acme",
+			Organization:       []string{"Acme, Inc."},
+			OrganizationalUnit: []string{"Dev", "Ops"},
+			Locality:           []string{"Seattle"},
+			Province:           []string{"WA"},
+			Country:            []string{"US"},
+		}
+		if !reflect.DeepEqual(expected, name) {
+			t.Fatalf("expected %v, but got %v", expected, name)
+		}
+	})
+
+	t.Run("default common name", func(t *testing.T) {
+		name, err := parseSubject("O=Acme", "wabbit-networks.io")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if name.CommonName != "wabbit-networks.io" {
+			t.Fatalf("expected common name wabbit-networks.io, but got %s", name.CommonName)
+		}
+	})
+
+	for _, subject := range []string{"CN", "CN=a,CN=b", "E=a@b.c", "CN="} {
+		if _, err := parseSubject(subject, "wabbit-networks.io"); err == nil {
+			t.Fatalf("expected error for subject %q, but got nil", subject)
+		}
+	}
+}
+
+func TestGenerateCertChain(t *testing.T) {
+	for _, tt := range []struct {
+		algorithm string
+		bits      int
+	}{
+		{algorithm: algorithmRSA, bits: 2048},
+		{algorithm: algorithmECDSA, bits: 384},
+	} {
+		t.Run(tt.algorithm, func(t *testing.T) {
+			key, _, err := generateTestKey(tt.algorithm, tt.bits)
+			if err != nil {
+				t.Fatal(err)
+			}
+			subject, err := parseSubject("", "wabbit-networks.io")
+			if err != nil {
+				t.Fatal(err)
+			}
+			chain, err := generateCertChain(key, tt.algorithm, tt.bits, subject, 24*time.Hour)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(chain) != 3 {
+				t.Fatalf("expected 3 certificates, but got %d", len(chain))
+			}
+			if err := corex509.ValidateCodeSigningCertChain(chain, nil); err != nil {
+				t.Fatalf("expected valid code signing certificate chain, but got %v", err)
+			}
+
+			cert, err := generateSelfSignedCert(key, subject, 24*time.Hour)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := corex509.ValidateCodeSigningCertChain([]*x509.Certificate{cert}, nil); err != nil {
+				t.Fatalf("expected valid self-signed code signing certificate, but got %v", err)
+			}
+		})
+	}
+}
+
+func TestGenerateTSACertChain(t *testing.T) {
+	key, _, err := generateTestKey(algorithmRSA, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	subject, err := parseSubject("", "wabbit-networks-tsa")
+	if err != nil {
+		t.Fatal(err)
+	}
+	chain, err := generateTSACertChain(key, algorithmRSA, 2048, subject, 24*time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(chain) != 2 {
+		t.Fatalf("expected 2 certificates, but got %d", len(chain))
+	}
+	if err := corex509.ValidateTimestampingCertChain(chain); err != nil {
+		t.Fatalf("expected valid timestamping certificate chain, but got %v", err)
+	}
+}
+
+func TestCertGenerateTestCommand_TSA(t *testing.T) {
+	opts := &certGenerateTestOpts{}
+	cmd := certGenerateTestCommand(opts)
+	if err := cmd.ParseFlags([]string{"name", "--tsa"}); err != nil {
+		t.Fatalf("Parse Flag failed: %v", err)
+	}
+	if !opts.tsa {
+		t.Fatal("expected tsa to be set")
+	}
+
+	cmd = certGenerateTestCommand(nil)
+	if err := cmd.ParseFlags([]string{"name", "--tsa", "--default"}); err != nil {
+		t.Fatalf("Parse Flag failed: %v", err)
+	}
+	if err := cmd.ValidateFlagGroups(); err == nil {
+		t.Fatal("expected error for --tsa with --default, but got nil")
+	}
+}
+
+func TestGenerateTestKey_Unsupported(t *testing.T) {
+	if _, _, err := generateTestKey(algorithmRSA, 1024); err == nil {
+		t.Fatal("expected error for RSA 1024, but got nil")
+	}
+	if _, _, err := generateTestKey(algorithmECDSA, 224); err == nil {
+		t.Fatal("expected error for ECDSA 224, but got nil")
+	}
+	if _, _, err := generateTestKey("ed25519", 256); err == nil {
+		t.Fatal("expected error for ed25519, but got nil")
+	}
+}
@@ -0,0 +1,296 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cert
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/notaryproject/notation-go/dir"
+	"github.com/notaryproject/notation-go/log"
+	"github.com/notaryproject/notation-go/verifier/trustpolicy"
+	"github.com/notaryproject/notation/cmd/notation/internal/cmdutil"
+	"github.com/notaryproject/notation/cmd/notation/internal/truststore"
+	"github.com/notaryproject/notation/internal/cmd"
+	"github.com/notaryproject/notation/internal/osutil"
+	"github.com/notaryproject/notation/internal/x509"
+	"github.com/spf13/cobra"
+)
+
+// maxBundleEntryBytes is the maximum size of a file in a trust store bundle.
+const maxBundleEntryBytes = 4 * 1024 * 1024 // 4 MiB
+
+// bundleEntry is a file read from a trust store bundle.
+type bundleEntry struct {
+	// name is the slash-separated path of the file relative to the notation
+	// configuration directory.
+	name string
+
+	// data is the content of the file.
+	data []byte
+}
+
+type certImportBundleOpts struct {
+	cmd.LoggingFlagOpts
+	bundlePath string
+	force      bool
+}
+
+func certImportBundleCommand(opts *certImportBundleOpts) *cobra.Command {
+	if opts == nil {
+		opts = &certImportBundleOpts{}
+	}
+	command := &cobra.Command{
+		Use:   "import-bundle [flags] <bundle_path>",
+		Short: "Import certificates and trust policies from a bundle",
+		Long: `Import certificates and trust policies from a bundle
+
+The bundle is a gzip compressed tar file created by "notation cert export". All files in the bundle are validated before any of them is imported. Files identical to the existing ones are skipped. The command fails without importing anything if a file in the bundle conflicts with an existing file, unless "--force" is set.
+
+Example - Import certificates and trust policies from "bundle.tar.gz":
+  notation cert import-bundle bundle.tar.gz
+
+Example - Import certificates and trust policies from "bundle.tar.gz", overwriting conflicting files:
+  notation cert import-bundle --force bundle.tar.gz
+`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("requires 1 argument but received %d.\nUsage: notation certificate import-bundle <bundle_path>\nPlease specify the path of a bundle created by notation cert export", len(args))
+			}
+			opts.bundlePath = args[0]
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return importBundle(cmd.Context(), opts)
+		},
+	}
+	opts.LoggingFlagOpts.ApplyFlags(command.Flags())
+	command.Flags().BoolVar(&opts.force, "force", false, "overwrite the existing files that conflict with the bundle")
+	return command
+}
+
+func importBundle(ctx context.Context, opts *certImportBundleOpts) error {
+	// set log level
+	ctx = opts.LoggingFlagOpts.InitializeLogger(ctx)
+	logger := log.GetLogger(ctx)
+
+	entries, err := readBundle(opts.bundlePath)
+	if err != nil {
+		return fmt.Errorf("failed to read bundle %s: %w", opts.bundlePath, err)
+	}
+	if len(entries) == 0 {
+		return fmt.Errorf("no certificate or trust policy found in bundle %s", opts.bundlePath)
+	}
+
+	// detect conflicts before writing anything
+	var toImport, conflicts []bundleEntry
+	for _, entry := range entries {
+		existing, err := readExistingFile(entry.name)
+		if err != nil {
+			return err
+		}
+		switch {
+		case existing == nil:
+			toImport = append(toImport, entry)
+		case !bytes.Equal(existing, entry.data):
+			toImport = append(toImport, entry)
+			conflicts = append(conflicts, entry)
+		default:
+			logger.Debugf("Skipping %s, identical to the existing file", entry.name)
+		}
+	}
+	if len(conflicts) > 0 {
+		if !opts.force {
+			for _, entry := range conflicts {
+				fmt.Fprintf(os.Stderr, "Conflict: %s differs from the existing file\n", entry.name)
+			}
+			return fmt.Errorf("found %d file(s) in the bundle conflicting with the existing files, nothing is imported. Use --force to overwrite them", len(conflicts))
+		}
+		for _, entry := range conflicts {
+			fmt.Fprintf(os.Stderr, "Warning: existing file %s will be overwritten\n", entry.name)
+		}
+	}
+
+	// core process
+	for _, entry := range toImport {
+		targetPath, err := dir.ConfigFS().SysPath(strings.Split(entry.name, "/")...)
+		if err != nil {
+			return err
+		}
+		logger.Debugf("Writing %s to %s", entry.name, targetPath)
+		if err := osutil.WriteFile(targetPath, entry.data); err != nil {
+			return fmt.Errorf("failed to import %s: %w", entry.name, err)
+		}
+		if entry.name == dir.PathOCITrustPolicy {
+			// the imported OCI trust policy takes the place of the old trust
+			// policy file `trustpolicy.json`
+			if err := cmdutil.DeleteOldTrustPolicyFile(); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				fmt.Fprintf(os.Stderr, "Warning: failed to delete old trust policy configuration trustpolicy.json: %s\n", err)
+			}
+		}
+		fmt.Printf("Imported %s\n", entry.name)
+	}
+
+	// write out
+	fmt.Printf("Successfully imported %d file(s) from %s, %d file(s) unchanged\n", len(toImport), opts.bundlePath, len(entries)-len(toImport))
+	return nil
+}
+
+// readBundle reads and validates all entries of the gzip compressed tar file
+// at bundlePath.
+func readBundle(bundlePath string) ([]bundleEntry, error) {
+	in, err := os.Open(bundlePath)
+	if err != nil {
+		return nil, err
+	}
+	defer in.Close()
+	gzipReader, err := gzip.NewReader(in)
+	if err != nil {
+		return nil, err
+	}
+	defer gzipReader.Close()
+
+	var entries []bundleEntry
+	seen := make(map[string]bool)
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return entries, nil
+			}
+			return nil, err
+		}
+		switch header.Typeflag {
+		case tar.TypeDir:
+			continue
+		case tar.TypeReg:
+		default:
+			return nil, fmt.Errorf("entry %s is not a regular file", header.Name)
+		}
+		if seen[header.Name] {
+			return nil, fmt.Errorf("duplicate entry %s", header.Name)
+		}
+		seen[header.Name] = true
+		if header.Size > maxBundleEntryBytes {
+			return nil, fmt.Errorf("entry %s exceeds the size limit of %d bytes", header.Name, maxBundleEntryBytes)
+		}
+		data, err := io.ReadAll(io.LimitReader(tarReader, maxBundleEntryBytes))
+		if err != nil {
+			return nil, err
+		}
+		if err := validateBundleEntry(header.Name, data); err != nil {
+			return nil, fmt.Errorf("invalid entry %s: %w", header.Name, err)
+		}
+		entries = append(entries, bundleEntry{
+			name: header.Name,
+			data: data,
+		})
+	}
+}
+
+// validateBundleEntry validates that name is either a trust policy or a
+// certificate file under truststore/x509/<store_type>/<store_name>, and that
+// data is a valid trust policy or contains certificates accordingly.
+func validateBundleEntry(name string, data []byte) error {
+	switch name {
+	case dir.PathOCITrustPolicy:
+		var doc trustpolicy.OCIDocument
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return err
+		}
+		return doc.Validate()
+	case dir.PathBlobTrustPolicy:
+		var doc trustpolicy.BlobDocument
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return err
+		}
+		return doc.Validate()
+	}
+
+	elems := strings.Split(name, "/")
+	if len(elems) != 5 || path.Join(elems[:2]...) != path.Join(dir.TrustStoreDir, "x509") {
+		return errors.New("unexpected path, expecting a trust policy or a certificate file under truststore/x509/<store_type>/<store_name>")
+	}
+	if !truststore.IsValidStoreType(elems[2]) {
+		return fmt.Errorf("unsupported store type: %s", elems[2])
+	}
+	if !isValidBundlePathElement(elems[3]) {
+		return errors.New("named store name needs to follow [a-zA-Z0-9_.-]+ format")
+	}
+	if truststore.IsReservedSystemStore(elems[2], elems[3]) {
+		return truststore.ErrReservedSystemStore
+	}
+	if !isValidBundleFileName(elems[4]) {
+		return errors.New("file name needs to be a single path element other than \".\" and \"..\"")
+	}
+	certs, err := x509.ParseCertificates(data)
+	if err != nil {
+		return err
+	}
+	if len(certs) == 0 {
+		return errors.New("no valid certificate found in the file")
+	}
+	return nil
+}
+
+// isValidBundlePathElement checks if elem is a valid file name that does not
+// refer to the current or the parent directory.
+func isValidBundlePathElement(elem string) bool {
+	return truststore.IsValidFileName(elem) && elem != "." && elem != ".."
+}
+
+// isValidBundleFileName checks if elem is a single local path element that
+// does not refer to the current or the parent directory. Unlike the named
+// store names, the certificate file names are not restricted further, since
+// "notation cert add" keeps the original file names.
+func isValidBundleFileName(elem string) bool {
+	return elem != "." && filepath.IsLocal(elem) && filepath.Base(elem) == elem
+}
+
+// readExistingFile reads the existing file for the bundle entry name. A nil
+// slice is returned if the file does not exist. The old OCI trust policy file
+// `trustpolicy.json` is read if `trustpolicy.oci.json` does not exist.
+func readExistingFile(name string) ([]byte, error) {
+	candidates := []string{name}
+	if name == dir.PathOCITrustPolicy {
+		candidates = append(candidates, dir.PathTrustPolicy)
+	}
+	for _, candidate := range candidates {
+		existingPath, err := dir.ConfigFS().SysPath(strings.Split(candidate, "/")...)
+		if err != nil {
+			return nil, err
+		}
+		data, err := os.ReadFile(existingPath)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to read existing file %s: %w", existingPath, err)
+		}
+		return data, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,279 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cert
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/notaryproject/notation-go/dir"
+)
+
+func TestCertImportBundleCommand(t *testing.T) {
+	opts := &certImportBundleOpts{}
+	cmd := certImportBundleCommand(opts)
+	expected := &certImportBundleOpts{
+		bundlePath: "bundle.tar.gz",
+		force:      true,
+	}
+	if err := cmd.ParseFlags([]string{
+		"bundle.tar.gz",
+		"--force"}); err != nil {
+		t.Fatalf("Parse Flag failed: %v", err)
+	}
+	if err := cmd.Args(cmd, cmd.Flags().Args()); err != nil {
+		t.Fatalf("Parse Args failed: %v", err)
+	}
+	if !reflect.DeepEqual(*expected, *opts) {
+		t.Fatalf("Expect cert import-bundle opts: %v, got: %v", expected, opts)
+	}
+}
+
+func TestCertImportBundleCommand_MissingArgs(t *testing.T) {
+	cmd := certImportBundleCommand(nil)
+	if err := cmd.ParseFlags(nil); err != nil {
+		t.Fatalf("Parse Flag failed: %v", err)
+	}
+	if err := cmd.Args(cmd, cmd.Flags().Args()); err == nil {
+		t.Fatal("Parse Args expected error, but ok")
+	}
+}
+
+func TestExportImportBundle(t *testing.T) {
+	defer func(oldDir string) {
+		dir.UserConfigDir = oldDir
+	}(dir.UserConfigDir)
+	dir.UserConfigDir = t.TempDir()
+	writeTestTrustStore(t)
+	sourceDir := dir.UserConfigDir
+
+	bundlePath := filepath.Join(t.TempDir(), "bundle.tar.gz")
+	if err := exportCerts(context.Background(), &certExportOpts{
+		output:             bundlePath,
+		includeTrustPolicy: true,
+	}); err != nil {
+		t.Fatalf("exportCerts() failed: %v", err)
+	}
+
+	// import into an empty configuration directory
+	dir.UserConfigDir = t.TempDir()
+	if err := importBundle(context.Background(), &certImportBundleOpts{bundlePath: bundlePath}); err != nil {
+		t.Fatalf("importBundle() failed: %v", err)
+	}
+	for _, name := range []string{
+		"truststore/x509/ca/test/ca.pem",
+		"truststore/x509/tsa/test/tsa.pem",
+		dir.PathOCITrustPolicy,
+	} {
+		expected, err := os.ReadFile(filepath.Join(sourceDir, filepath.FromSlash(name)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := os.ReadFile(filepath.Join(dir.UserConfigDir, filepath.FromSlash(name)))
+		if err != nil {
+			t.Fatalf("expected %s to be imported, but got %v", name, err)
+		}
+		if string(got) != string(expected) {
+			t.Fatalf("expected %s to be identical to the exported file", name)
+		}
+	}
+
+	// importing again is a no-op
+	if err := importBundle(context.Background(), &certImportBundleOpts{bundlePath: bundlePath}); err != nil {
+		t.Fatalf("importBundle() failed: %v", err)
+	}
+
+	// conflicting files are not overwritten without force
+	policyPath := filepath.Join(dir.UserConfigDir, dir.PathOCITrustPolicy)
+	modified := strings.Replace(testOCITrustPolicy, `"name":"test"`, `"name":"modified"`, 1)
+	if err := os.WriteFile(policyPath, []byte(modified), 0600); err != nil {
+		t.Fatal(err)
+	}
+	certPath := filepath.Join(dir.UserConfigDir, "truststore", "x509", "ca", "test", "ca.pem")
+	if err := os.Remove(certPath); err != nil {
+		t.Fatal(err)
+	}
+	expectedErrMsg := "found 1 file(s) in the bundle conflicting with the existing files, nothing is imported. Use --force to overwrite them"
+	if err := importBundle(context.Background(), &certImportBundleOpts{bundlePath: bundlePath}); err == nil || err.Error() != expectedErrMsg {
+		t.Fatalf("expected error %q, but got %v", expectedErrMsg, err)
+	}
+	if _, err := os.Stat(certPath); !os.IsNotExist(err) {
+		t.Fatalf("expected nothing to be imported on conflict, but got %v", err)
+	}
+
+	// conflicting files are overwritten with force
+	if err := importBundle(context.Background(), &certImportBundleOpts{bundlePath: bundlePath, force: true}); err != nil {
+		t.Fatalf("importBundle() failed: %v", err)
+	}
+	got, err := os.ReadFile(policyPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != testOCITrustPolicy {
+		t.Fatal("expected trust policy to be overwritten")
+	}
+	if _, err := os.Stat(certPath); err != nil {
+		t.Fatalf("expected certificate to be imported, but got %v", err)
+	}
+}
+
+func TestExportImportBundle_FileNames(t *testing.T) {
+	defer func(oldDir string) {
+		dir.UserConfigDir = oldDir
+	}(dir.UserConfigDir)
+	dir.UserConfigDir = t.TempDir()
+	sourceDir := dir.UserConfigDir
+
+	// "notation cert add" keeps the original file name
+	certData, err := os.ReadFile(filepath.FromSlash("../internal/truststore/testdata/self-signed.crt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	certDir := filepath.Join(sourceDir, dir.TrustStoreDir, "x509", "ca", "test")
+	if err := os.MkdirAll(certDir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(certDir, "My Root (2024).crt"), certData, 0600); err != nil {
+		t.Fatal(err)
+	}
+	bundlePath := filepath.Join(t.TempDir(), "bundle.tar.gz")
+	if err := exportCerts(context.Background(), &certExportOpts{output: bundlePath}); err != nil {
+		t.Fatalf("exportCerts() failed: %v", err)
+	}
+
+	dir.UserConfigDir = t.TempDir()
+	if err := importBundle(context.Background(), &certImportBundleOpts{bundlePath: bundlePath}); err != nil {
+		t.Fatalf("importBundle() failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir.UserConfigDir, dir.TrustStoreDir, "x509", "ca", "test", "My Root (2024).crt")); err != nil {
+		t.Fatalf("expected the certificate to be imported with its original name, but got %v", err)
+	}
+}
+
+func TestReadBundle_InvalidEntry(t *testing.T) {
+	certData, err := os.ReadFile(filepath.FromSlash("../internal/truststore/testdata/self-signed.crt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name     string
+		header   *tar.Header
+		data     string
+		errorMsg string
+	}{
+		{
+			name:     "path traversal",
+			header:   &tar.Header{Typeflag: tar.TypeReg, Name: "truststore/x509/ca/../../../evil.crt"},
+			data:     string(certData),
+			errorMsg: "unexpected path",
+		},
+		{
+			name:     "parent directory as store name",
+			header:   &tar.Header{Typeflag: tar.TypeReg, Name: "truststore/x509/ca/../evil.crt"},
+			data:     string(certData),
+			errorMsg: "named store name needs to follow",
+		},
+		{
+			name:     "current directory as file name",
+			header:   &tar.Header{Typeflag: tar.TypeReg, Name: "truststore/x509/ca/test/."},
+			data:     string(certData),
+			errorMsg: "file name needs to be a single path element",
+		},
+		{
+			name:     "unknown file",
+			header:   &tar.Header{Typeflag: tar.TypeReg, Name: "config.json"},
+			data:     "{}",
+			errorMsg: "unexpected path",
+		},
+		{
+			name:     "invalid store type",
+			header:   &tar.Header{Typeflag: tar.TypeReg, Name: "truststore/x509/invalid/test/cert.crt"},
+			data:     string(certData),
+			errorMsg: "unsupported store type: invalid",
+		},
+		{
+			name:     "reserved system store",
+			header:   &tar.Header{Typeflag: tar.TypeReg, Name: "truststore/x509/tsa/system/cert.crt"},
+			data:     string(certData),
+			errorMsg: "is reserved for the system root certificate bundle",
+		},
+		{
+			name:     "invalid certificate",
+			header:   &tar.Header{Typeflag: tar.TypeReg, Name: "truststore/x509/ca/test/cert.crt"},
+			data:     "not a certificate",
+			errorMsg: "invalid entry truststore/x509/ca/test/cert.crt",
+		},
+		{
+			name:     "invalid trust policy",
+			header:   &tar.Header{Typeflag: tar.TypeReg, Name: dir.PathBlobTrustPolicy},
+			data:     `{"version":"1.0"}`,
+			errorMsg: "invalid entry trustpolicy.blob.json",
+		},
+		{
+			name:     "symbolic link",
+			header:   &tar.Header{Typeflag: tar.TypeSymlink, Name: "truststore/x509/ca/test/cert.crt", Linkname: "/etc/passwd"},
+			errorMsg: "is not a regular file",
+		},
+		{
+			name:     "too large",
+			header:   &tar.Header{Typeflag: tar.TypeReg, Name: "truststore/x509/ca/test/cert.crt", Size: maxBundleEntryBytes + 1},
+			errorMsg: "exceeds the size limit",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bundlePath := filepath.Join(t.TempDir(), "bundle.tar.gz")
+			writeTestBundle(t, bundlePath, tt.header, tt.data)
+			_, err := readBundle(bundlePath)
+			if err == nil || !strings.Contains(err.Error(), tt.errorMsg) {
+				t.Fatalf("expected error containing %q, but got %v", tt.errorMsg, err)
+			}
+		})
+	}
+}
+
+// writeTestBundle writes a bundle with a single entry. The entry content is
+// omitted if header.Size is set.
+func writeTestBundle(t *testing.T, bundlePath string, header *tar.Header, data string) {
+	t.Helper()
+	bundleFile, err := os.Create(bundlePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer bundleFile.Close()
+	gzipWriter := gzip.NewWriter(bundleFile)
+	tarWriter := tar.NewWriter(gzipWriter)
+	if header.Size == 0 && header.Typeflag == tar.TypeReg {
+		header.Size = int64(len(data))
+	}
+	header.Mode = 0600
+	if err := tarWriter.WriteHeader(header); err != nil {
+		t.Fatal(err)
+	}
+	if header.Size == int64(len(data)) {
+		if _, err := tarWriter.Write([]byte(data)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// the tar writer reports an error for missing content, which is expected
+	// when header.Size is set
+	tarWriter.Flush()
+	gzipWriter.Close()
+}
@@ -15,22 +15,29 @@ package cert
 
 import (
 	"context"
+	"crypto/x509"
 	"fmt"
 	"os"
+	"slices"
+	"time"
 
 	"github.com/notaryproject/notation-go/dir"
 	"github.com/notaryproject/notation-go/log"
 	notationgoTruststore "github.com/notaryproject/notation-go/verifier/truststore"
+	"github.com/notaryproject/notation/cmd/notation/internal/display"
+	"github.com/notaryproject/notation/cmd/notation/internal/option"
 	"github.com/notaryproject/notation/cmd/notation/internal/truststore"
 	"github.com/notaryproject/notation/internal/cmd"
-	"github.com/notaryproject/notation/internal/ioutil"
 	"github.com/spf13/cobra"
 )
 
 type certListOpts struct {
 	cmd.LoggingFlagOpts
-	storeType  string
-	namedStore string
+	option.Common
+	option.Format
+	storeType      string
+	namedStore     string
+	expiringWithin string
 }
 
 func certListCommand(opts *certListOpts) *cobra.Command {
@@ -57,95 +64,178 @@ Example - List all certificate files from trust store "wabbit-networks" of type
 
 Example - List all certificate files from trust store of type "tsa"
   notation cert ls --type tsa
+
+Example - List all certificate files stored in the trust store in JSON format
+  notation cert ls --output json
+
+Example - List all certificates of the system root certificate bundle
+  notation cert ls --type tsa --store system
+
+Example - List all certificate files that contain certificates expired or expiring within 30 days
+  notation cert ls --expiring-within 30d
 `,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.Format.Parse(cmd); err != nil {
+				return err
+			}
+			opts.Common.Parse(cmd)
+			return nil
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return listCerts(cmd.Context(), opts)
 		},
 	}
 	opts.LoggingFlagOpts.ApplyFlags(command.Flags())
-	command.Flags().StringVarP(&opts.storeType, "type", "t", "", "specify trust store type, options: ca, signingAuthority")
+	command.Flags().StringVarP(&opts.storeType, "type", "t", "", "specify trust store type, options: ca, signingAuthority, tsa")
 	command.Flags().StringVarP(&opts.namedStore, "store", "s", "", "specify named store")
+	command.Flags().StringVar(&opts.expiringWithin, "expiring-within", "", "only list certificate files that contain certificates expired or expiring within the duration, in days(d), hours(h) and/or minutes(m). For example: 30d, 12h")
+	opts.Format.ApplyFlags(command.Flags(), option.FormatTypeText, option.FormatTypeJSON)
 	return command
 }
 
 func listCerts(ctx context.Context, opts *certListOpts) error {
 	// set log level
 	ctx = opts.LoggingFlagOpts.InitializeLogger(ctx)
-	logger := log.GetLogger(ctx)
 
-	namedStore := opts.namedStore
-	storeType := opts.storeType
+	displayHandler, err := display.NewCertListHandler(opts.Printer, opts.Format)
+	if err != nil {
+		return err
+	}
+	var expiringWithin time.Duration
+	if opts.expiringWithin != "" {
+		expiringWithin, err = cmd.ParseDuration(opts.expiringWithin)
+		if err != nil {
+			return fmt.Errorf("invalid value for --expiring-within: %w", err)
+		}
+	}
+
+	// core process
+	if truststore.IsReservedSystemStore(opts.storeType, opts.namedStore) {
+		if err := truststore.CheckReservedSystemStore(dir.ConfigFS()); err != nil {
+			return err
+		}
+		bundlePath, certs, err := truststore.SystemCerts(ctx)
+		if err != nil {
+			return err
+		}
+		if opts.expiringWithin != "" {
+			deadline := time.Now().Add(expiringWithin)
+			certs = slices.DeleteFunc(certs, func(cert *x509.Certificate) bool {
+				return !cert.NotAfter.Before(deadline)
+			})
+		}
+		displayHandler.OnSystemCertificatesListed(bundlePath, certs)
+		return displayHandler.Render()
+	}
+	var certPaths []string
+	if opts.expiringWithin != "" {
+		// unreadable certificate files are reported without failing the
+		// whole listing
+		files, err := listTrustStoreFiles(ctx, opts.storeType, opts.namedStore, truststore.ListCertFiles)
+		if err != nil {
+			return err
+		}
+		for _, expiringCert := range truststore.FindExpiringCerts(files, time.Now(), expiringWithin) {
+			if expiringCert.Err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", expiringCert.Err)
+				continue
+			}
+			if !slices.Contains(certPaths, expiringCert.Path) {
+				certPaths = append(certPaths, expiringCert.Path)
+			}
+		}
+	} else {
+		certPaths, err = listCertPaths(ctx, opts.storeType, opts.namedStore)
+		if err != nil {
+			return err
+		}
+	}
+
+	// write out
+	displayHandler.OnCertificatesListed(certPaths)
+	return displayHandler.Render()
+}
+
+// listCertPaths lists the paths of the certificate files in the trust store
+// filtered by storeType and namedStore. Empty storeType or namedStore matches
+// all store types or all named stores. An empty list is returned if storeType
+// is invalid or there's no certificate yet.
+func listCertPaths(ctx context.Context, storeType, namedStore string) ([]string, error) {
+	return listTrustStoreFiles(ctx, storeType, namedStore, truststore.ListCerts)
+}
+
+// listTrustStoreFiles lists the files in the trust store filtered by
+// storeType and namedStore with the list function, in the same way as
+// listCertPaths.
+func listTrustStoreFiles(ctx context.Context, storeType, namedStore string, list func(root string, depth int) ([]string, error)) ([]string, error) {
+	logger := log.GetLogger(ctx)
 	configFS := dir.ConfigFS()
 
-	// List all certificates under truststore/x509, display empty if there's
-	// no certificate yet
+	// List all certificates under truststore/x509
 	if namedStore == "" && storeType == "" {
 		var certPaths []string
 		for _, t := range notationgoTruststore.Types {
 			path, err := configFS.SysPath(dir.TrustStoreDir, "x509", string(t))
 			if err := truststore.CheckNonErrNotExistError(err); err != nil {
-				return err
+				return nil, err
 			}
-			certs, err := truststore.ListCerts(path, 1)
+			certs, err := list(path, 1)
 			if err := truststore.CheckNonErrNotExistError(err); err != nil {
 				logger.Debugln("Failed to complete list at path:", path)
-				return fmt.Errorf("failed to list all certificates stored in the trust store, with error: %s", err.Error())
+				return nil, fmt.Errorf("failed to list all certificates stored in the trust store, with error: %s", err.Error())
 			}
 			certPaths = append(certPaths, certs...)
 		}
-		return ioutil.PrintCertMap(os.Stdout, certPaths)
+		return certPaths, nil
 	}
 
-	// List all certificates under truststore/x509/storeType/namedStore,
-	// display empty if store type is invalid or there's no certificate yet
+	// List all certificates under truststore/x509/storeType/namedStore
 	if namedStore != "" && storeType != "" {
 		if !truststore.IsValidStoreType(storeType) {
-			return nil
+			return nil, nil
 		}
 		path, err := configFS.SysPath(dir.TrustStoreDir, "x509", storeType, namedStore)
 		if err := truststore.CheckNonErrNotExistError(err); err != nil {
-			return err
+			return nil, err
 		}
-		certPaths, err := truststore.ListCerts(path, 0)
+		certPaths, err := list(path, 0)
 		if err := truststore.CheckNonErrNotExistError(err); err != nil {
 			logger.Debugln("Failed to complete list at path:", path)
-			return fmt.Errorf("failed to list all certificates stored in the named store %s of type %s, with error: %s", namedStore, storeType, err.Error())
+			return nil, fmt.Errorf("failed to list all certificates stored in the named store %s of type %s, with error: %s", namedStore, storeType, err.Error())
 		}
-		return ioutil.PrintCertMap(os.Stdout, certPaths)
+		return certPaths, nil
 	}
 
-	// List all certificates under x509/storeType, display empty if store type
-	// is invalid or there's no certificate yet
+	// List all certificates under x509/storeType
 	if storeType != "" {
 		if !truststore.IsValidStoreType(storeType) {
-			return nil
+			return nil, nil
 		}
 		path, err := configFS.SysPath(dir.TrustStoreDir, "x509", storeType)
 		if err := truststore.CheckNonErrNotExistError(err); err != nil {
-			return err
+			return nil, err
 		}
-		certPaths, err := truststore.ListCerts(path, 1)
+		certPaths, err := list(path, 1)
 		if err := truststore.CheckNonErrNotExistError(err); err != nil {
 			logger.Debugln("Failed to complete list at path:", path)
-			return fmt.Errorf("failed to list all certificates stored of type %s, with error: %s", storeType, err.Error())
+			return nil, fmt.Errorf("failed to list all certificates stored of type %s, with error: %s", storeType, err.Error())
 		}
-		return ioutil.PrintCertMap(os.Stdout, certPaths)
+		return certPaths, nil
 	}
 
-	// List all certificates under named store namedStore, display empty if
-	// there's no certificate yet
+	// List all certificates under named store namedStore
 	var certPaths []string
 	for _, t := range notationgoTruststore.Types {
 		path, err := configFS.SysPath(dir.TrustStoreDir, "x509", string(t), namedStore)
 		if err := truststore.CheckNonErrNotExistError(err); err != nil {
-			return err
+			return nil, err
 		}
-		certs, err := truststore.ListCerts(path, 0)
+		certs, err := list(path, 0)
 		if err := truststore.CheckNonErrNotExistError(err); err != nil {
 			logger.Debugln("Failed to complete list at path:", path)
-			return fmt.Errorf("failed to list all certificates stored in the named store %s, with error: %s", namedStore, err.Error())
+			return nil, fmt.Errorf("failed to list all certificates stored in the named store %s, with error: %s", namedStore, err.Error())
 		}
 		certPaths = append(certPaths, certs...)
 	}
-	return ioutil.PrintCertMap(os.Stdout, certPaths)
+	return certPaths, nil
 }
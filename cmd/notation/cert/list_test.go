@@ -16,12 +16,19 @@ package cert
 import (
 	"reflect"
 	"testing"
+
+	"github.com/notaryproject/notation/cmd/notation/internal/option"
+	"github.com/spf13/pflag"
 )
 
 func TestCertListCommand(t *testing.T) {
 	opts := &certListOpts{}
 	cmd := certListCommand(opts)
+	format := option.Format{}
+	format.ApplyFlags(&pflag.FlagSet{}, option.FormatTypeText, option.FormatTypeJSON)
+	format.CurrentType = string(option.FormatTypeText)
 	expected := &certListOpts{
+		Format:     format,
 		storeType:  "ca",
 		namedStore: "test",
 	}
@@ -34,3 +41,30 @@ func TestCertListCommand(t *testing.T) {
 		t.Fatalf("Expect cert list opts: %v, got: %v", expected, opts)
 	}
 }
+
+func TestCertListCommand_JSON(t *testing.T) {
+	opts := &certListOpts{}
+	cmd := certListCommand(opts)
+	if err := cmd.ParseFlags([]string{
+		"--output", "json"}); err != nil {
+		t.Fatalf("Parse Flag failed: %v", err)
+	}
+	if err := cmd.PreRunE(cmd, cmd.Flags().Args()); err != nil {
+		t.Fatalf("PreRunE failed: %v", err)
+	}
+	if opts.Format.CurrentType != string(option.FormatTypeJSON) {
+		t.Fatalf("Expect output format %q, got: %q", option.FormatTypeJSON, opts.Format.CurrentType)
+	}
+}
+
+func TestCertListCommand_ExpiringWithin(t *testing.T) {
+	opts := &certListOpts{}
+	cmd := certListCommand(opts)
+	if err := cmd.ParseFlags([]string{
+		"--expiring-within", "30d"}); err != nil {
+		t.Fatalf("Parse Flag failed: %v", err)
+	}
+	if opts.expiringWithin != "30d" {
+		t.Fatalf("Expect expiring within %q, got: %q", "30d", opts.expiringWithin)
+	}
+}
@@ -0,0 +1,195 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cert
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/notaryproject/notation-go/log"
+	"github.com/notaryproject/notation/cmd/notation/internal/option"
+	"github.com/notaryproject/notation/cmd/notation/internal/registryutil"
+	"github.com/notaryproject/notation/cmd/notation/internal/truststore"
+	"github.com/notaryproject/notation/internal/httputil"
+	"github.com/notaryproject/notation/internal/osutil"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	"oras.land/oras-go/v2/registry"
+)
+
+const (
+	// notationCertDownloadTmpDir is the pattern of the temporary directory
+	// for downloading certificate files.
+	notationCertDownloadTmpDir = "notation-cert-download"
+
+	// downloadCertFromURLTimeout is the timeout when downloading a
+	// certificate file from a URL.
+	downloadCertFromURLTimeout = 2 * time.Minute
+
+	// maxCertFileBytes is the maximum size of a certificate file or a
+	// certificate bundle manifest fetched remotely.
+	maxCertFileBytes = 4 * 1024 * 1024 // 4 MiB
+)
+
+// downloadCertFromURL downloads the certificate file from the HTTPS URL
+// certURL into tmpDir, and verifies its SHA256 checksum against
+// inputChecksum. The path of the downloaded file is returned.
+func downloadCertFromURL(ctx context.Context, certURL, inputChecksum, tmpDir string) (string, error) {
+	parsedURL, err := url.Parse(certURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse certificate download URL %s with error: %w", certURL, err)
+	}
+	if parsedURL.Scheme != "https" {
+		return "", fmt.Errorf("failed to download certificate from URL: only the HTTPS scheme is supported, but got %s", parsedURL.Scheme)
+	}
+	fileName := path.Base(parsedURL.Path)
+	if !truststore.IsValidFileName(fileName) || fileName == "." || fileName == ".." {
+		return "", fmt.Errorf("failed to download certificate from URL %s: the certificate file name in the URL path needs to follow [a-zA-Z0-9_.-]+ format", certURL)
+	}
+
+	// download
+	client := httputil.NewClient(ctx, &http.Client{Timeout: downloadCertFromURLTimeout})
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, certURL, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to download certificate from URL %s with error: %w", certURL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to download certificate from URL %s: %s %q: https response bad status: %s", certURL, resp.Request.Method, resp.Request.URL, resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxCertFileBytes+1))
+	if err != nil {
+		return "", fmt.Errorf("failed to download certificate from URL %s with error: %w", certURL, err)
+	}
+	if len(data) > maxCertFileBytes {
+		return "", fmt.Errorf("failed to download certificate from URL %s: https response reached the %d MiB size limit", certURL, maxCertFileBytes/1024/1024)
+	}
+
+	// checksum check
+	sha256sum := sha256.Sum256(data)
+	if enc := hex.EncodeToString(sha256sum[:]); !strings.EqualFold(enc, inputChecksum) {
+		return "", fmt.Errorf("certificate SHA-256 checksum does not match user input. Expecting %s", inputChecksum)
+	}
+
+	certPath := filepath.Join(tmpDir, fileName)
+	if err := osutil.WriteFile(certPath, data); err != nil {
+		return "", err
+	}
+	return certPath, nil
+}
+
+// pullCertsFromRegistry pulls the certificate files of the certificate bundle
+// artifact at reference into tmpDir. Each layer of the artifact annotated with
+// "org.opencontainers.image.title" is a certificate file named by the
+// annotation. The paths and the names of the pulled files are returned.
+func pullCertsFromRegistry(ctx context.Context, secureOpts *option.Secure, reference, tmpDir string) ([]string, []string, error) {
+	logger := log.GetLogger(ctx)
+	ref, err := registry.ParseReference(reference)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%q: %w. Expecting <registry>/<repository>:<tag> or <registry>/<repository>@<digest>", reference, err)
+	}
+	if ref.Reference == "" {
+		return nil, nil, fmt.Errorf("%q: invalid reference: no tag or digest. Expecting <registry>/<repository>:<tag> or <registry>/<repository>@<digest>", reference)
+	}
+	if err := ref.ValidateReferenceAsDigest(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: Always add certificates using digest(@sha256:...) rather than a tag(:%s) because resolved digest may not point to the same certificate bundle, as tags are mutable.\n", ref.Reference)
+	}
+	repo, err := registryutil.NewRepositoryClient(ctx, secureOpts, ref)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// fetch the manifest
+	manifestDesc, rc, err := repo.FetchReference(ctx, ref.Reference)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch certificate bundle %s: %w", reference, err)
+	}
+	defer rc.Close()
+	if manifestDesc.MediaType != ocispec.MediaTypeImageManifest {
+		return nil, nil, fmt.Errorf("failed to fetch certificate bundle %s: unsupported manifest media type %s, expecting %s", reference, manifestDesc.MediaType, ocispec.MediaTypeImageManifest)
+	}
+	if manifestDesc.Size > maxCertFileBytes {
+		return nil, nil, fmt.Errorf("failed to fetch certificate bundle %s: manifest size %d exceeds the size limit of %d bytes", reference, manifestDesc.Size, maxCertFileBytes)
+	}
+	manifestBytes, err := content.ReadAll(rc, manifestDesc)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch certificate bundle %s: %w", reference, err)
+	}
+	var manifest ocispec.Manifest
+	if err := json.Unmarshal(manifestBytes, &manifest); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse manifest of certificate bundle %s: %w", reference, err)
+	}
+	logger.Infof("Resolved certificate bundle %s to %s", reference, manifestDesc.Digest)
+
+	// fetch the certificate files
+	var certPaths, names []string
+	seen := make(map[string]bool)
+	for _, layer := range manifest.Layers {
+		name := layer.Annotations[ocispec.AnnotationTitle]
+		if name == "" {
+			logger.Debugf("Skipped layer %s without %s annotation", layer.Digest, ocispec.AnnotationTitle)
+			continue
+		}
+		if !truststore.IsValidFileName(name) || name == "." || name == ".." {
+			return nil, nil, fmt.Errorf("invalid certificate file name %q in certificate bundle %s: file name needs to follow [a-zA-Z0-9_.-]+ format", name, reference)
+		}
+		if seen[name] {
+			return nil, nil, fmt.Errorf("duplicate certificate file name %q in certificate bundle %s", name, reference)
+		}
+		seen[name] = true
+		if layer.Size > maxCertFileBytes {
+			return nil, nil, fmt.Errorf("certificate file %s in certificate bundle %s exceeds the size limit of %d bytes", name, reference, maxCertFileBytes)
+		}
+		data, err := fetchBlob(ctx, repo, layer)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to fetch certificate file %s in certificate bundle %s: %w", name, reference, err)
+		}
+		certPath := filepath.Join(tmpDir, name)
+		if err := osutil.WriteFile(certPath, data); err != nil {
+			return nil, nil, err
+		}
+		certPaths = append(certPaths, certPath)
+		names = append(names, name)
+	}
+	if len(certPaths) == 0 {
+		return nil, nil, fmt.Errorf("no certificate file found in certificate bundle %s", reference)
+	}
+	return certPaths, names, nil
+}
+
+// fetchBlob fetches the blob described by desc with its digest and size
+// verified.
+func fetchBlob(ctx context.Context, fetcher content.Fetcher, desc ocispec.Descriptor) ([]byte, error) {
+	rc, err := fetcher.Fetch(ctx, desc)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return content.ReadAll(rc, desc)
+}
@@ -0,0 +1,189 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cert
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/notaryproject/notation-core-go/testhelper"
+	"github.com/notaryproject/notation/cmd/notation/internal/option"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+func TestDownloadCertFromURL_InvalidURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		certURL  string
+		errorMsg string
+	}{
+		{
+			name:     "non-HTTPS scheme",
+			certURL:  "http://acme-rockets.io/certs/root.crt",
+			errorMsg: "only the HTTPS scheme is supported, but got http",
+		},
+		{
+			name:     "no file name",
+			certURL:  "https://acme-rockets.io/",
+			errorMsg: "the certificate file name in the URL path needs to follow [a-zA-Z0-9_.-]+ format",
+		},
+		{
+			name:     "invalid file name",
+			certURL:  "https://acme-rockets.io/certs/root%20ca.crt",
+			errorMsg: "the certificate file name in the URL path needs to follow [a-zA-Z0-9_.-]+ format",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := downloadCertFromURL(context.Background(), tt.certURL, "abcd", t.TempDir())
+			if err == nil || !strings.Contains(err.Error(), tt.errorMsg) {
+				t.Fatalf("expected error containing %q, but got %v", tt.errorMsg, err)
+			}
+		})
+	}
+}
+
+func TestPullCertsFromRegistry(t *testing.T) {
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: testhelper.GetRSARootCertificate().Cert.Raw})
+	certDesc := ocispec.Descriptor{
+		MediaType: "application/x-pem-file",
+		Digest:    digest.FromBytes(certPEM),
+		Size:      int64(len(certPEM)),
+		Annotations: map[string]string{
+			ocispec.AnnotationTitle: "root.pem",
+		},
+	}
+	configDesc := ocispec.DescriptorEmptyJSON
+	manifest := ocispec.Manifest{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Config:    configDesc,
+		Layers: []ocispec.Descriptor{
+			certDesc,
+			// layers without title are skipped
+			{
+				MediaType: "application/octet-stream",
+				Digest:    digest.FromString("skipped"),
+				Size:      7,
+			},
+		},
+	}
+	manifest.SchemaVersion = 2
+	manifestJSON, err := json.Marshal(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	manifestDigest := digest.FromBytes(manifestJSON)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/certs/manifests/v1", "/v2/certs/manifests/" + manifestDigest.String():
+			w.Header().Set("Content-Type", ocispec.MediaTypeImageManifest)
+			w.Header().Set("Docker-Content-Digest", manifestDigest.String())
+			w.Write(manifestJSON)
+		case "/v2/certs/blobs/" + certDesc.Digest.String():
+			w.Write(certPEM)
+		default:
+			t.Errorf("unexpected access: %s %q", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+	uri, err := url.Parse(ts.URL)
+	if err != nil {
+		t.Fatalf("invalid test http server: %v", err)
+	}
+	secureOpts := &option.Secure{
+		InsecureRegistry: true,
+	}
+
+	for _, reference := range []string{
+		uri.Host + "/certs:v1",
+		uri.Host + "/certs@" + manifestDigest.String(),
+	} {
+		tmpDir := t.TempDir()
+		certPaths, names, err := pullCertsFromRegistry(context.Background(), secureOpts, reference, tmpDir)
+		if err != nil {
+			t.Fatalf("pullCertsFromRegistry(%q) failed: %v", reference, err)
+		}
+		expectedPaths := []string{filepath.Join(tmpDir, "root.pem")}
+		if !reflect.DeepEqual(certPaths, expectedPaths) {
+			t.Fatalf("expected certificate paths %v, but got %v", expectedPaths, certPaths)
+		}
+		if !reflect.DeepEqual(names, []string{"root.pem"}) {
+			t.Fatalf("expected certificate names [root.pem], but got %v", names)
+		}
+		data, err := os.ReadFile(certPaths[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != string(certPEM) {
+			t.Fatal("expected pulled certificate file to match the layer")
+		}
+	}
+
+	t.Run("no tag or digest", func(t *testing.T) {
+		expectedErrMsg := "invalid reference: no tag or digest"
+		_, _, err := pullCertsFromRegistry(context.Background(), secureOpts, uri.Host+"/certs", t.TempDir())
+		if err == nil || !strings.Contains(err.Error(), expectedErrMsg) {
+			t.Fatalf("expected error containing %q, but got %v", expectedErrMsg, err)
+		}
+	})
+}
+
+func TestAddCerts_RemoteSourceErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     *certAddOpts
+		errorMsg string
+	}{
+		{
+			name: "missing checksum",
+			opts: &certAddOpts{
+				storeType:  "ca",
+				namedStore: "test",
+				path:       []string{"https://acme-rockets.io/certs/root.crt"},
+				isURL:      true,
+			},
+			errorMsg: "adding certificate from URL requires non-empty SHA256 checksum of the certificate file",
+		},
+		{
+			name: "checksum without URL",
+			opts: &certAddOpts{
+				storeType:     "ca",
+				namedStore:    "test",
+				path:          []string{"root.crt"},
+				inputChecksum: "abcd",
+			},
+			errorMsg: "\"--sha256sum\" is only supported when \"--url\" flag is set",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := addCerts(context.Background(), tt.opts)
+			if err == nil || err.Error() != tt.errorMsg {
+				t.Fatalf("expected error %q, but got %v", tt.errorMsg, err)
+			}
+		})
+	}
+}
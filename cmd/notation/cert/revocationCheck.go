@@ -0,0 +1,197 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package cert
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/notaryproject/notation-core-go/revocation"
+	"github.com/notaryproject/notation-core-go/revocation/purpose"
+	"github.com/notaryproject/notation-core-go/revocation/result"
+	corex509 "github.com/notaryproject/notation-core-go/x509"
+	"github.com/notaryproject/notation/internal/cmd"
+	clirev "github.com/notaryproject/notation/internal/revocation"
+	"github.com/spf13/cobra"
+)
+
+const (
+	purposeCodeSigning  = "codesigning"
+	purposeTimestamping = "timestamping"
+)
+
+type certRevocationCheckOpts struct {
+	cmd.LoggingFlagOpts
+	path           string
+	purpose        string
+	revocationMode string
+	ocspTimeout    time.Duration
+	crlTimeout     time.Duration
+}
+
+func certRevocationCheckCommand(opts *certRevocationCheckOpts) *cobra.Command {
+	if opts == nil {
+		opts = &certRevocationCheckOpts{}
+	}
+	command := &cobra.Command{
+		Use:   "revocation-check [flags] <cert_chain_path>",
+		Short: "Check the revocation status of a certificate chain",
+		Long: `Check the revocation status of a certificate chain
+
+The revocation status of the certificate chain in the file, ordered from the leaf certificate, is checked in the same way as the certificate chain of a signature by "notation verify", without creating or verifying any signature. For each certificate, the revocation status, the method used, the OCSP responders or the CRL distribution points queried and whether the responses are served from the local cache are reported. The command fails if any certificate is revoked or its revocation status is unknown.
+
+Example - Check the revocation status of a code signing certificate chain:
+  notation cert revocation-check chain.pem
+
+Example - Check the revocation status of a timestamping certificate chain:
+  notation cert revocation-check --purpose timestamping tsa-chain.pem
+
+Example - Check the revocation status of a certificate chain against the local cache only:
+  notation cert revocation-check --revocation-mode offline chain.pem
+`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return errors.New("missing certificate chain file path")
+			}
+			if len(args) > 1 {
+				return errors.New("revocation-check only supports single certificate chain file")
+			}
+			opts.path = args[0]
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return checkCertRevocation(cmd.Context(), opts)
+		},
+	}
+	opts.LoggingFlagOpts.ApplyFlags(command.Flags())
+	command.Flags().StringVar(&opts.purpose, "purpose", purposeCodeSigning, fmt.Sprintf("purpose of the certificate chain, options: %q, %q", purposeCodeSigning, purposeTimestamping))
+	cmd.SetPflagRevocationMode(command.Flags(), &opts.revocationMode)
+	cmd.SetPflagOCSPTimeout(command.Flags(), &opts.ocspTimeout)
+	cmd.SetPflagCRLTimeout(command.Flags(), &opts.crlTimeout)
+	return command
+}
+
+func checkCertRevocation(ctx context.Context, opts *certRevocationCheckOpts) error {
+	// set log level
+	ctx = opts.LoggingFlagOpts.InitializeLogger(ctx)
+
+	certPurpose, err := parsePurpose(opts.purpose)
+	if err != nil {
+		return err
+	}
+	revocationMode, err := clirev.ParseMode(opts.revocationMode)
+	if err != nil {
+		return err
+	}
+	certChain, err := corex509.ReadCertificateFile(opts.path)
+	if err != nil {
+		return fmt.Errorf("failed to read certificate file %s: %w", opts.path, err)
+	}
+	if len(certChain) == 0 {
+		return fmt.Errorf("no valid certificate found in the file %s", opts.path)
+	}
+	cacheHits := &clirev.CacheHits{}
+	revocationValidator, err := clirev.NewRevocationValidatorWithOptions(ctx, certPurpose, clirev.Options{
+		Mode:        revocationMode,
+		CacheHits:   cacheHits,
+		OCSPTimeout: opts.ocspTimeout,
+		CRLTimeout:  opts.crlTimeout,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create revocation validator: %w", err)
+	}
+
+	// core process
+	results, err := revocationValidator.ValidateContext(ctx, revocation.ValidateContextOptions{
+		CertChain: certChain,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to check revocation status of certificate chain %s: %w", opts.path, err)
+	}
+
+	// write out
+	printRevocationResults(os.Stdout, certChain, results, cacheHits)
+	var revoked, unknown int
+	for _, certResult := range results {
+		switch certResult.Result {
+		case result.ResultRevoked:
+			revoked++
+		case result.ResultUnknown:
+			unknown++
+		}
+	}
+	if revoked > 0 || unknown > 0 {
+		return fmt.Errorf("certificate chain %s has %d revoked certificate(s) and %d certificate(s) with unknown revocation status", opts.path, revoked, unknown)
+	}
+	fmt.Printf("No certificate in the certificate chain %s is revoked\n", opts.path)
+	return nil
+}
+
+// parsePurpose parses the purpose of a certificate chain.
+func parsePurpose(certPurpose string) (purpose.Purpose, error) {
+	switch certPurpose {
+	case purposeCodeSigning:
+		return purpose.CodeSigning, nil
+	case purposeTimestamping:
+		return purpose.Timestamping, nil
+	}
+	return 0, fmt.Errorf("unsupported purpose %q, options: %q, %q", certPurpose, purposeCodeSigning, purposeTimestamping)
+}
+
+// printRevocationResults prints the revocation results of certChain to w.
+// results are ordered as certChain.
+func printRevocationResults(w io.Writer, certChain []*x509.Certificate, results []*result.CertRevocationResult, cacheHits *clirev.CacheHits) {
+	for i, certResult := range results {
+		if i >= len(certChain) {
+			break
+		}
+		fmt.Fprintf(w, "Certificate %d: %s\n", i+1, certChain[i].Subject)
+		fmt.Fprintf(w, "  Status: %s\n", revocationStatus(certResult.Result))
+		if certResult.Result == result.ResultNonRevokable {
+			continue
+		}
+		fmt.Fprintf(w, "  Method: %s\n", certResult.RevocationMethod)
+		for _, serverResult := range certResult.ServerResults {
+			if serverResult.Server == "" {
+				continue
+			}
+			source := "cache miss"
+			if cacheHits.Contains(certChain[i], serverResult.Server) {
+				source = "cache hit"
+			}
+			fmt.Fprintf(w, "  %s %s: %s (%s)\n", serverResult.RevocationMethod, serverResult.Server, revocationStatus(serverResult.Result), source)
+			if serverResult.Error != nil {
+				fmt.Fprintf(w, "    Error: %v\n", serverResult.Error)
+			}
+		}
+	}
+}
+
+// revocationStatus returns the revocation status of r.
+func revocationStatus(r result.Result) string {
+	switch r {
+	case result.ResultOK:
+		return "good"
+	case result.ResultRevoked:
+		return "revoked"
+	case result.ResultNonRevokable:
+		return "not checked (root certificate, or no OCSP responder or CRL distribution point)"
+	default:
+		return "unknown"
+	}
+}
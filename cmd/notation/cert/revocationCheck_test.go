@@ -0,0 +1,167 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package cert
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/notaryproject/notation-core-go/revocation/purpose"
+	"github.com/notaryproject/notation-core-go/revocation/result"
+	"github.com/notaryproject/notation-core-go/testhelper"
+	"github.com/notaryproject/notation-go/dir"
+	clirev "github.com/notaryproject/notation/internal/revocation"
+	"github.com/notaryproject/notation/pkg/configutil"
+)
+
+func TestCertRevocationCheckCommand(t *testing.T) {
+	opts := &certRevocationCheckOpts{}
+	cmd := certRevocationCheckCommand(opts)
+	expected := &certRevocationCheckOpts{
+		path:           "chain.pem",
+		purpose:        "timestamping",
+		revocationMode: "offline",
+		ocspTimeout:    configutil.DefaultOCSPTimeout,
+		crlTimeout:     configutil.DefaultCRLTimeout,
+	}
+	if err := cmd.ParseFlags([]string{
+		"chain.pem",
+		"--purpose", "timestamping",
+		"--revocation-mode", "offline"}); err != nil {
+		t.Fatalf("Parse Flag failed: %v", err)
+	}
+	if err := cmd.Args(cmd, cmd.Flags().Args()); err != nil {
+		t.Fatalf("Parse Args failed: %v", err)
+	}
+	if !reflect.DeepEqual(*expected, *opts) {
+		t.Fatalf("Expect cert revocation-check opts: %v, got: %v", expected, opts)
+	}
+}
+
+func TestCertRevocationCheckCommand_MissingArgs(t *testing.T) {
+	cmd := certRevocationCheckCommand(nil)
+	if err := cmd.ParseFlags([]string{}); err != nil {
+		t.Fatalf("Parse Flag failed: %v", err)
+	}
+	if err := cmd.Args(cmd, cmd.Flags().Args()); err == nil {
+		t.Fatal("Parse Args expected error, but ok")
+	}
+}
+
+func TestParsePurpose(t *testing.T) {
+	if got, err := parsePurpose("codesigning"); err != nil || got != purpose.CodeSigning {
+		t.Fatalf("expected code signing purpose, but got %v, %v", got, err)
+	}
+	if got, err := parsePurpose("timestamping"); err != nil || got != purpose.Timestamping {
+		t.Fatalf("expected timestamping purpose, but got %v, %v", got, err)
+	}
+	if _, err := parsePurpose("invalid"); err == nil {
+		t.Fatal("expected error for invalid purpose")
+	}
+}
+
+func TestCheckCertRevocation(t *testing.T) {
+	dir.UserCacheDir = t.TempDir()
+	defer func() {
+		dir.UserCacheDir = ""
+	}()
+	writeChain := func(t *testing.T, certs ...*x509.Certificate) string {
+		var data []byte
+		for _, cert := range certs {
+			data = append(data, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})...)
+		}
+		path := filepath.Join(t.TempDir(), "chain.pem")
+		if err := os.WriteFile(path, data, 0600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	t.Run("not revocable", func(t *testing.T) {
+		path := writeChain(t, testhelper.GetRSALeafCertificate().Cert, testhelper.GetRSARootCertificate().Cert)
+		if err := checkCertRevocation(context.Background(), &certRevocationCheckOpts{
+			path:           path,
+			purpose:        purposeCodeSigning,
+			revocationMode: "offline",
+		}); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("unknown in offline mode", func(t *testing.T) {
+		chain := testhelper.GetRevokableRSAChainWithRevocations(2, true, false)
+		path := writeChain(t, chain[0].Cert, chain[1].Cert)
+		err := checkCertRevocation(context.Background(), &certRevocationCheckOpts{
+			path:           path,
+			purpose:        purposeCodeSigning,
+			revocationMode: "offline",
+		})
+		if err == nil || !strings.Contains(err.Error(), "1 certificate(s) with unknown revocation status") {
+			t.Fatalf("expected unknown revocation status error, but got %v", err)
+		}
+	})
+
+	t.Run("invalid purpose", func(t *testing.T) {
+		if err := checkCertRevocation(context.Background(), &certRevocationCheckOpts{
+			path:    "chain.pem",
+			purpose: "invalid",
+		}); err == nil {
+			t.Fatal("expected error for invalid purpose")
+		}
+	})
+}
+
+func TestPrintRevocationResults(t *testing.T) {
+	leaf := testhelper.GetRSALeafCertificate().Cert
+	root := testhelper.GetRSARootCertificate().Cert
+	results := []*result.CertRevocationResult{
+		{
+			Result:           result.ResultOK,
+			RevocationMethod: result.RevocationMethodOCSPFallbackCRL,
+			ServerResults: []*result.ServerResult{
+				{Result: result.ResultUnknown, Server: "http://ocsp.test", Error: errors.New("unknown status"), RevocationMethod: result.RevocationMethodOCSP},
+				{Result: result.ResultOK, Server: "http://crl.test/ca.crl", RevocationMethod: result.RevocationMethodCRL},
+			},
+		},
+		{
+			Result: result.ResultNonRevokable,
+		},
+	}
+	cacheHits := &clirev.CacheHits{}
+	cacheHits.RecordCRL(&x509.RevocationList{RawIssuer: leaf.RawIssuer}, "http://crl.test/ca.crl")
+	// a cache hit of the same responder for another certificate
+	cacheHits.RecordOCSP(root.SerialNumber, "http://ocsp.test")
+	var buf bytes.Buffer
+	printRevocationResults(&buf, []*x509.Certificate{leaf, root}, results, cacheHits)
+	got := buf.String()
+	for _, want := range []string{
+		"Certificate 1: " + leaf.Subject.String(),
+		"  Status: good\n",
+		"  Method: OCSPFallbackCRL\n",
+		"  OCSP http://ocsp.test: unknown (cache miss)\n    Error: unknown status\n",
+		"  CRL http://crl.test/ca.crl: good (cache hit)\n",
+		"Certificate 2: " + root.Subject.String() + "\n  Status: not checked",
+	} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("expected output to contain %q, but got:\n%s", want, got)
+		}
+	}
+}
@@ -21,6 +21,8 @@ import (
 	corex509 "github.com/notaryproject/notation-core-go/x509"
 	"github.com/notaryproject/notation-go/dir"
 	"github.com/notaryproject/notation-go/log"
+	"github.com/notaryproject/notation/cmd/notation/internal/display"
+	"github.com/notaryproject/notation/cmd/notation/internal/option"
 	"github.com/notaryproject/notation/cmd/notation/internal/truststore"
 	"github.com/notaryproject/notation/internal/cmd"
 	"github.com/spf13/cobra"
@@ -28,6 +30,8 @@ import (
 
 type certShowOpts struct {
 	cmd.LoggingFlagOpts
+	option.Common
+	option.Format
 	storeType  string
 	namedStore string
 	cert       string
@@ -60,7 +64,20 @@ Example - Show details of certificate "cert2.pem" with type "signingAuthority" f
 
 Example - Show details of certificate "wabbit-networks-timestamp.pem" with type "tsa" from trust store "timestamp":
   notation cert show --type tsa --store timestamp wabbit-networks-timestamp.pem
+
+Example - Show details of certificate "cert1.pem" with type "ca" from trust store "acme-rockets" in JSON format:
+  notation cert show --type ca --store acme-rockets cert1.pem --output json
+
+Example - Print certificate "cert1.pem" with type "ca" from trust store "acme-rockets" in PEM format:
+  notation cert show --type ca --store acme-rockets cert1.pem --output pem
 `,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.Format.Parse(cmd); err != nil {
+				return err
+			}
+			opts.Common.Parse(cmd)
+			return nil
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return showCerts(cmd.Context(), opts)
 		},
@@ -68,6 +85,7 @@ Example - Show details of certificate "wabbit-networks-timestamp.pem" with type
 	opts.LoggingFlagOpts.ApplyFlags(command.Flags())
 	command.Flags().StringVarP(&opts.storeType, "type", "t", "", "specify trust store type, options: ca, signingAuthority")
 	command.Flags().StringVarP(&opts.namedStore, "store", "s", "", "specify named store")
+	opts.Format.ApplyFlags(command.Flags(), option.FormatTypeText, option.FormatTypeJSON, option.FormatTypePEM)
 	command.MarkFlagRequired("type")
 	command.MarkFlagRequired("store")
 	return command
@@ -78,6 +96,10 @@ func showCerts(ctx context.Context, opts *certShowOpts) error {
 	ctx = opts.LoggingFlagOpts.InitializeLogger(ctx)
 	logger := log.GetLogger(ctx)
 
+	displayHandler, err := display.NewCertShowHandler(opts.Printer, opts.Format)
+	if err != nil {
+		return err
+	}
 	storeType := opts.storeType
 	if storeType == "" {
 		return errors.New("store type cannot be empty")
@@ -107,8 +129,7 @@ func showCerts(ctx context.Context, opts *certShowOpts) error {
 		return fmt.Errorf("failed to show details of certificate %s, with error: no valid certificate found in the file", cert)
 	}
 
-	//write out
-	truststore.ShowCerts(certs)
-
-	return nil
+	// write out
+	displayHandler.OnCertificatesLoaded(certs)
+	return displayHandler.Render()
 }
@@ -16,12 +16,19 @@ package cert
 import (
 	"reflect"
 	"testing"
+
+	"github.com/notaryproject/notation/cmd/notation/internal/option"
+	"github.com/spf13/pflag"
 )
 
 func TestCertShowCommand(t *testing.T) {
 	opts := &certShowOpts{}
 	cmd := certShowCommand(opts)
+	format := option.Format{}
+	format.ApplyFlags(&pflag.FlagSet{}, option.FormatTypeText, option.FormatTypeJSON, option.FormatTypePEM)
+	format.CurrentType = string(option.FormatTypeText)
 	expected := &certShowOpts{
+		Format:     format,
 		storeType:  "ca",
 		namedStore: "test",
 		cert:       "test.crt",
@@ -49,3 +56,21 @@ func TestCertShowCommand_MissingArgs(t *testing.T) {
 		t.Fatal("Parse Args expected error, but ok")
 	}
 }
+
+func TestCertShowCommand_PEM(t *testing.T) {
+	opts := &certShowOpts{}
+	cmd := certShowCommand(opts)
+	if err := cmd.ParseFlags([]string{
+		"test.crt",
+		"-t", "ca",
+		"-s", "test",
+		"--output", "pem"}); err != nil {
+		t.Fatalf("Parse Flag failed: %v", err)
+	}
+	if err := cmd.PreRunE(cmd, cmd.Flags().Args()); err != nil {
+		t.Fatalf("PreRunE failed: %v", err)
+	}
+	if opts.Format.CurrentType != string(option.FormatTypePEM) {
+		t.Fatalf("Expect output format %q, got: %q", option.FormatTypePEM, opts.Format.CurrentType)
+	}
+}
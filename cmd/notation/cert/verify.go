@@ -0,0 +1,289 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cert
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/notaryproject/notation-core-go/revocation"
+	"github.com/notaryproject/notation-core-go/revocation/purpose"
+	"github.com/notaryproject/notation-core-go/revocation/result"
+	corex509 "github.com/notaryproject/notation-core-go/x509"
+	"github.com/notaryproject/notation-go/dir"
+	"github.com/notaryproject/notation-go/log"
+	notationgoTruststore "github.com/notaryproject/notation-go/verifier/truststore"
+	"github.com/notaryproject/notation/cmd/notation/internal/cmdutil"
+	"github.com/notaryproject/notation/cmd/notation/internal/truststore"
+	"github.com/notaryproject/notation/internal/cmd"
+	clirev "github.com/notaryproject/notation/internal/revocation"
+	"github.com/spf13/cobra"
+)
+
+type certVerifyOpts struct {
+	cmd.LoggingFlagOpts
+	storeType  string
+	namedStore string
+	path       string
+}
+
+func certVerifyCommand(opts *certVerifyOpts) *cobra.Command {
+	if opts == nil {
+		opts = &certVerifyOpts{}
+	}
+	command := &cobra.Command{
+		Use:   "verify --type <type> --store <name> [flags] <cert_path>",
+		Short: "Verify a certificate chain against a named trust store",
+		Long: `Verify a certificate chain against a named trust store
+
+The certificate chain in the file, ordered from the leaf certificate, is verified in the same way as the certificate chain of a signature by "notation verify". The chain must include a certificate in the trust store. The structure, the key usages and the extended key usages, the validity periods and the revocation status of the certificates are reported. Certificate chains verified against a trust store of type "tsa" are verified as timestamping certificate chains, and others as code signing certificate chains.
+
+Example - Verify a certificate chain against trust store "acme-rockets" of type "ca":
+  notation cert verify --type ca --store acme-rockets chain.pem
+
+Example - Verify a timestamping certificate chain against trust store "timestamp" of type "tsa":
+  notation cert verify --type tsa --store timestamp tsa-chain.pem
+`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return errors.New("missing certificate file path")
+			}
+			if len(args) > 1 {
+				return errors.New("verify only supports single certificate file")
+			}
+			opts.path = args[0]
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return verifyCert(cmd.Context(), opts)
+		},
+	}
+	opts.LoggingFlagOpts.ApplyFlags(command.Flags())
+	command.Flags().StringVarP(&opts.storeType, "type", "t", "", "specify trust store type, options: ca, signingAuthority, tsa")
+	command.Flags().StringVarP(&opts.namedStore, "store", "s", "", "specify named store")
+	command.MarkFlagRequired("type")
+	command.MarkFlagRequired("store")
+	return command
+}
+
+func verifyCert(ctx context.Context, opts *certVerifyOpts) error {
+	// set log level
+	ctx = opts.LoggingFlagOpts.InitializeLogger(ctx)
+	logger := log.GetLogger(ctx)
+
+	if !truststore.IsValidStoreType(opts.storeType) {
+		return fmt.Errorf("unsupported store type: %s", opts.storeType)
+	}
+	certChain, err := corex509.ReadCertificateFile(opts.path)
+	if err != nil {
+		return fmt.Errorf("failed to read certificate file %s: %w", opts.path, err)
+	}
+	if len(certChain) == 0 {
+		return fmt.Errorf("no valid certificate found in the file %s", opts.path)
+	}
+	logger.Debugf("Verifying %d certificate(s) against trust store %s/%s", len(certChain), opts.storeType, opts.namedStore)
+	trustedCerts, err := truststore.NewX509TrustStore(dir.ConfigFS()).GetCertificates(ctx, notationgoTruststore.Type(opts.storeType), opts.namedStore)
+	if err != nil {
+		return err
+	}
+	certPurpose := purpose.CodeSigning
+	if opts.storeType == string(notationgoTruststore.TypeTSA) {
+		certPurpose = purpose.Timestamping
+	}
+	revocationValidator, err := clirev.NewRevocationValidator(ctx, certPurpose)
+	if err != nil {
+		return fmt.Errorf("failed to create revocation validator: %w", err)
+	}
+
+	// core process
+	results := verifyCertChain(ctx, certChain, trustedCerts, certPurpose, revocationValidator, time.Now())
+
+	// write out
+	if err := cmdutil.PrintCheckResults(os.Stdout, results); err != nil {
+		return err
+	}
+	failed, warnings := cmdutil.CountCheckResults(results)
+	if failed > 0 {
+		return fmt.Errorf("certificate chain %s failed %d of %d checks against trust store %s/%s", opts.path, failed, len(results), opts.storeType, opts.namedStore)
+	}
+	if warnings > 0 {
+		fmt.Printf("Successfully verified certificate chain %s against trust store %s/%s with %d warning(s)\n", opts.path, opts.storeType, opts.namedStore, warnings)
+		return nil
+	}
+	fmt.Printf("Successfully verified certificate chain %s against trust store %s/%s\n", opts.path, opts.storeType, opts.namedStore)
+	return nil
+}
+
+// verifyCertChain verifies certChain against trustedCerts at time now for
+// certPurpose. The revocation status is checked by revocationValidator.
+//
+// certChain must not be empty.
+func verifyCertChain(ctx context.Context, certChain, trustedCerts []*x509.Certificate, certPurpose purpose.Purpose, revocationValidator revocation.Validator, now time.Time) []cmdutil.CheckResult {
+	return []cmdutil.CheckResult{
+		checkTrustStore(certChain, trustedCerts, now),
+		checkCertChainStructure(certChain, certPurpose),
+		checkCertChainValidity(certChain, now),
+		checkCertChainRevocation(ctx, certChain, revocationValidator),
+	}
+}
+
+// checkTrustStore checks whether certChain includes a trusted certificate. If
+// not, it tries to build a path from certChain to the trusted certificates to
+// find out the missing certificates.
+func checkTrustStore(certChain, trustedCerts []*x509.Certificate, now time.Time) cmdutil.CheckResult {
+	res := cmdutil.CheckResult{
+		Check: "trust store",
+	}
+	for _, cert := range certChain {
+		for _, trusted := range trustedCerts {
+			if cert.Equal(trusted) {
+				res.Status = cmdutil.CheckStatusOK
+				res.Detail = fmt.Sprintf("certificate %q is trusted", cert.Subject)
+				return res
+			}
+		}
+	}
+
+	res.Status = cmdutil.CheckStatusFailed
+	roots := x509.NewCertPool()
+	for _, trusted := range trustedCerts {
+		roots.AddCert(trusted)
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range certChain[1:] {
+		intermediates.AddCert(cert)
+	}
+	chains, err := certChain[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		CurrentTime:   now,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	if err != nil {
+		res.Detail = fmt.Sprintf("no certificate in the chain is trusted, and no path can be built to the trust store: %v", err)
+		return res
+	}
+	var missing []string
+	for _, cert := range chains[0][len(certChain):] {
+		missing = append(missing, fmt.Sprintf("%q", cert.Subject))
+	}
+	res.Detail = fmt.Sprintf("no certificate in the chain is trusted, but a path can be built to the trust store by appending %s to the chain", strings.Join(missing, ", "))
+	return res
+}
+
+// checkCertChainStructure checks the structure, the key usages and the
+// extended key usages of certChain for certPurpose.
+func checkCertChainStructure(certChain []*x509.Certificate, certPurpose purpose.Purpose) cmdutil.CheckResult {
+	res := cmdutil.CheckResult{
+		Check:  "certificate chain",
+		Status: cmdutil.CheckStatusOK,
+		Detail: fmt.Sprintf("%d certificate(s)", len(certChain)),
+	}
+	var err error
+	switch certPurpose {
+	case purpose.Timestamping:
+		err = corex509.ValidateTimestampingCertChain(certChain)
+	default:
+		err = corex509.ValidateCodeSigningCertChain(certChain, nil)
+	}
+	if err != nil {
+		res.Status = cmdutil.CheckStatusFailed
+		res.Detail = err.Error()
+	}
+	return res
+}
+
+// checkCertChainValidity checks the validity periods of the certificates in
+// certChain at time now.
+func checkCertChainValidity(certChain []*x509.Certificate, now time.Time) cmdutil.CheckResult {
+	res := cmdutil.CheckResult{
+		Check: "validity",
+	}
+	var problems []string
+	expiring := certChain[0]
+	for _, cert := range certChain {
+		switch {
+		case now.Before(cert.NotBefore):
+			problems = append(problems, fmt.Sprintf("certificate %q is not valid until %s", cert.Subject, cert.NotBefore.Format(time.ANSIC)))
+		case now.After(cert.NotAfter):
+			problems = append(problems, fmt.Sprintf("certificate %q expired on %s", cert.Subject, cert.NotAfter.Format(time.ANSIC)))
+		}
+		if cert.NotAfter.Before(expiring.NotAfter) {
+			expiring = cert
+		}
+	}
+	if len(problems) > 0 {
+		res.Status = cmdutil.CheckStatusFailed
+		res.Detail = strings.Join(problems, "; ")
+		return res
+	}
+	res.Status = cmdutil.CheckStatusOK
+	res.Detail = fmt.Sprintf("valid until %s", expiring.NotAfter.Format(time.ANSIC))
+	return res
+}
+
+// checkCertChainRevocation checks the revocation status of the certificates in
+// certChain with revocationValidator.
+func checkCertChainRevocation(ctx context.Context, certChain []*x509.Certificate, revocationValidator revocation.Validator) cmdutil.CheckResult {
+	res := cmdutil.CheckResult{
+		Check: "revocation",
+	}
+	certResults, err := revocationValidator.ValidateContext(ctx, revocation.ValidateContextOptions{
+		CertChain: certChain,
+	})
+	if err != nil {
+		res.Status = cmdutil.CheckStatusWarning
+		res.Detail = fmt.Sprintf("revocation status cannot be checked: %v", err)
+		return res
+	}
+	var revoked, unknown []string
+	var nonRevokable int
+	for i, certResult := range certResults {
+		switch certResult.Result {
+		case result.ResultNonRevokable:
+			nonRevokable++
+		case result.ResultRevoked:
+			revoked = append(revoked, fmt.Sprintf("certificate %q is revoked", certChain[i].Subject))
+		case result.ResultUnknown:
+			detail := fmt.Sprintf("revocation status of certificate %q is unknown", certChain[i].Subject)
+			for _, serverResult := range certResult.ServerResults {
+				if serverResult.Error != nil {
+					detail += fmt.Sprintf(": %v", serverResult.Error)
+					break
+				}
+			}
+			unknown = append(unknown, detail)
+		}
+	}
+	switch {
+	case len(revoked) > 0:
+		res.Status = cmdutil.CheckStatusFailed
+		res.Detail = strings.Join(revoked, "; ")
+	case len(unknown) > 0:
+		res.Status = cmdutil.CheckStatusWarning
+		res.Detail = strings.Join(unknown, "; ")
+	case nonRevokable == len(certResults):
+		res.Status = cmdutil.CheckStatusOK
+		res.Detail = "no OCSP or CRL endpoint found in the certificates"
+	default:
+		res.Status = cmdutil.CheckStatusOK
+		res.Detail = "not revoked"
+	}
+	return res
+}
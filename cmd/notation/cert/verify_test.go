@@ -0,0 +1,138 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cert
+
+import (
+	"context"
+	"crypto/x509"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/notaryproject/notation-core-go/revocation"
+	"github.com/notaryproject/notation-core-go/revocation/purpose"
+	"github.com/notaryproject/notation-core-go/revocation/result"
+	"github.com/notaryproject/notation-core-go/testhelper"
+	"github.com/notaryproject/notation/cmd/notation/internal/cmdutil"
+)
+
+type mockRevocationValidator struct {
+	results []*result.CertRevocationResult
+}
+
+func (v *mockRevocationValidator) ValidateContext(ctx context.Context, opts revocation.ValidateContextOptions) ([]*result.CertRevocationResult, error) {
+	return v.results, nil
+}
+
+func (v *mockRevocationValidator) Validate(certChain []*x509.Certificate, signingTime time.Time) ([]*result.CertRevocationResult, error) {
+	return v.results, nil
+}
+
+func TestCertVerifyCommand(t *testing.T) {
+	opts := &certVerifyOpts{}
+	cmd := certVerifyCommand(opts)
+	expected := &certVerifyOpts{
+		storeType:  "ca",
+		namedStore: "test",
+		path:       "chain.pem",
+	}
+	if err := cmd.ParseFlags([]string{
+		"chain.pem",
+		"-t", "ca",
+		"-s", "test"}); err != nil {
+		t.Fatalf("Parse Flag failed: %v", err)
+	}
+	if err := cmd.Args(cmd, cmd.Flags().Args()); err != nil {
+		t.Fatalf("Parse Args failed: %v", err)
+	}
+	if !reflect.DeepEqual(*expected, *opts) {
+		t.Fatalf("Expect cert verify opts: %v, got: %v", expected, opts)
+	}
+}
+
+func TestCertVerifyCommand_MissingArgs(t *testing.T) {
+	cmd := certVerifyCommand(nil)
+	if err := cmd.ParseFlags([]string{
+		"-t", "ca",
+		"-s", "test"}); err != nil {
+		t.Fatalf("Parse Flag failed: %v", err)
+	}
+	if err := cmd.Args(cmd, cmd.Flags().Args()); err == nil {
+		t.Fatal("Parse Args expected error, but ok")
+	}
+}
+
+func TestVerifyCertChain(t *testing.T) {
+	leaf := testhelper.GetRSALeafCertificate().Cert
+	root := testhelper.GetRSARootCertificate().Cert
+	certChain := []*x509.Certificate{leaf, root}
+	now := leaf.NotBefore.Add(time.Hour)
+	ctx := context.Background()
+	notRevoked := &mockRevocationValidator{
+		results: []*result.CertRevocationResult{
+			{Result: result.ResultOK},
+			{Result: result.ResultNonRevokable},
+		},
+	}
+
+	t.Run("trusted", func(t *testing.T) {
+		results := verifyCertChain(ctx, certChain, []*x509.Certificate{root}, purpose.CodeSigning, notRevoked, now)
+		if failed, warnings := cmdutil.CountCheckResults(results); failed != 0 || warnings != 0 {
+			t.Fatalf("expected all checks to pass, got %v", results)
+		}
+	})
+
+	t.Run("root missing from chain", func(t *testing.T) {
+		results := verifyCertChain(ctx, []*x509.Certificate{leaf}, []*x509.Certificate{root}, purpose.CodeSigning, notRevoked, now)
+		if results[0].Status != cmdutil.CheckStatusFailed || !strings.Contains(results[0].Detail, "a path can be built to the trust store") {
+			t.Fatalf("expected trust store check to fail with a path to the trust store, got %v", results[0])
+		}
+	})
+
+	t.Run("untrusted", func(t *testing.T) {
+		other := testhelper.GetECRootCertificate().Cert
+		results := verifyCertChain(ctx, certChain, []*x509.Certificate{other}, purpose.CodeSigning, notRevoked, now)
+		if results[0].Status != cmdutil.CheckStatusFailed || !strings.Contains(results[0].Detail, "no path can be built") {
+			t.Fatalf("expected trust store check to fail without a path to the trust store, got %v", results[0])
+		}
+	})
+
+	t.Run("wrong purpose", func(t *testing.T) {
+		results := verifyCertChain(ctx, certChain, []*x509.Certificate{root}, purpose.Timestamping, notRevoked, now)
+		if results[1].Status != cmdutil.CheckStatusFailed {
+			t.Fatalf("expected certificate chain check to fail, got %v", results[1])
+		}
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		results := verifyCertChain(ctx, certChain, []*x509.Certificate{root}, purpose.CodeSigning, notRevoked, leaf.NotAfter.Add(time.Hour))
+		if results[2].Status != cmdutil.CheckStatusFailed || !strings.Contains(results[2].Detail, "expired on") {
+			t.Fatalf("expected validity check to fail, got %v", results[2])
+		}
+	})
+
+	t.Run("revoked", func(t *testing.T) {
+		revoked := &mockRevocationValidator{
+			results: []*result.CertRevocationResult{
+				{Result: result.ResultRevoked},
+				{Result: result.ResultNonRevokable},
+			},
+		}
+		results := verifyCertChain(ctx, certChain, []*x509.Certificate{root}, purpose.CodeSigning, revoked, now)
+		if results[3].Status != cmdutil.CheckStatusFailed || !strings.Contains(results[3].Detail, "is revoked") {
+			t.Fatalf("expected revocation check to fail, got %v", results[3])
+		}
+	})
+}
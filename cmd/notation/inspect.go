@@ -29,7 +29,7 @@ import (
 
 type inspectOpts struct {
 	cmd.LoggingFlagOpts
-	SecureFlagOpts
+	option.Secure
 	option.Common
 	option.Format
 	reference     string
@@ -77,7 +77,7 @@ Example - Inspect signatures on an OCI artifact identified by a digest and outpu
 		},
 	}
 	opts.LoggingFlagOpts.ApplyFlags(command.Flags())
-	opts.SecureFlagOpts.ApplyFlags(command.Flags())
+	opts.Secure.ApplyFlags(command.Flags())
 	command.Flags().IntVar(&opts.maxSignatures, "max-signatures", 100, "maximum number of signatures to evaluate or examine")
 
 	// set output format
@@ -98,7 +98,7 @@ func runInspect(command *cobra.Command, opts *inspectOpts) error {
 	reference := opts.reference
 	// always use the Referrers API, if not supported, automatically fallback to
 	// the referrers tag schema
-	sigRepo, err := getRemoteRepository(ctx, &opts.SecureFlagOpts, reference, false)
+	sigRepo, err := getRemoteRepository(ctx, &opts.Secure, reference, false)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,66 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmdutil
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+)
+
+// CheckStatus is the status of a check performed by a diagnostic command.
+type CheckStatus string
+
+const (
+	// CheckStatusOK indicates that the check passed.
+	CheckStatusOK CheckStatus = "ok"
+
+	// CheckStatusWarning indicates that the check passed with a problem that
+	// needs attention.
+	CheckStatusWarning CheckStatus = "warning"
+
+	// CheckStatusFailed indicates that the check failed.
+	CheckStatusFailed CheckStatus = "failed"
+)
+
+// CheckResult is the result of a check performed by a diagnostic command.
+type CheckResult struct {
+	Check  string
+	Status CheckStatus
+	Detail string
+}
+
+// PrintCheckResults prints out the check results in a table.
+func PrintCheckResults(w io.Writer, results []CheckResult) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "CHECK\tSTATUS\tDETAIL\t")
+	for _, result := range results {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t\n", result.Check, result.Status, result.Detail)
+	}
+	return tw.Flush()
+}
+
+// CountCheckResults returns the number of failed checks and the number of
+// checks with warnings.
+func CountCheckResults(results []CheckResult) (failed, warnings int) {
+	for _, result := range results {
+		switch result.Status {
+		case CheckStatusFailed:
+			failed++
+		case CheckStatusWarning:
+			warnings++
+		}
+	}
+	return failed, warnings
+}
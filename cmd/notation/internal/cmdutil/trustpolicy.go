@@ -0,0 +1,34 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmdutil
+
+import (
+	"os"
+
+	"github.com/notaryproject/notation-go/dir"
+)
+
+// DeleteOldTrustPolicyFile deletes the old trust policy configuration
+// `trustpolicy.json` if exists. An error wrapping os.ErrNotExist is returned
+// if the file does not exist.
+func DeleteOldTrustPolicyFile() error {
+	oldPolicyPath, err := dir.ConfigFS().SysPath(dir.PathTrustPolicy)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(oldPolicyPath); err != nil {
+		return err
+	}
+	return os.Remove(oldPolicyPath)
+}
@@ -0,0 +1,43 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmdutil
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/notaryproject/notation-go/dir"
+)
+
+func TestDeleteOldTrustPolicyFile(t *testing.T) {
+	dir.UserConfigDir = t.TempDir()
+	defer func() { dir.UserConfigDir = "" }()
+
+	if err := DeleteOldTrustPolicyFile(); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected os.ErrNotExist, but got %v", err)
+	}
+
+	policyPath := filepath.Join(dir.UserConfigDir, dir.PathTrustPolicy)
+	if err := os.WriteFile(policyPath, []byte("{}"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := DeleteOldTrustPolicyFile(); err != nil {
+		t.Fatalf("expected no error, but got %v", err)
+	}
+	if _, err := os.Stat(policyPath); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected %s to be deleted, but got %v", policyPath, err)
+	}
+}
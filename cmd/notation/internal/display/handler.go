@@ -24,6 +24,7 @@ import (
 
 	"github.com/notaryproject/notation/cmd/notation/internal/display/metadata"
 	"github.com/notaryproject/notation/cmd/notation/internal/display/metadata/json"
+	"github.com/notaryproject/notation/cmd/notation/internal/display/metadata/pem"
 	"github.com/notaryproject/notation/cmd/notation/internal/display/metadata/text"
 	"github.com/notaryproject/notation/cmd/notation/internal/display/metadata/tree"
 	"github.com/notaryproject/notation/cmd/notation/internal/display/output"
@@ -71,3 +72,65 @@ func NewBlobVerifyHandler(printer *output.Printer) metadata.BlobVerifyHandler {
 func NewListHandler(printer *output.Printer) metadata.ListHandler {
 	return tree.NewListHandler(printer)
 }
+
+// NewKeyShowHandler creates a new metadata KeyShowHandler based on the output
+// format.
+func NewKeyShowHandler(printer *output.Printer, format option.Format) (metadata.KeyShowHandler, error) {
+	switch option.FormatType(format.CurrentType) {
+	case option.FormatTypeJSON:
+		return json.NewKeyShowHandler(printer), nil
+	case option.FormatTypeTree:
+		return tree.NewKeyShowHandler(printer), nil
+	}
+	return nil, fmt.Errorf("unrecognized output format %s", format.CurrentType)
+}
+
+// NewKeyListHandler creates a new metadata KeyListHandler for rendering the
+// list of signing keys based on the output format.
+func NewKeyListHandler(printer *output.Printer, format option.Format) (metadata.KeyListHandler, error) {
+	switch option.FormatType(format.CurrentType) {
+	case option.FormatTypeJSON:
+		return json.NewKeyListHandler(printer), nil
+	case option.FormatTypeText:
+		return text.NewKeyListHandler(printer), nil
+	}
+	return nil, fmt.Errorf("unrecognized output format %s", format.CurrentType)
+}
+
+// NewCertListHandler creates a new metadata CertListHandler for rendering the
+// list of certificate files in the trust store based on the output format.
+func NewCertListHandler(printer *output.Printer, format option.Format) (metadata.CertListHandler, error) {
+	switch option.FormatType(format.CurrentType) {
+	case option.FormatTypeJSON:
+		return json.NewCertListHandler(printer), nil
+	case option.FormatTypeText:
+		return text.NewCertListHandler(printer), nil
+	}
+	return nil, fmt.Errorf("unrecognized output format %s", format.CurrentType)
+}
+
+// NewCertShowHandler creates a new metadata CertShowHandler for rendering the
+// details of certificates based on the output format.
+func NewCertShowHandler(printer *output.Printer, format option.Format) (metadata.CertShowHandler, error) {
+	switch option.FormatType(format.CurrentType) {
+	case option.FormatTypeJSON:
+		return json.NewCertShowHandler(printer), nil
+	case option.FormatTypeText:
+		return text.NewCertShowHandler(printer), nil
+	case option.FormatTypePEM:
+		return pem.NewCertShowHandler(printer), nil
+	}
+	return nil, fmt.Errorf("unrecognized output format %s", format.CurrentType)
+}
+
+// NewPluginListHandler creates a new metadata PluginListHandler for rendering
+// the list of installed plugins based on the output format.
+func NewPluginListHandler(printer *output.Printer, format option.Format) (metadata.PluginListHandler, error) {
+	switch option.FormatType(format.CurrentType) {
+	case option.FormatTypeJSON:
+		return json.NewPluginListHandler(printer), nil
+	case option.FormatTypeText:
+		return text.NewPluginListHandler(printer), nil
+	}
+	return nil, fmt.Errorf("unrecognized output format %s", format.CurrentType)
+}
@@ -17,8 +17,13 @@
 package metadata
 
 import (
+	"crypto/x509"
+	"time"
+
 	"github.com/notaryproject/notation-core-go/signature"
 	"github.com/notaryproject/notation-go"
+	"github.com/notaryproject/notation-go/config"
+	"github.com/notaryproject/notation-go/plugin/proto"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 )
 
@@ -58,6 +63,10 @@ type VerifyHandler interface {
 	// OnResolvingTagReference outputs the tag reference warning.
 	OnResolvingTagReference(reference string)
 
+	// OnHistoricalEvaluation marks the verification as a historical
+	// evaluation at the time at.
+	OnHistoricalEvaluation(at time.Time)
+
 	// OnVerifySucceeded sets the successful verification result for the handler.
 	//
 	// outcomes must not be nil or empty.
@@ -71,6 +80,10 @@ type VerifyHandler interface {
 type BlobVerifyHandler interface {
 	Renderer
 
+	// OnHistoricalEvaluation marks the verification as a historical
+	// evaluation at the time at.
+	OnHistoricalEvaluation(at time.Time)
+
 	// OnVerifySucceeded sets the successful verification result for the handler.
 	//
 	// outcomes must not be nil or empty.
@@ -89,3 +102,59 @@ type ListHandler interface {
 	// OnSignatureListed adds the signature digest to be rendered.
 	OnSignatureListed(signatureManifest ocispec.Descriptor) error
 }
+
+// KeyShowHandler is a handler for rendering the details of a signing key.
+type KeyShowHandler interface {
+	Renderer
+
+	// OnLocalKeyLoaded sets the local signing key along with its key spec and
+	// its certificate chain for the handler.
+	OnLocalKeyLoaded(key config.KeySuite, isDefault bool, keySpec string, certChain []*x509.Certificate)
+
+	// OnPluginKeyDescribed sets the plugin signing key along with the key spec
+	// described by the plugin for the handler.
+	OnPluginKeyDescribed(key config.KeySuite, isDefault bool, keySpec string)
+}
+
+// KeyListHandler is a handler for rendering the list of signing keys.
+type KeyListHandler interface {
+	Renderer
+
+	// OnKeysLoaded sets the signing keys and the name of the default signing
+	// key for the handler.
+	OnKeysLoaded(keys []config.KeySuite, defaultKey *string)
+}
+
+// CertListHandler is a handler for rendering the list of certificate files in
+// the trust store.
+type CertListHandler interface {
+	Renderer
+
+	// OnCertificatesListed sets the paths of the certificate files in the trust
+	// store for the handler.
+	OnCertificatesListed(certPaths []string)
+
+	// OnSystemCertificatesListed sets the certificates of the system root
+	// certificate bundle at bundlePath for the handler.
+	OnSystemCertificatesListed(bundlePath string, certs []*x509.Certificate)
+}
+
+// CertShowHandler is a handler for rendering the details of the certificates
+// in a certificate file of the trust store.
+type CertShowHandler interface {
+	Renderer
+
+	// OnCertificatesLoaded sets the certificates read from the certificate
+	// file for the handler.
+	OnCertificatesLoaded(certs []*x509.Certificate)
+}
+
+// PluginListHandler is a handler for rendering the list of installed plugins.
+type PluginListHandler interface {
+	Renderer
+
+	// OnPluginListed adds an installed plugin along with its metadata to be
+	// rendered. err is the error of loading the plugin or getting its metadata,
+	// in which case metadata is nil.
+	OnPluginListed(name string, metadata *proto.GetMetadataResponse, err error)
+}
@@ -0,0 +1,92 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package json
+
+import (
+	"crypto/x509"
+	"path/filepath"
+
+	"github.com/notaryproject/notation/cmd/notation/internal/display/output"
+)
+
+// certFile is the certificate file in the trust store for printing in JSON
+// format.
+type certFile struct {
+	StoreType   string `json:"storeType"`
+	StoreName   string `json:"storeName"`
+	Certificate string `json:"certificate"`
+	Path        string `json:"path"`
+}
+
+// systemCert is a certificate of the system root certificate bundle for
+// printing in JSON format.
+type systemCert struct {
+	certificate
+	Path string `json:"path"`
+}
+
+// CertListHandler is a handler for rendering the list of certificate files in
+// the trust store in JSON format. It implements the metadata.CertListHandler
+// interface.
+type CertListHandler struct {
+	printer     *output.Printer
+	certs       []*certFile
+	systemCerts []*systemCert
+}
+
+// NewCertListHandler creates a CertListHandler to render the list of
+// certificate files in JSON format.
+func NewCertListHandler(printer *output.Printer) *CertListHandler {
+	return &CertListHandler{
+		printer: printer,
+		certs:   []*certFile{},
+	}
+}
+
+// OnCertificatesListed sets the paths of the certificate files in the trust
+// store for the handler.
+//
+// Each path is in the form of {storeType}/{storeName}/{fileName}.
+func (h *CertListHandler) OnCertificatesListed(certPaths []string) {
+	for _, certPath := range certPaths {
+		storeDir := filepath.Dir(certPath)
+		h.certs = append(h.certs, &certFile{
+			StoreType:   filepath.Base(filepath.Dir(storeDir)),
+			StoreName:   filepath.Base(storeDir),
+			Certificate: filepath.Base(certPath),
+			Path:        certPath,
+		})
+	}
+}
+
+// OnSystemCertificatesListed sets the certificates of the system root
+// certificate bundle at bundlePath for the handler.
+func (h *CertListHandler) OnSystemCertificatesListed(bundlePath string, certs []*x509.Certificate) {
+	h.systemCerts = []*systemCert{}
+	for _, cert := range getCertificates(certs) {
+		h.systemCerts = append(h.systemCerts, &systemCert{
+			certificate: *cert,
+			Path:        bundlePath,
+		})
+	}
+}
+
+// Render prints out the list of certificate files, or the certificates of the
+// system root certificate bundle if listed, in JSON format.
+func (h *CertListHandler) Render() error {
+	if h.systemCerts != nil {
+		return output.PrintPrettyJSON(h.printer, h.systemCerts)
+	}
+	return output.PrintPrettyJSON(h.printer, h.certs)
+}
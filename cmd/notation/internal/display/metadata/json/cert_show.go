@@ -0,0 +1,120 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package json
+
+import (
+	"crypto/x509"
+	"encoding/hex"
+
+	"github.com/notaryproject/notation/cmd/notation/internal/display/output"
+	nx509 "github.com/notaryproject/notation/internal/x509"
+)
+
+// certificateFull is the full certificate information of a certificate in the
+// trust store for printing in JSON format.
+type certificateFull struct {
+	certificateDetails
+	SerialNumber            string                   `json:"serialNumber"`
+	KeyAlgorithm            string                   `json:"keyAlgorithm"`
+	KeySize                 int                      `json:"keySize,omitempty"`
+	KeyUsages               []string                 `json:"keyUsages,omitempty"`
+	IsCA                    bool                     `json:"isCA"`
+	MaxPathLength           *int                     `json:"maxPathLength,omitempty"`
+	SubjectAlternativeNames *subjectAlternativeNames `json:"subjectAlternativeNames,omitempty"`
+	OCSPServers             []string                 `json:"OCSPServers,omitempty"`
+	IssuingCertificateURLs  []string                 `json:"issuingCertificateURLs,omitempty"`
+	CRLDistributionPoints   []string                 `json:"CRLDistributionPoints,omitempty"`
+}
+
+// subjectAlternativeNames is the subject alternative names of a certificate
+// for printing in JSON format.
+type subjectAlternativeNames struct {
+	DNSNames       []string `json:"DNSNames,omitempty"`
+	EmailAddresses []string `json:"emailAddresses,omitempty"`
+	IPAddresses    []string `json:"IPAddresses,omitempty"`
+	URIs           []string `json:"URIs,omitempty"`
+}
+
+// CertShowHandler is a handler for rendering the details of certificates in
+// JSON format. It implements the metadata.CertShowHandler interface.
+type CertShowHandler struct {
+	printer *output.Printer
+	certs   []*certificateFull
+}
+
+// NewCertShowHandler creates a CertShowHandler to render the details of
+// certificates in JSON format.
+func NewCertShowHandler(printer *output.Printer) *CertShowHandler {
+	return &CertShowHandler{
+		printer: printer,
+		certs:   []*certificateFull{},
+	}
+}
+
+// OnCertificatesLoaded sets the certificates read from the certificate file
+// for the handler.
+func (h *CertShowHandler) OnCertificatesLoaded(certs []*x509.Certificate) {
+	details := getCertificateDetails(certs)
+	for i, cert := range certs {
+		keyAlgorithm, keySize := nx509.PublicKeyAlgorithm(cert)
+		h.certs = append(h.certs, &certificateFull{
+			certificateDetails:      *details[i],
+			SerialNumber:            hex.EncodeToString(cert.SerialNumber.Bytes()),
+			KeyAlgorithm:            keyAlgorithm,
+			KeySize:                 keySize,
+			KeyUsages:               nx509.KeyUsageNames(cert),
+			IsCA:                    cert.IsCA,
+			MaxPathLength:           getMaxPathLength(cert),
+			SubjectAlternativeNames: getSubjectAlternativeNames(cert),
+			OCSPServers:             cert.OCSPServer,
+			IssuingCertificateURLs:  cert.IssuingCertificateURL,
+			CRLDistributionPoints:   cert.CRLDistributionPoints,
+		})
+	}
+}
+
+// Render prints out the details of the certificates in JSON format.
+func (h *CertShowHandler) Render() error {
+	return output.PrintPrettyJSON(h.printer, h.certs)
+}
+
+// getMaxPathLength returns the path length constraint of a CA certificate, or
+// nil if the certificate has no such constraint.
+func getMaxPathLength(cert *x509.Certificate) *int {
+	if !cert.BasicConstraintsValid || !cert.IsCA {
+		return nil
+	}
+	if cert.MaxPathLen <= 0 && !cert.MaxPathLenZero {
+		return nil
+	}
+	maxPathLength := cert.MaxPathLen
+	return &maxPathLength
+}
+
+func getSubjectAlternativeNames(cert *x509.Certificate) *subjectAlternativeNames {
+	if len(cert.DNSNames) == 0 && len(cert.EmailAddresses) == 0 && len(cert.IPAddresses) == 0 && len(cert.URIs) == 0 {
+		return nil
+	}
+	sans := &subjectAlternativeNames{
+		DNSNames:       cert.DNSNames,
+		EmailAddresses: cert.EmailAddresses,
+	}
+	for _, ip := range cert.IPAddresses {
+		sans.IPAddresses = append(sans.IPAddresses, ip.String())
+	}
+	for _, uri := range cert.URIs {
+		sans.URIs = append(sans.URIs, uri.String())
+	}
+	return sans
+}
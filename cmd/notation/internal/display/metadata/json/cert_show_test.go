@@ -0,0 +1,53 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package json
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/json"
+	"testing"
+
+	"github.com/notaryproject/notation-core-go/testhelper"
+	"github.com/notaryproject/notation/cmd/notation/internal/display/output"
+)
+
+func TestCertShowHandler(t *testing.T) {
+	buf := &bytes.Buffer{}
+	handler := NewCertShowHandler(output.NewPrinter(buf, buf))
+	rsaChain := testhelper.GetRSACertTuple(3072)
+	handler.OnCertificatesLoaded([]*x509.Certificate{rsaChain.Cert, testhelper.GetRSARootCertificate().Cert})
+	if err := handler.Render(); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 certificates, got: %v", got)
+	}
+	for _, field := range []string{"SHA256Fingerprint", "SHA1Fingerprint", "issuedTo", "issuedBy", "validFrom", "expiry", "serialNumber", "keyAlgorithm", "keySize", "isCA"} {
+		if _, ok := got[0][field]; !ok {
+			t.Fatalf("expected certificate field %q, got: %v", field, got[0])
+		}
+	}
+	if got[0]["keyAlgorithm"] != "RSA" || got[0]["keySize"] != float64(3072) {
+		t.Fatalf("unexpected key algorithm: %v %v", got[0]["keyAlgorithm"], got[0]["keySize"])
+	}
+	if got[1]["isCA"] != true {
+		t.Fatalf("expected root certificate to be a CA, got: %v", got[1])
+	}
+}
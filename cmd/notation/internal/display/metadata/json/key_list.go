@@ -0,0 +1,63 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package json
+
+import (
+	"github.com/notaryproject/notation-go/config"
+	"github.com/notaryproject/notation/cmd/notation/internal/display/output"
+)
+
+// KeyListHandler is a handler for rendering the list of signing keys in JSON
+// format. It implements the metadata.KeyListHandler interface.
+type KeyListHandler struct {
+	printer *output.Printer
+	keys    []*key
+}
+
+// NewKeyListHandler creates a KeyListHandler to render the list of signing
+// keys in JSON format.
+func NewKeyListHandler(printer *output.Printer) *KeyListHandler {
+	return &KeyListHandler{
+		printer: printer,
+		keys:    []*key{},
+	}
+}
+
+// OnKeysLoaded sets the signing keys and the name of the default signing key
+// for the handler.
+func (h *KeyListHandler) OnKeysLoaded(keys []config.KeySuite, defaultKey *string) {
+	for _, keySuite := range keys {
+		k := &key{
+			Name:    keySuite.Name,
+			Default: defaultKey != nil && *defaultKey == keySuite.Name,
+		}
+		switch {
+		case keySuite.X509KeyPair != nil:
+			k.Type = keyTypeLocal
+			k.KeyPath = keySuite.KeyPath
+			k.CertificatePath = keySuite.CertificatePath
+		case keySuite.ExternalKey != nil:
+			k.Type = keyTypePlugin
+			k.KeyID = keySuite.ID
+			k.PluginName = keySuite.PluginName
+			k.PluginConfig = keySuite.PluginConfig
+		}
+		h.keys = append(h.keys, k)
+	}
+}
+
+// Render prints out the list of signing keys in JSON format.
+func (h *KeyListHandler) Render() error {
+	return output.PrintPrettyJSON(h.printer, h.keys)
+}
@@ -0,0 +1,119 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package json
+
+import (
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/hex"
+	"time"
+
+	"github.com/notaryproject/notation-go/config"
+	"github.com/notaryproject/notation/cmd/notation/internal/display/output"
+	nx509 "github.com/notaryproject/notation/internal/x509"
+)
+
+const (
+	// keyTypeLocal is the type of a signing key stored locally.
+	keyTypeLocal = "local"
+
+	// keyTypePlugin is the type of a signing key managed by a plugin.
+	keyTypePlugin = "plugin"
+)
+
+// key is the signing key information for printing in JSON format.
+type key struct {
+	Name            string                `json:"name"`
+	Default         bool                  `json:"default"`
+	Type            string                `json:"type"`
+	KeySpec         string                `json:"keySpec,omitempty"`
+	KeyPath         string                `json:"keyPath,omitempty"`
+	CertificatePath string                `json:"certificatePath,omitempty"`
+	KeyID           string                `json:"keyId,omitempty"`
+	PluginName      string                `json:"pluginName,omitempty"`
+	PluginConfig    map[string]string     `json:"pluginConfig,omitempty"`
+	Certificates    []*certificateDetails `json:"certificates,omitempty"`
+}
+
+// certificateDetails is the detailed certificate information for printing in
+// JSON format.
+type certificateDetails struct {
+	certificate
+	SHA1Fingerprint   string    `json:"SHA1Fingerprint"`
+	ValidFrom         time.Time `json:"validFrom"`
+	ExtendedKeyUsages []string  `json:"extendedKeyUsages,omitempty"`
+}
+
+// KeyShowHandler is a handler for rendering the details of a signing key in
+// JSON format. It implements the metadata.KeyShowHandler interface.
+type KeyShowHandler struct {
+	printer *output.Printer
+	key     *key
+}
+
+// NewKeyShowHandler creates a KeyShowHandler to render signing key details in
+// JSON format.
+func NewKeyShowHandler(printer *output.Printer) *KeyShowHandler {
+	return &KeyShowHandler{
+		printer: printer,
+	}
+}
+
+// OnLocalKeyLoaded sets the local signing key along with its key spec and its
+// certificate chain for the handler.
+func (h *KeyShowHandler) OnLocalKeyLoaded(keySuite config.KeySuite, isDefault bool, keySpec string, certChain []*x509.Certificate) {
+	h.key = &key{
+		Name:            keySuite.Name,
+		Default:         isDefault,
+		Type:            keyTypeLocal,
+		KeySpec:         keySpec,
+		KeyPath:         keySuite.KeyPath,
+		CertificatePath: keySuite.CertificatePath,
+		Certificates:    getCertificateDetails(certChain),
+	}
+}
+
+// OnPluginKeyDescribed sets the plugin signing key along with the key spec
+// described by the plugin for the handler.
+func (h *KeyShowHandler) OnPluginKeyDescribed(keySuite config.KeySuite, isDefault bool, keySpec string) {
+	h.key = &key{
+		Name:         keySuite.Name,
+		Default:      isDefault,
+		Type:         keyTypePlugin,
+		KeySpec:      keySpec,
+		KeyID:        keySuite.ID,
+		PluginName:   keySuite.PluginName,
+		PluginConfig: keySuite.PluginConfig,
+	}
+}
+
+// Render prints out the signing key details in JSON format.
+func (h *KeyShowHandler) Render() error {
+	return output.PrintPrettyJSON(h.printer, h.key)
+}
+
+func getCertificateDetails(certChain []*x509.Certificate) []*certificateDetails {
+	certificates := getCertificates(certChain)
+	details := make([]*certificateDetails, 0, len(certChain))
+	for i, cert := range certChain {
+		hash := sha1.Sum(cert.Raw)
+		details = append(details, &certificateDetails{
+			certificate:       *certificates[i],
+			SHA1Fingerprint:   hex.EncodeToString(hash[:]),
+			ValidFrom:         cert.NotBefore,
+			ExtendedKeyUsages: nx509.ExtKeyUsageNames(cert),
+		})
+	}
+	return details
+}
@@ -0,0 +1,63 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package json
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/json"
+	"testing"
+
+	"github.com/notaryproject/notation-core-go/testhelper"
+	"github.com/notaryproject/notation-go/config"
+	"github.com/notaryproject/notation/cmd/notation/internal/display/output"
+)
+
+func TestKeyShowHandler(t *testing.T) {
+	buf := &bytes.Buffer{}
+	handler := NewKeyShowHandler(output.NewPrinter(buf, buf))
+	key := config.KeySuite{
+		Name: "wabbit-networks",
+		X509KeyPair: &config.X509KeyPair{
+			KeyPath:         "/path/to/key",
+			CertificatePath: "/path/to/cert",
+		},
+	}
+	cert := testhelper.GetRSALeafCertificate().Cert
+	handler.OnLocalKeyLoaded(key, true, "RSA-3072", []*x509.Certificate{cert})
+	if err := handler.Render(); err != nil {
+		t.Fatal(err)
+	}
+
+	var got map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got["name"] != "wabbit-networks" || got["default"] != true || got["type"] != "local" || got["keySpec"] != "RSA-3072" {
+		t.Fatalf("unexpected key: %v", got)
+	}
+	if _, ok := got["pluginName"]; ok {
+		t.Fatal("expected pluginName to be omitted for a local key")
+	}
+	certs, ok := got["certificates"].([]any)
+	if !ok || len(certs) != 1 {
+		t.Fatalf("expected 1 certificate, got: %v", got["certificates"])
+	}
+	certificate := certs[0].(map[string]any)
+	for _, field := range []string{"SHA256Fingerprint", "SHA1Fingerprint", "issuedTo", "issuedBy", "validFrom", "expiry", "extendedKeyUsages"} {
+		if _, ok := certificate[field]; !ok {
+			t.Fatalf("expected certificate field %q, got: %v", field, certificate)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package json
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/notaryproject/notation-go/config"
+	"github.com/notaryproject/notation-go/plugin/proto"
+	"github.com/notaryproject/notation/cmd/notation/internal/display/output"
+)
+
+func TestKeyListHandler(t *testing.T) {
+	buf := &bytes.Buffer{}
+	handler := NewKeyListHandler(output.NewPrinter(buf, buf))
+	defaultKey := "wabbit-networks"
+	handler.OnKeysLoaded([]config.KeySuite{
+		{
+			Name: "wabbit-networks",
+			X509KeyPair: &config.X509KeyPair{
+				KeyPath:         "/path/to/key",
+				CertificatePath: "/path/to/cert",
+			},
+		},
+		{
+			Name: "acme-rockets",
+			ExternalKey: &config.ExternalKey{
+				ID:         "key-id",
+				PluginName: "acme-plugin",
+			},
+		},
+	}, &defaultKey)
+	if err := handler.Render(); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	expected := []map[string]any{
+		{
+			"name":            "wabbit-networks",
+			"default":         true,
+			"type":            "local",
+			"keyPath":         "/path/to/key",
+			"certificatePath": "/path/to/cert",
+		},
+		{
+			"name":       "acme-rockets",
+			"default":    false,
+			"type":       "plugin",
+			"keyId":      "key-id",
+			"pluginName": "acme-plugin",
+		},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestKeyListHandler_Empty(t *testing.T) {
+	buf := &bytes.Buffer{}
+	handler := NewKeyListHandler(output.NewPrinter(buf, buf))
+	handler.OnKeysLoaded(nil, nil)
+	if err := handler.Render(); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); got != "[]\n" {
+		t.Fatalf("expected empty list, got %q", got)
+	}
+}
+
+func TestCertListHandler(t *testing.T) {
+	buf := &bytes.Buffer{}
+	handler := NewCertListHandler(output.NewPrinter(buf, buf))
+	certPath := filepath.Join("truststore", "x509", "ca", "acme-rockets", "root.crt")
+	handler.OnCertificatesListed([]string{certPath})
+	if err := handler.Render(); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	expected := []map[string]any{
+		{
+			"storeType":   "ca",
+			"storeName":   "acme-rockets",
+			"certificate": "root.crt",
+			"path":        certPath,
+		},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestPluginListHandler(t *testing.T) {
+	buf := &bytes.Buffer{}
+	handler := NewPluginListHandler(output.NewPrinter(buf, buf))
+	handler.OnPluginListed("acme-plugin", &proto.GetMetadataResponse{
+		Name:                      "acme-plugin",
+		Description:               "Acme signing plugin",
+		Version:                   "1.0.0",
+		URL:                       "https://example.com",
+		SupportedContractVersions: []string{"1.0"},
+		Capabilities:              []proto.Capability{proto.CapabilitySignatureGenerator},
+	}, nil)
+	handler.OnPluginListed("broken-plugin", nil, errors.New("plugin executable file not found"))
+	if err := handler.Render(); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	expected := []map[string]any{
+		{
+			"name":                      "acme-plugin",
+			"description":               "Acme signing plugin",
+			"version":                   "1.0.0",
+			"url":                       "https://example.com",
+			"supportedContractVersions": []any{"1.0"},
+			"capabilities":              []any{string(proto.CapabilitySignatureGenerator)},
+		},
+		{
+			"name":                      "broken-plugin",
+			"description":               "",
+			"version":                   "",
+			"url":                       "",
+			"supportedContractVersions": []any{},
+			"capabilities":              []any{},
+			"error":                     "plugin executable file not found",
+		},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package json
+
+import (
+	"github.com/notaryproject/notation-go/plugin/proto"
+	"github.com/notaryproject/notation/cmd/notation/internal/display/output"
+)
+
+// pluginInfo is the installed plugin information for printing in JSON format.
+type pluginInfo struct {
+	Name                      string   `json:"name"`
+	Description               string   `json:"description"`
+	Version                   string   `json:"version"`
+	URL                       string   `json:"url"`
+	SupportedContractVersions []string `json:"supportedContractVersions"`
+	Capabilities              []string `json:"capabilities"`
+	Error                     string   `json:"error,omitempty"`
+}
+
+// PluginListHandler is a handler for rendering the list of installed plugins
+// in JSON format. It implements the metadata.PluginListHandler interface.
+type PluginListHandler struct {
+	printer *output.Printer
+	plugins []*pluginInfo
+}
+
+// NewPluginListHandler creates a PluginListHandler to render the list of
+// installed plugins in JSON format.
+func NewPluginListHandler(printer *output.Printer) *PluginListHandler {
+	return &PluginListHandler{
+		printer: printer,
+		plugins: []*pluginInfo{},
+	}
+}
+
+// OnPluginListed adds an installed plugin along with its metadata to be
+// rendered.
+func (h *PluginListHandler) OnPluginListed(name string, metadata *proto.GetMetadataResponse, err error) {
+	info := &pluginInfo{
+		Name:                      name,
+		SupportedContractVersions: []string{},
+		Capabilities:              []string{},
+	}
+	if metadata != nil {
+		info.Description = metadata.Description
+		info.Version = metadata.Version
+		info.URL = metadata.URL
+		info.SupportedContractVersions = append(info.SupportedContractVersions, metadata.SupportedContractVersions...)
+		for _, c := range metadata.Capabilities {
+			info.Capabilities = append(info.Capabilities, string(c))
+		}
+	}
+	if err != nil {
+		info.Error = err.Error()
+	}
+	h.plugins = append(h.plugins, info)
+}
+
+// Render prints out the list of installed plugins in JSON format.
+func (h *PluginListHandler) Render() error {
+	return output.PrintPrettyJSON(h.printer, h.plugins)
+}
@@ -0,0 +1,53 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package pem provides handlers for rendering certificates in PEM format.
+package pem
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+
+	"github.com/notaryproject/notation/cmd/notation/internal/display/output"
+)
+
+// CertShowHandler is a handler for rendering certificates in PEM format. It
+// implements the metadata.CertShowHandler interface.
+type CertShowHandler struct {
+	printer *output.Printer
+	certs   []*x509.Certificate
+}
+
+// NewCertShowHandler creates a CertShowHandler to render certificates in PEM
+// format.
+func NewCertShowHandler(printer *output.Printer) *CertShowHandler {
+	return &CertShowHandler{
+		printer: printer,
+	}
+}
+
+// OnCertificatesLoaded sets the certificates read from the certificate file
+// for the handler.
+func (h *CertShowHandler) OnCertificatesLoaded(certs []*x509.Certificate) {
+	h.certs = certs
+}
+
+// Render prints out the certificates as PEM blocks.
+func (h *CertShowHandler) Render() error {
+	for _, cert := range h.certs {
+		if err := pem.Encode(h.printer, &pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -14,6 +14,8 @@
 package text
 
 import (
+	"time"
+
 	"github.com/notaryproject/notation-go"
 	"github.com/notaryproject/notation/cmd/notation/internal/display/output"
 )
@@ -25,6 +27,7 @@ type BlobVerifyHandler struct {
 	printer  *output.Printer
 	outcome  *notation.VerificationOutcome
 	blobPath string
+	at       time.Time
 }
 
 // NewBlobVerifyHandler creates a new BlobVerifyHandler.
@@ -34,6 +37,12 @@ func NewBlobVerifyHandler(printer *output.Printer) *BlobVerifyHandler {
 	}
 }
 
+// OnHistoricalEvaluation marks the verification as a historical evaluation at
+// the time at.
+func (h *BlobVerifyHandler) OnHistoricalEvaluation(at time.Time) {
+	h.at = at
+}
+
 // OnVerifySucceeded sets the successful verification result for the handler.
 //
 // outcomes must not be nil or empty.
@@ -44,5 +53,5 @@ func (h *BlobVerifyHandler) OnVerifySucceeded(outcomes []*notation.VerificationO
 
 // Render prints out the verification results in human-readable format.
 func (h *BlobVerifyHandler) Render() error {
-	return printVerificationSuccess(h.printer, h.outcome, h.blobPath, false)
+	return printVerificationSuccess(h.printer, h.outcome, h.blobPath, false, h.at)
 }
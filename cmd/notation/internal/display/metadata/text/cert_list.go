@@ -0,0 +1,62 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"crypto/x509"
+
+	"github.com/notaryproject/notation/cmd/notation/internal/display/output"
+	"github.com/notaryproject/notation/internal/ioutil"
+)
+
+// CertListHandler is a handler for rendering the list of certificate files in
+// the trust store in human-readable format. It implements the
+// metadata.CertListHandler interface.
+type CertListHandler struct {
+	printer     *output.Printer
+	certPaths   []string
+	bundlePath  string
+	systemCerts []*x509.Certificate
+}
+
+// NewCertListHandler creates a CertListHandler to render the list of
+// certificate files in human-readable format.
+func NewCertListHandler(printer *output.Printer) *CertListHandler {
+	return &CertListHandler{
+		printer: printer,
+	}
+}
+
+// OnCertificatesListed sets the paths of the certificate files in the trust
+// store for the handler.
+func (h *CertListHandler) OnCertificatesListed(certPaths []string) {
+	h.certPaths = certPaths
+}
+
+// OnSystemCertificatesListed sets the certificates of the system root
+// certificate bundle at bundlePath for the handler.
+func (h *CertListHandler) OnSystemCertificatesListed(bundlePath string, certs []*x509.Certificate) {
+	h.bundlePath = bundlePath
+	h.systemCerts = certs
+}
+
+// Render prints out the list of certificate files in a table, or the
+// certificates of the system root certificate bundle if listed. Nothing is
+// printed if there is no certificate.
+func (h *CertListHandler) Render() error {
+	if h.bundlePath != "" {
+		return ioutil.PrintSystemCerts(h.printer, h.bundlePath, h.systemCerts)
+	}
+	return ioutil.PrintCertMap(h.printer, h.certPaths)
+}
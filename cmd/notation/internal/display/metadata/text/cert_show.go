@@ -0,0 +1,111 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"strings"
+
+	"github.com/notaryproject/notation/cmd/notation/internal/display/output"
+	nx509 "github.com/notaryproject/notation/internal/x509"
+)
+
+const certSeparator = "--------------------------------------------------------------------------------"
+
+// CertShowHandler is a handler for rendering the details of certificates in
+// human-readable format. It implements the metadata.CertShowHandler interface.
+type CertShowHandler struct {
+	printer *output.Printer
+	certs   []*x509.Certificate
+}
+
+// NewCertShowHandler creates a CertShowHandler to render the details of
+// certificates in human-readable format.
+func NewCertShowHandler(printer *output.Printer) *CertShowHandler {
+	return &CertShowHandler{
+		printer: printer,
+	}
+}
+
+// OnCertificatesLoaded sets the certificates read from the certificate file
+// for the handler.
+func (h *CertShowHandler) OnCertificatesLoaded(certs []*x509.Certificate) {
+	h.certs = certs
+}
+
+// Render prints out the details of the certificates separated by lines.
+func (h *CertShowHandler) Render() error {
+	h.printer.Println("Certificate details")
+	h.printer.Println(certSeparator)
+	for i, cert := range h.certs {
+		h.printCert(cert)
+		if i != len(h.certs)-1 {
+			h.printer.Println(certSeparator)
+		}
+	}
+	return nil
+}
+
+// printCert prints out the details of a certificate.
+func (h *CertShowHandler) printCert(cert *x509.Certificate) {
+	h.printer.Println("Issuer:", cert.Issuer)
+	h.printer.Println("Subject:", cert.Subject)
+	h.printer.Println("Serial number:", hex.EncodeToString(cert.SerialNumber.Bytes()))
+	h.printer.Println("Valid from:", cert.NotBefore)
+	h.printer.Println("Valid to:", cert.NotAfter)
+	keyAlgorithm, keySize := nx509.PublicKeyAlgorithm(cert)
+	if keySize > 0 {
+		h.printer.Printf("Key algorithm: %s %d\n", keyAlgorithm, keySize)
+	} else {
+		h.printer.Println("Key algorithm:", keyAlgorithm)
+	}
+	h.printer.Println("IsCA:", cert.IsCA)
+	if cert.BasicConstraintsValid && cert.IsCA && (cert.MaxPathLen > 0 || cert.MaxPathLenZero) {
+		h.printer.Println("Max path length:", cert.MaxPathLen)
+	}
+	h.printList("Key usages:", nx509.KeyUsageNames(cert))
+	h.printList("Extended key usages:", nx509.ExtKeyUsageNames(cert))
+	h.printList("DNS names:", cert.DNSNames)
+	h.printList("Email addresses:", cert.EmailAddresses)
+	var ipAddresses []string
+	for _, ip := range cert.IPAddresses {
+		ipAddresses = append(ipAddresses, ip.String())
+	}
+	h.printList("IP addresses:", ipAddresses)
+	var uris []string
+	for _, uri := range cert.URIs {
+		uris = append(uris, uri.String())
+	}
+	h.printList("URIs:", uris)
+	h.printList("OCSP servers:", cert.OCSPServer)
+	h.printList("Issuing certificate URLs:", cert.IssuingCertificateURL)
+	h.printList("CRL distribution points:", cert.CRLDistributionPoints)
+
+	sha1Hash := sha1.Sum(cert.Raw)
+	h.printer.Println("SHA1 Thumbprint:", hex.EncodeToString(sha1Hash[:]))
+	sha256Hash := sha256.Sum256(cert.Raw)
+	h.printer.Println("SHA256 Thumbprint:", hex.EncodeToString(sha256Hash[:]))
+}
+
+// printList prints out values after the label separated by commas. Nothing is
+// printed if values is empty.
+func (h *CertShowHandler) printList(label string, values []string) {
+	if len(values) == 0 {
+		return
+	}
+	h.printer.Println(label, strings.Join(values, ", "))
+}
@@ -0,0 +1,48 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"github.com/notaryproject/notation-go/config"
+	"github.com/notaryproject/notation/cmd/notation/internal/display/output"
+	"github.com/notaryproject/notation/internal/ioutil"
+)
+
+// KeyListHandler is a handler for rendering the list of signing keys in
+// human-readable format. It implements the metadata.KeyListHandler interface.
+type KeyListHandler struct {
+	printer    *output.Printer
+	keys       []config.KeySuite
+	defaultKey *string
+}
+
+// NewKeyListHandler creates a KeyListHandler to render the list of signing
+// keys in human-readable format.
+func NewKeyListHandler(printer *output.Printer) *KeyListHandler {
+	return &KeyListHandler{
+		printer: printer,
+	}
+}
+
+// OnKeysLoaded sets the signing keys and the name of the default signing key
+// for the handler.
+func (h *KeyListHandler) OnKeysLoaded(keys []config.KeySuite, defaultKey *string) {
+	h.keys = keys
+	h.defaultKey = defaultKey
+}
+
+// Render prints out the list of signing keys in a table.
+func (h *KeyListHandler) Render() error {
+	return ioutil.PrintKeyMap(h.printer, h.defaultKey, h.keys)
+}
@@ -0,0 +1,69 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/notaryproject/notation-go/plugin/proto"
+	"github.com/notaryproject/notation/cmd/notation/internal/display/output"
+)
+
+// PluginListHandler is a handler for rendering the list of installed plugins
+// in human-readable format. It implements the metadata.PluginListHandler
+// interface.
+type PluginListHandler struct {
+	printer *output.Printer
+	plugins []pluginEntry
+}
+
+// pluginEntry is an installed plugin along with its metadata.
+type pluginEntry struct {
+	name     string
+	metadata *proto.GetMetadataResponse
+	err      error
+}
+
+// NewPluginListHandler creates a PluginListHandler to render the list of
+// installed plugins in human-readable format.
+func NewPluginListHandler(printer *output.Printer) *PluginListHandler {
+	return &PluginListHandler{
+		printer: printer,
+	}
+}
+
+// OnPluginListed adds an installed plugin along with its metadata to be
+// rendered.
+func (h *PluginListHandler) OnPluginListed(name string, metadata *proto.GetMetadataResponse, err error) {
+	if metadata == nil {
+		metadata = &proto.GetMetadataResponse{}
+	}
+	h.plugins = append(h.plugins, pluginEntry{
+		name:     name,
+		metadata: metadata,
+		err:      err,
+	})
+}
+
+// Render prints out the list of installed plugins in a table.
+func (h *PluginListHandler) Render() error {
+	tw := tabwriter.NewWriter(h.printer, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tDESCRIPTION\tVERSION\tCAPABILITIES\tERROR\t")
+	for _, p := range h.plugins {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%v\t%v\t\n",
+			p.name, p.metadata.Description, p.metadata.Version, p.metadata.Capabilities, p.err)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,44 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/notaryproject/notation-go/plugin/proto"
+	"github.com/notaryproject/notation/cmd/notation/internal/display/output"
+)
+
+func TestPluginListHandler(t *testing.T) {
+	buf := &bytes.Buffer{}
+	handler := NewPluginListHandler(output.NewPrinter(buf, buf))
+	handler.OnPluginListed("acme-plugin", &proto.GetMetadataResponse{
+		Description:  "Acme signing plugin",
+		Version:      "1.0.0",
+		Capabilities: []proto.Capability{proto.CapabilitySignatureGenerator},
+	}, nil)
+	handler.OnPluginListed("broken-plugin", nil, errors.New("plugin executable file not found"))
+	if err := handler.Render(); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "NAME            DESCRIPTION           VERSION   CAPABILITIES                ERROR                              \n" +
+		"acme-plugin     Acme signing plugin   1.0.0     [SIGNATURE_GENERATOR.RAW]   <nil>                              \n" +
+		"broken-plugin                                   []                          plugin executable file not found   \n"
+	if got := buf.String(); got != expected {
+		t.Fatalf("expected\n%q\ngot\n%q", expected, got)
+	}
+}
@@ -14,6 +14,8 @@
 package text
 
 import (
+	"time"
+
 	"github.com/notaryproject/notation-go"
 	"github.com/notaryproject/notation/cmd/notation/internal/display/output"
 )
@@ -25,6 +27,7 @@ type VerifyHandler struct {
 	outcome         *notation.VerificationOutcome
 	digestReference string
 	hasWarning      bool
+	at              time.Time
 }
 
 // NewVerifyHandler creates a new VerifyHandler.
@@ -40,6 +43,12 @@ func (h *VerifyHandler) OnResolvingTagReference(reference string) {
 	h.hasWarning = true
 }
 
+// OnHistoricalEvaluation marks the verification as a historical evaluation at
+// the time at.
+func (h *VerifyHandler) OnHistoricalEvaluation(at time.Time) {
+	h.at = at
+}
+
 // OnVerifySucceeded sets the successful verification result for the handler.
 //
 // outcomes must not be nil or empty.
@@ -50,5 +59,5 @@ func (h *VerifyHandler) OnVerifySucceeded(outcomes []*notation.VerificationOutco
 
 // Render prints out the verification results in human-readable format.
 func (h *VerifyHandler) Render() error {
-	return printVerificationSuccess(h.printer, h.outcome, h.digestReference, h.hasWarning)
+	return printVerificationSuccess(h.printer, h.outcome, h.digestReference, h.hasWarning, h.at)
 }
@@ -19,14 +19,17 @@ import (
 	"fmt"
 	"reflect"
 	"text/tabwriter"
+	"time"
 
 	"github.com/notaryproject/notation-go"
 	"github.com/notaryproject/notation-go/verifier/trustpolicy"
 	"github.com/notaryproject/notation/cmd/notation/internal/display/output"
 )
 
-// printVerificationSuccess prints out messages when verification succeeds
-func printVerificationSuccess(printer *output.Printer, outcome *notation.VerificationOutcome, artifact string, hasWarning bool) error {
+// printVerificationSuccess prints out messages when verification succeeds.
+// If at is not zero, the verification is marked as a historical evaluation at
+// that time.
+func printVerificationSuccess(printer *output.Printer, outcome *notation.VerificationOutcome, artifact string, hasWarning bool, at time.Time) error {
 	// write out on success
 	// print out warning for any failed result with logged verification action
 	for _, result := range outcome.VerificationResults {
@@ -43,6 +46,10 @@ func printVerificationSuccess(printer *output.Printer, outcome *notation.Verific
 	}
 	if reflect.DeepEqual(outcome.VerificationLevel, trustpolicy.LevelSkip) {
 		printer.Println("Trust policy is configured to skip signature verification for", artifact)
+	} else if !at.IsZero() {
+		printer.Printf("Historical evaluation: certificate validity, signature expiry and revocation were evaluated at %s instead of the current time\n", at.Format(time.RFC3339))
+		printer.Printf("Successfully verified signature for %s as of %s\n", artifact, at.Format(time.RFC3339))
+		printUserMetadataIfPresent(printer, outcome)
 	} else {
 		printer.Println("Successfully verified signature for", artifact)
 		printUserMetadataIfPresent(printer, outcome)
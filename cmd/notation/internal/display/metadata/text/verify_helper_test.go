@@ -16,10 +16,13 @@ package text
 import (
 	"bytes"
 	"encoding/json"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/notaryproject/notation-core-go/signature"
 	"github.com/notaryproject/notation-go"
+	"github.com/notaryproject/notation-go/verifier/trustpolicy"
 	"github.com/notaryproject/notation/cmd/notation/internal/display/output"
 	"github.com/notaryproject/notation/internal/envelope"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
@@ -55,3 +58,38 @@ func TestPrintMetadataIfPresent(t *testing.T) {
 		}
 	})
 }
+
+func TestPrintVerificationSuccess(t *testing.T) {
+	outcome := &notation.VerificationOutcome{
+		EnvelopeContent:   &signature.EnvelopeContent{},
+		VerificationLevel: trustpolicy.LevelStrict,
+	}
+
+	t.Run("current time", func(t *testing.T) {
+		buf := bytes.Buffer{}
+		printer := output.NewPrinter(&buf, &buf)
+		if err := printVerificationSuccess(printer, outcome, "localhost:5000/net-monitor@sha256:abcd", false, time.Time{}); err != nil {
+			t.Fatal(err)
+		}
+		expected := "Successfully verified signature for localhost:5000/net-monitor@sha256:abcd\n"
+		if got := buf.String(); got != expected {
+			t.Errorf("unexpected output: %q", got)
+		}
+	})
+
+	t.Run("historical evaluation", func(t *testing.T) {
+		buf := bytes.Buffer{}
+		printer := output.NewPrinter(&buf, &buf)
+		at := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+		if err := printVerificationSuccess(printer, outcome, "localhost:5000/net-monitor@sha256:abcd", false, at); err != nil {
+			t.Fatal(err)
+		}
+		got := buf.String()
+		if !strings.HasPrefix(got, "Historical evaluation: ") {
+			t.Errorf("expected output to be marked as historical evaluation, but got %q", got)
+		}
+		if !strings.Contains(got, "Successfully verified signature for localhost:5000/net-monitor@sha256:abcd as of 2024-01-02T15:04:05Z\n") {
+			t.Errorf("unexpected output: %q", got)
+		}
+	})
+}
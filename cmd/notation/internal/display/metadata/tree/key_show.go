@@ -0,0 +1,99 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tree
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/notaryproject/notation-go/config"
+	"github.com/notaryproject/notation/cmd/notation/internal/display/output"
+	nx509 "github.com/notaryproject/notation/internal/x509"
+)
+
+// KeyShowHandler is a handler for rendering the details of a signing key in a
+// tree format. It implements the metadata.KeyShowHandler interface.
+type KeyShowHandler struct {
+	printer *output.Printer
+	keyNode *node
+}
+
+// NewKeyShowHandler creates a KeyShowHandler to render signing key details in
+// tree format.
+func NewKeyShowHandler(printer *output.Printer) *KeyShowHandler {
+	return &KeyShowHandler{
+		printer: printer,
+	}
+}
+
+// OnLocalKeyLoaded sets the local signing key along with its key spec and its
+// certificate chain for the handler.
+func (h *KeyShowHandler) OnLocalKeyLoaded(key config.KeySuite, isDefault bool, keySpec string, certChain []*x509.Certificate) {
+	h.keyNode = newKeyNode(key.Name, isDefault)
+	h.keyNode.AddPair("type", "local")
+	h.keyNode.AddPair("key spec", keySpec)
+	h.keyNode.AddPair("key path", key.KeyPath)
+	h.keyNode.AddPair("certificate path", key.CertificatePath)
+	addCertificateDetails(h.keyNode, certChain)
+}
+
+// OnPluginKeyDescribed sets the plugin signing key along with the key spec
+// described by the plugin for the handler.
+func (h *KeyShowHandler) OnPluginKeyDescribed(key config.KeySuite, isDefault bool, keySpec string) {
+	h.keyNode = newKeyNode(key.Name, isDefault)
+	h.keyNode.AddPair("type", "plugin")
+	h.keyNode.AddPair("key spec", keySpec)
+	h.keyNode.AddPair("key id", key.ID)
+	h.keyNode.AddPair("plugin name", key.PluginName)
+	if len(key.PluginConfig) > 0 {
+		configNode := h.keyNode.Add("plugin config")
+		for _, k := range slices.Sorted(maps.Keys(key.PluginConfig)) {
+			configNode.AddPair(k, key.PluginConfig[k])
+		}
+	}
+}
+
+// Render prints out the signing key details in tree format.
+func (h *KeyShowHandler) Render() error {
+	return h.keyNode.Print(h.printer)
+}
+
+func newKeyNode(name string, isDefault bool) *node {
+	if isDefault {
+		name += " (default)"
+	}
+	return newNode(name)
+}
+
+func addCertificateDetails(node *node, certChain []*x509.Certificate) {
+	certListNode := node.Add("certificates")
+	for _, cert := range certChain {
+		sha256Hash := sha256.Sum256(cert.Raw)
+		sha1Hash := sha1.Sum(cert.Raw)
+		certNode := certListNode.AddPair("SHA256 fingerprint", strings.ToLower(hex.EncodeToString(sha256Hash[:])))
+		certNode.AddPair("SHA1 fingerprint", hex.EncodeToString(sha1Hash[:]))
+		certNode.AddPair("issued to", cert.Subject.String())
+		certNode.AddPair("issued by", cert.Issuer.String())
+		certNode.AddPair("valid from", formatTime(cert.NotBefore))
+		certNode.AddPair("expiry", formatTime(cert.NotAfter))
+		if ekus := nx509.ExtKeyUsageNames(cert); len(ekus) > 0 {
+			certNode.AddPair("extended key usages", strings.Join(ekus, ", "))
+		}
+	}
+}
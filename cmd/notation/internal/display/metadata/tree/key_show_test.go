@@ -0,0 +1,81 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tree
+
+import (
+	"bytes"
+	"crypto/x509"
+	"strings"
+	"testing"
+
+	"github.com/notaryproject/notation-core-go/testhelper"
+	"github.com/notaryproject/notation-go/config"
+	"github.com/notaryproject/notation/cmd/notation/internal/display/output"
+)
+
+func TestKeyShowHandler_LocalKey(t *testing.T) {
+	buf := &bytes.Buffer{}
+	handler := NewKeyShowHandler(output.NewPrinter(buf, buf))
+	key := config.KeySuite{
+		Name: "wabbit-networks",
+		X509KeyPair: &config.X509KeyPair{
+			KeyPath:         "/path/to/key",
+			CertificatePath: "/path/to/cert",
+		},
+	}
+	handler.OnLocalKeyLoaded(key, true, "RSA-3072", []*x509.Certificate{testhelper.GetRSALeafCertificate().Cert})
+	if err := handler.Render(); err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{
+		"wabbit-networks (default)",
+		"├── type: local",
+		"├── key spec: RSA-3072",
+		"├── key path: /path/to/key",
+		"SHA1 fingerprint: ",
+		"extended key usages: codeSigning",
+	} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Fatalf("expected output to contain %q, got:\n%s", expected, buf.String())
+		}
+	}
+}
+
+func TestKeyShowHandler_PluginKey(t *testing.T) {
+	buf := &bytes.Buffer{}
+	handler := NewKeyShowHandler(output.NewPrinter(buf, buf))
+	key := config.KeySuite{
+		Name: "kms-key",
+		ExternalKey: &config.ExternalKey{
+			ID:           "key-id",
+			PluginName:   "kms",
+			PluginConfig: map[string]string{"region": "us-west-2"},
+		},
+	}
+	handler.OnPluginKeyDescribed(key, false, "EC-384")
+	if err := handler.Render(); err != nil {
+		t.Fatal(err)
+	}
+	expected := `kms-key
+├── type: plugin
+├── key spec: EC-384
+├── key id: key-id
+├── plugin name: kms
+└── plugin config
+    └── region: us-west-2
+`
+	if buf.String() != expected {
+		t.Fatalf("expected:\n%s\ngot:\n%s", expected, buf.String())
+	}
+}
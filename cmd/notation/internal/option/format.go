@@ -48,6 +48,8 @@ var (
 	FormatTypeText FormatType = "text"
 	// FormatTypeTree is the tree format type for human-readable output.
 	FormatTypeTree FormatType = "tree"
+	// FormatTypePEM is the PEM format type for certificates.
+	FormatTypePEM FormatType = "pem"
 )
 
 // Format contains input and parsed options for formatted output flags.
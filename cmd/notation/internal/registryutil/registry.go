@@ -0,0 +1,107 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package registryutil provides clients for registry operations of commands.
+package registryutil
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/notaryproject/notation/cmd/notation/internal/option"
+	notationauth "github.com/notaryproject/notation/internal/auth"
+	"github.com/notaryproject/notation/internal/httputil"
+	"github.com/notaryproject/notation/pkg/configutil"
+	"oras.land/oras-go/v2/registry"
+	"oras.land/oras-go/v2/registry/remote"
+	"oras.land/oras-go/v2/registry/remote/auth"
+	"oras.land/oras-go/v2/registry/remote/credentials"
+)
+
+// NewRepositoryClient returns a *remote.Repository of ref with the
+// credential from opts or the saved credentials.
+func NewRepositoryClient(ctx context.Context, opts *option.Secure, ref registry.Reference) (*remote.Repository, error) {
+	authClient, insecureRegistry, err := getAuthClient(ctx, opts, ref, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return &remote.Repository{
+		Client:    authClient,
+		Reference: ref,
+		PlainHTTP: insecureRegistry,
+	}, nil
+}
+
+// NewRegistryLoginClient returns a *remote.Registry of serverAddress without
+// credential.
+func NewRegistryLoginClient(ctx context.Context, opts *option.Secure, serverAddress string) (*remote.Registry, error) {
+	reg, err := remote.NewRegistry(serverAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	reg.Client, reg.PlainHTTP, err = getAuthClient(ctx, opts, reg.Reference, false)
+	if err != nil {
+		return nil, err
+	}
+	return reg, nil
+}
+
+// getAuthClient returns an *auth.Client and a bool indicating if the registry
+// is insecure.
+//
+// If withCredential is true, the returned *auth.Client will have its Credential
+// function configured.
+//
+// If withCredential is false, the returned *auth.Client will have a nil
+// Credential function.
+func getAuthClient(ctx context.Context, opts *option.Secure, ref registry.Reference, withCredential bool) (*auth.Client, bool, error) {
+	var insecureRegistry bool
+	if opts.InsecureRegistry {
+		insecureRegistry = opts.InsecureRegistry
+	} else {
+		insecureRegistry = configutil.IsRegistryInsecure(ref.Registry)
+		if !insecureRegistry {
+			if host, _, _ := net.SplitHostPort(ref.Registry); host == "localhost" {
+				insecureRegistry = true
+			}
+		}
+	}
+
+	// build authClient
+	transport, err := httputil.NewTransportForEndpoint(configutil.EndpointRegistry)
+	if err != nil {
+		return nil, false, err
+	}
+	authClient := httputil.NewAuthClient(ctx, &http.Client{Transport: transport})
+	if !withCredential {
+		return authClient, insecureRegistry, nil
+	}
+
+	cred := opts.Credential()
+	if cred != auth.EmptyCredential {
+		// use the specified credential
+		authClient.Credential = auth.StaticCredential(ref.Host(), cred)
+	} else {
+		// use saved credentials
+		credsStore, err := notationauth.NewCredentialsStore()
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to get credentials store: %w", err)
+		}
+		authClient.Credential = credentials.Credential(credsStore)
+	}
+	return authClient, insecureRegistry, nil
+}
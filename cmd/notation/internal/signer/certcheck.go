@@ -0,0 +1,173 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signer
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/notaryproject/notation-core-go/revocation"
+	"github.com/notaryproject/notation-core-go/revocation/result"
+	"github.com/notaryproject/notation-core-go/signature"
+	"github.com/notaryproject/notation-go"
+	"github.com/notaryproject/notation-go/log"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// CertificateCheckOptions contains parameters for checking the signing
+// certificate chain before the signature is returned by a Signer.
+type CertificateCheckOptions struct {
+	// ExpiryWindow is the duration before the expiry of the signing
+	// certificate within which the signing certificate is reported as
+	// expiring. Zero disables the check.
+	ExpiryWindow time.Duration
+
+	// RevocationValidator validates the revocation status of the signing
+	// certificate chain. Revocation is not checked if it is nil.
+	RevocationValidator revocation.Validator
+
+	// Strict fails the signing if the signing certificate is expired, expiring
+	// or revoked. Otherwise, warnings are printed out.
+	Strict bool
+}
+
+// certCheckSigner is a Signer that checks the signing certificate chain
+// returned by the underlying Signer before returning the signature.
+type certCheckSigner struct {
+	Signer
+	opts CertificateCheckOptions
+}
+
+// WithCertificateCheck checks whether the signing certificate of s is
+// expired, expiring within the expiry window or revoked.
+//
+// If certChain, the signing certificate chain of s, is known before signing,
+// as for local keys, it is checked immediately, so that no signature is
+// generated and no timestamp is requested with a problematic certificate, and
+// s is returned as is. Otherwise, as for keys managed by plugins which return
+// the certificate chain only at signing time, a Signer is returned that checks
+// the certificate chain returned by s after the signature is generated and
+// before the signature is returned.
+func WithCertificateCheck(ctx context.Context, s Signer, certChain []*x509.Certificate, opts CertificateCheckOptions) (Signer, error) {
+	if len(certChain) > 0 {
+		if err := checkCertificateChain(ctx, certChain, opts); err != nil {
+			return nil, err
+		}
+		return s, nil
+	}
+	return &certCheckSigner{
+		Signer: s,
+		opts:   opts,
+	}, nil
+}
+
+// Sign signs the OCI artifact described by its descriptor and checks the
+// signing certificate chain.
+func (s *certCheckSigner) Sign(ctx context.Context, desc ocispec.Descriptor, opts notation.SignerSignOptions) ([]byte, *signature.SignerInfo, error) {
+	sig, signerInfo, err := s.Signer.Sign(ctx, desc, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := checkCertificateChain(ctx, signerInfo.CertificateChain, s.opts); err != nil {
+		return nil, nil, err
+	}
+	return sig, signerInfo, nil
+}
+
+// SignBlob signs the blob and checks the signing certificate chain.
+func (s *certCheckSigner) SignBlob(ctx context.Context, descGenFunc notation.BlobDescriptorGenerator, opts notation.SignerSignOptions) ([]byte, *signature.SignerInfo, error) {
+	sig, signerInfo, err := s.Signer.SignBlob(ctx, descGenFunc, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := checkCertificateChain(ctx, signerInfo.CertificateChain, s.opts); err != nil {
+		return nil, nil, err
+	}
+	return sig, signerInfo, nil
+}
+
+// PluginAnnotations returns the signature manifest annotations returned from
+// the plugin, if the underlying Signer is a plugin signer.
+func (s *certCheckSigner) PluginAnnotations() map[string]string {
+	if signerAnts, ok := s.Signer.(interface{ PluginAnnotations() map[string]string }); ok {
+		return signerAnts.PluginAnnotations()
+	}
+	return nil
+}
+
+// checkCertificateChain checks the signing certificate chain, failing on
+// problems in strict mode and printing out warnings otherwise.
+func checkCertificateChain(ctx context.Context, certChain []*x509.Certificate, opts CertificateCheckOptions) error {
+	problems := CheckSigningCertificate(ctx, certChain, time.Now(), opts)
+	if len(problems) == 0 {
+		return nil
+	}
+	if opts.Strict {
+		return fmt.Errorf("signing certificate check failed: %w", errors.Join(problems...))
+	}
+	for _, problem := range problems {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", problem)
+	}
+	return nil
+}
+
+// CheckSigningCertificate checks whether the signing certificate, i.e. the
+// first certificate of certChain, is expired or expiring at time now, and
+// whether any certificate in certChain is revoked. The problems found are
+// returned.
+//
+// Failures of the revocation check itself are logged but not returned, as
+// they do not indicate that the signing certificate is revoked.
+func CheckSigningCertificate(ctx context.Context, certChain []*x509.Certificate, now time.Time, opts CertificateCheckOptions) []error {
+	logger := log.GetLogger(ctx)
+	if len(certChain) == 0 {
+		return []error{errors.New("signing certificate chain is empty")}
+	}
+
+	var problems []error
+	leaf := certChain[0]
+	switch {
+	case now.Before(leaf.NotBefore):
+		problems = append(problems, fmt.Errorf("signing certificate with subject %q is not valid until %s", leaf.Subject, leaf.NotBefore.UTC()))
+	case now.After(leaf.NotAfter):
+		problems = append(problems, fmt.Errorf("signing certificate with subject %q expired on %s", leaf.Subject, leaf.NotAfter.UTC()))
+	case opts.ExpiryWindow > 0 && now.Add(opts.ExpiryWindow).After(leaf.NotAfter):
+		problems = append(problems, fmt.Errorf("signing certificate with subject %q expires in %d days on %s", leaf.Subject, int(leaf.NotAfter.Sub(now).Hours()/24), leaf.NotAfter.UTC()))
+	}
+
+	if opts.RevocationValidator == nil {
+		return problems
+	}
+	logger.Debug("Checking revocation status of the signing certificate chain")
+	certResults, err := opts.RevocationValidator.ValidateContext(ctx, revocation.ValidateContextOptions{
+		CertChain: certChain,
+	})
+	if err != nil {
+		logger.Warnf("Failed to check revocation status of the signing certificate chain: %v", err)
+		return problems
+	}
+	for i, certResult := range certResults {
+		switch certResult.Result {
+		case result.ResultRevoked:
+			problems = append(problems, fmt.Errorf("certificate with subject %q in the signing certificate chain is revoked", certChain[i].Subject))
+		case result.ResultUnknown:
+			logger.Warnf("Revocation status of certificate with subject %q in the signing certificate chain is unknown", certChain[i].Subject)
+		}
+	}
+	return problems
+}
@@ -0,0 +1,203 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signer
+
+import (
+	"context"
+	"crypto/x509"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/notaryproject/notation-core-go/revocation"
+	"github.com/notaryproject/notation-core-go/revocation/result"
+	"github.com/notaryproject/notation-core-go/signature"
+	"github.com/notaryproject/notation-core-go/testhelper"
+	"github.com/notaryproject/notation-go"
+	"github.com/notaryproject/notation-go/signer"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+type mockRevocationValidator struct {
+	results []*result.CertRevocationResult
+}
+
+func (v *mockRevocationValidator) ValidateContext(ctx context.Context, opts revocation.ValidateContextOptions) ([]*result.CertRevocationResult, error) {
+	return v.results, nil
+}
+
+func (v *mockRevocationValidator) Validate(certChain []*x509.Certificate, signingTime time.Time) ([]*result.CertRevocationResult, error) {
+	return v.results, nil
+}
+
+func TestCheckSigningCertificate(t *testing.T) {
+	// only the validity period of the signing certificate is checked, so the
+	// certificate is not required to be re-signed
+	leaf := *testhelper.GetRSALeafCertificate().Cert
+	leaf.NotBefore = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	leaf.NotAfter = leaf.NotBefore.AddDate(1, 0, 0)
+	certChain := []*x509.Certificate{&leaf, testhelper.GetRSARootCertificate().Cert}
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		opts     CertificateCheckOptions
+		expected string
+	}{
+		{
+			name: "valid",
+			now:  leaf.NotBefore.Add(time.Hour),
+			opts: CertificateCheckOptions{
+				ExpiryWindow: 24 * time.Hour,
+			},
+		},
+		{
+			name:     "not yet valid",
+			now:      leaf.NotBefore.Add(-time.Hour),
+			expected: "is not valid until",
+		},
+		{
+			name:     "expired",
+			now:      leaf.NotAfter.Add(time.Hour),
+			expected: "expired on",
+		},
+		{
+			name: "expiring",
+			now:  leaf.NotAfter.Add(-48 * time.Hour),
+			opts: CertificateCheckOptions{
+				ExpiryWindow: 7 * 24 * time.Hour,
+			},
+			expected: "expires in 2 days",
+		},
+		{
+			name: "expiry window disabled",
+			now:  leaf.NotAfter.Add(-48 * time.Hour),
+		},
+		{
+			name: "revoked",
+			now:  leaf.NotBefore.Add(time.Hour),
+			opts: CertificateCheckOptions{
+				RevocationValidator: &mockRevocationValidator{
+					results: []*result.CertRevocationResult{
+						{Result: result.ResultRevoked},
+						{Result: result.ResultNonRevokable},
+					},
+				},
+			},
+			expected: "is revoked",
+		},
+		{
+			name: "revocation unknown",
+			now:  leaf.NotBefore.Add(time.Hour),
+			opts: CertificateCheckOptions{
+				RevocationValidator: &mockRevocationValidator{
+					results: []*result.CertRevocationResult{
+						{Result: result.ResultUnknown},
+						{Result: result.ResultNonRevokable},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problems := CheckSigningCertificate(ctx, certChain, tt.now, tt.opts)
+			if tt.expected == "" {
+				if len(problems) != 0 {
+					t.Fatalf("expected no problem, got %v", problems)
+				}
+				return
+			}
+			if len(problems) != 1 || !strings.Contains(problems[0].Error(), tt.expected) {
+				t.Fatalf("expected a problem containing %q, got %v", tt.expected, problems)
+			}
+		})
+	}
+}
+
+func TestWithCertificateCheck(t *testing.T) {
+	certTuple := testhelper.GetRSALeafCertificate()
+	certChain := []*x509.Certificate{certTuple.Cert, testhelper.GetRSARootCertificate().Cert}
+	s, err := signer.NewGenericSigner(certTuple.PrivateKey, certChain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	revoked := &mockRevocationValidator{
+		results: []*result.CertRevocationResult{
+			{Result: result.ResultRevoked},
+			{Result: result.ResultNonRevokable},
+		},
+	}
+	desc := ocispec.Descriptor{
+		MediaType: "application/octet-stream",
+		Digest:    "sha256:c0d488a800e4127c334ad20d61d7bc21b4097540327217dfab52262adc02380c",
+		Size:      4,
+	}
+	signOpts := notation.SignerSignOptions{
+		SignatureMediaType: "application/jose+json",
+	}
+
+	t.Run("warning", func(t *testing.T) {
+		checked, err := WithCertificateCheck(context.Background(), s, certChain, CertificateCheckOptions{
+			RevocationValidator: revoked,
+		})
+		if err != nil {
+			t.Fatalf("expected a warning only, got %v", err)
+		}
+		if _, _, err := checked.Sign(context.Background(), desc, signOpts); err != nil {
+			t.Fatalf("expected signing to succeed with a warning, got %v", err)
+		}
+	})
+
+	t.Run("strict before signing", func(t *testing.T) {
+		counting := &countingSigner{Signer: s}
+		_, err := WithCertificateCheck(context.Background(), counting, certChain, CertificateCheckOptions{
+			RevocationValidator: revoked,
+			Strict:              true,
+		})
+		if err == nil || !strings.Contains(err.Error(), "signing certificate check failed") {
+			t.Fatalf("expected signing certificate check to fail, got %v", err)
+		}
+		if counting.calls != 0 {
+			t.Fatalf("expected no signature to be generated, got %d calls", counting.calls)
+		}
+	})
+
+	t.Run("strict after signing", func(t *testing.T) {
+		// the certificate chain is unknown before signing, e.g. for plugins
+		checked, err := WithCertificateCheck(context.Background(), s, nil, CertificateCheckOptions{
+			RevocationValidator: revoked,
+			Strict:              true,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _, err = checked.Sign(context.Background(), desc, signOpts)
+		if err == nil || !strings.Contains(err.Error(), "signing certificate check failed") {
+			t.Fatalf("expected signing certificate check to fail, got %v", err)
+		}
+	})
+}
+
+// countingSigner counts the calls of Sign.
+type countingSigner struct {
+	Signer
+	calls int
+}
+
+func (s *countingSigner) Sign(ctx context.Context, desc ocispec.Descriptor, opts notation.SignerSignOptions) ([]byte, *signature.SignerInfo, error) {
+	s.calls++
+	return s.Signer.Sign(ctx, desc, opts)
+}
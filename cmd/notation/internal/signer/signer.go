@@ -15,9 +15,12 @@ package signer
 
 import (
 	"context"
+	"crypto/x509"
 	"errors"
 
+	corex509 "github.com/notaryproject/notation-core-go/x509"
 	"github.com/notaryproject/notation-go"
+	"github.com/notaryproject/notation-go/config"
 	"github.com/notaryproject/notation-go/dir"
 	"github.com/notaryproject/notation-go/plugin"
 	"github.com/notaryproject/notation-go/signer"
@@ -50,6 +53,30 @@ func GetSigner(ctx context.Context, opts *cmd.SignerFlagOpts) (Signer, error) {
 	if err != nil {
 		return nil, err
 	}
+	return GetSignerFromKey(ctx, key)
+}
+
+// GetCertificateChain returns the signing certificate chain of the local key
+// selected by opts, so that it can be checked before signing. nil is returned
+// for keys managed by plugins, as plugins return the certificate chain only at
+// signing time.
+func GetCertificateChain(opts *cmd.SignerFlagOpts) ([]*x509.Certificate, error) {
+	if opts.KeyID != "" && opts.PluginName != "" && opts.Key == "" {
+		// on-demand key of a plugin
+		return nil, nil
+	}
+	key, err := configutil.ResolveKey(opts.Key)
+	if err != nil {
+		return nil, err
+	}
+	if key.X509KeyPair == nil {
+		return nil, nil
+	}
+	return corex509.ReadCertificateFile(key.X509KeyPair.CertificatePath)
+}
+
+// GetSignerFromKey returns a Signer based on a key in the signing key list.
+func GetSignerFromKey(ctx context.Context, key config.KeySuite) (Signer, error) {
 	if key.X509KeyPair != nil {
 		return signer.NewGenericSignerFromFiles(key.X509KeyPair.KeyPath, key.X509KeyPair.CertificatePath)
 	}
@@ -15,9 +15,14 @@ package signer
 
 import (
 	"context"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
 	"runtime"
 	"testing"
 
+	"github.com/notaryproject/notation-core-go/testhelper"
 	"github.com/notaryproject/notation-go"
 	"github.com/notaryproject/notation-go/dir"
 	"github.com/notaryproject/notation-go/signer"
@@ -216,3 +221,48 @@ func TestGetSignerFailed(t *testing.T) {
 		}
 	})
 }
+
+func TestGetCertificateChain(t *testing.T) {
+	defer func(oldConfigDir string) {
+		dir.UserConfigDir = oldConfigDir
+	}(dir.UserConfigDir)
+
+	t.Run("on-demand plugin key", func(t *testing.T) {
+		certChain, err := GetCertificateChain(&cmd.SignerFlagOpts{
+			KeyID:      "testKeyId",
+			PluginName: "testPlugin",
+		})
+		if err != nil || certChain != nil {
+			t.Fatalf("expected no certificate chain, but got %v, %v", certChain, err)
+		}
+	})
+
+	t.Run("plugin key in config", func(t *testing.T) {
+		dir.UserConfigDir = "./testdata/valid_signingkeys"
+		certChain, err := GetCertificateChain(&cmd.SignerFlagOpts{Key: "test"})
+		if err != nil || certChain != nil {
+			t.Fatalf("expected no certificate chain, but got %v, %v", certChain, err)
+		}
+	})
+
+	t.Run("local key", func(t *testing.T) {
+		tempDir := t.TempDir()
+		dir.UserConfigDir = tempDir
+		cert := testhelper.GetRSALeafCertificate().Cert
+		certPath := filepath.Join(tempDir, "test.crt")
+		if err := os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}), 0600); err != nil {
+			t.Fatal(err)
+		}
+		signingKeys := fmt.Sprintf(`{"default":"test","keys":[{"name":"test","keyPath":%q,"certPath":%q}]}`, filepath.Join(tempDir, "test.key"), certPath)
+		if err := os.WriteFile(filepath.Join(tempDir, "signingkeys.json"), []byte(signingKeys), 0600); err != nil {
+			t.Fatal(err)
+		}
+		certChain, err := GetCertificateChain(&cmd.SignerFlagOpts{Key: "test"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(certChain) != 1 || !certChain[0].Equal(cert) {
+			t.Fatalf("expected the signing certificate, but got %v", certChain)
+		}
+	})
+}
@@ -0,0 +1,120 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package timestamp
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+
+	"github.com/notaryproject/notation-core-go/revocation"
+	"github.com/notaryproject/notation-core-go/revocation/result"
+	"github.com/notaryproject/notation-core-go/signature"
+	corex509 "github.com/notaryproject/notation-core-go/x509"
+	"github.com/notaryproject/tspclient-go"
+)
+
+// CountersignOptions contains parameters for Countersign.
+type CountersignOptions struct {
+	// Timestamper requests the timestamp from the TSA servers.
+	Timestamper tspclient.Timestamper
+
+	// RootCAs is the set of trusted root certificates of the TSA servers.
+	RootCAs *x509.CertPool
+
+	// RevocationValidator validates the revocation status of the TSA
+	// certificate chain. Revocation is not checked if it is nil.
+	RevocationValidator revocation.Validator
+}
+
+// Countersign obtains an RFC 3161 timestamp countersignature over the
+// signature value of signerInfo, in the same way as timestamping on signing.
+// The TSA certificate chain is verified against opts.RootCAs and validated
+// against the Notary Project requirements of timestamping certificates. On
+// success, the full bytes of the timestamp token are returned.
+//
+// Reference: https://github.com/notaryproject/specifications/blob/v1.0.0/specs/signature-specification.md#timestamp-countersignature
+func Countersign(ctx context.Context, signerInfo *signature.SignerInfo, opts CountersignOptions) ([]byte, error) {
+	if opts.Timestamper == nil {
+		return nil, errors.New("timestamper cannot be nil")
+	}
+	if len(signerInfo.Signature) == 0 {
+		return nil, errors.New("signature value cannot be empty")
+	}
+	hash := signerInfo.SignatureAlgorithm.Hash()
+	if hash == 0 {
+		return nil, fmt.Errorf("unsupported signature algorithm %v", signerInfo.SignatureAlgorithm)
+	}
+	req, err := tspclient.NewRequest(tspclient.RequestOptions{
+		Content:       signerInfo.Signature,
+		HashAlgorithm: hash,
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp, err := opts.Timestamper.Timestamp(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	token, err := resp.SignedToken()
+	if err != nil {
+		return nil, err
+	}
+	tsaCertChain, err := token.Verify(ctx, x509.VerifyOptions{
+		Roots: opts.RootCAs,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := corex509.ValidateTimestampingCertChain(tsaCertChain); err != nil {
+		return nil, err
+	}
+	if opts.RevocationValidator != nil {
+		certResults, err := opts.RevocationValidator.ValidateContext(ctx, revocation.ValidateContextOptions{
+			CertChain: tsaCertChain,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to validate the revocation status of timestamping certificate chain with error: %w", err)
+		}
+		if err := checkRevocationResults(certResults, tsaCertChain); err != nil {
+			return nil, err
+		}
+	}
+	return resp.TimestampToken.FullBytes, nil
+}
+
+// checkRevocationResults returns an error if any certificate in certChain is
+// revoked or its revocation status is unknown.
+func checkRevocationResults(certResults []*result.CertRevocationResult, certChain []*x509.Certificate) error {
+	if len(certResults) != len(certChain) {
+		return fmt.Errorf("length of certificate revocation result %d does not match length of the certificate chain %d", len(certResults), len(certChain))
+	}
+	var unknownSubject string
+	for i := len(certResults) - 1; i >= 0; i-- {
+		switch certResults[i].Result {
+		case result.ResultOK, result.ResultNonRevokable:
+		case result.ResultRevoked:
+			return fmt.Errorf("timestamping certificate with subject %q is revoked", certChain[i].Subject.String())
+		default:
+			if unknownSubject == "" {
+				unknownSubject = certChain[i].Subject.String()
+			}
+		}
+	}
+	if unknownSubject != "" {
+		return fmt.Errorf("timestamping certificate with subject %q revocation status is unknown", unknownSubject)
+	}
+	return nil
+}
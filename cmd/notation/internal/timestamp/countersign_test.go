@@ -0,0 +1,141 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package timestamp
+
+import (
+	"context"
+	"crypto/x509"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/notaryproject/notation-core-go/revocation"
+	"github.com/notaryproject/notation-core-go/revocation/result"
+	"github.com/notaryproject/notation-core-go/signature"
+	"github.com/notaryproject/notation/internal/tsa"
+	"github.com/notaryproject/notation/internal/tsa/tsatest"
+	"github.com/notaryproject/tspclient-go"
+)
+
+type mockRevocationValidator struct {
+	result result.Result
+}
+
+func (v *mockRevocationValidator) Validate(certChain []*x509.Certificate, signingTime time.Time) ([]*result.CertRevocationResult, error) {
+	return v.ValidateContext(context.Background(), revocation.ValidateContextOptions{CertChain: certChain})
+}
+
+func (v *mockRevocationValidator) ValidateContext(_ context.Context, opts revocation.ValidateContextOptions) ([]*result.CertRevocationResult, error) {
+	results := make([]*result.CertRevocationResult, len(opts.CertChain))
+	for i := range results {
+		results[i] = &result.CertRevocationResult{Result: result.ResultOK}
+	}
+	results[0].Result = v.result
+	return results, nil
+}
+
+func TestCountersign(t *testing.T) {
+	tsaKey, tsaCertChain := tsatest.GenerateCertChain(t)
+	server, err := tsa.New(tsaKey, tsaCertChain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+	timestamper, err := tspclient.NewHTTPTimestamper(nil, ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(tsaCertChain[1])
+	signerInfo := &signature.SignerInfo{
+		SignatureAlgorithm: signature.AlgorithmPS256,
+		Signature:          []byte("signature"),
+	}
+
+	t.Run("success", func(t *testing.T) {
+		token, err := Countersign(context.Background(), signerInfo, CountersignOptions{
+			Timestamper:         timestamper,
+			RootCAs:             rootCAs,
+			RevocationValidator: &mockRevocationValidator{result: result.ResultOK},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		signedToken, err := tspclient.ParseSignedToken(token)
+		if err != nil {
+			t.Fatal(err)
+		}
+		info, err := signedToken.Info()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := info.Validate(signerInfo.Signature); err != nil {
+			t.Fatalf("expected the timestamp to cover the signature value, but got %v", err)
+		}
+	})
+
+	t.Run("untrusted tsa", func(t *testing.T) {
+		_, err := Countersign(context.Background(), signerInfo, CountersignOptions{
+			Timestamper: timestamper,
+			RootCAs:     x509.NewCertPool(),
+		})
+		if err == nil {
+			t.Fatal("expected error for untrusted tsa")
+		}
+	})
+
+	t.Run("revoked tsa", func(t *testing.T) {
+		_, err := Countersign(context.Background(), signerInfo, CountersignOptions{
+			Timestamper:         timestamper,
+			RootCAs:             rootCAs,
+			RevocationValidator: &mockRevocationValidator{result: result.ResultRevoked},
+		})
+		if err == nil || !strings.Contains(err.Error(), "is revoked") {
+			t.Fatalf("expected revoked error, but got %v", err)
+		}
+	})
+
+	t.Run("unknown revocation status", func(t *testing.T) {
+		_, err := Countersign(context.Background(), signerInfo, CountersignOptions{
+			Timestamper:         timestamper,
+			RootCAs:             rootCAs,
+			RevocationValidator: &mockRevocationValidator{result: result.ResultUnknown},
+		})
+		if err == nil || !strings.Contains(err.Error(), "revocation status is unknown") {
+			t.Fatalf("expected unknown revocation status error, but got %v", err)
+		}
+	})
+
+	t.Run("unsupported signature algorithm", func(t *testing.T) {
+		_, err := Countersign(context.Background(), &signature.SignerInfo{Signature: []byte("signature")}, CountersignOptions{
+			Timestamper: timestamper,
+			RootCAs:     rootCAs,
+		})
+		if err == nil {
+			t.Fatal("expected error for unsupported signature algorithm")
+		}
+	})
+
+	t.Run("empty signature", func(t *testing.T) {
+		_, err := Countersign(context.Background(), &signature.SignerInfo{SignatureAlgorithm: signature.AlgorithmPS256}, CountersignOptions{
+			Timestamper: timestamper,
+			RootCAs:     rootCAs,
+		})
+		if err == nil {
+			t.Fatal("expected error for empty signature")
+		}
+	})
+}
@@ -0,0 +1,208 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package timestamp provides timestamping against an ordered list of RFC 3161
+// Timestamping Authority (TSA) servers for signing commands.
+package timestamp
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/notaryproject/notation-go/dir"
+	"github.com/notaryproject/notation-go/log"
+	notationgoTruststore "github.com/notaryproject/notation-go/verifier/truststore"
+	"github.com/notaryproject/notation/cmd/notation/internal/truststore"
+	"github.com/notaryproject/notation/internal/httputil"
+	nx509 "github.com/notaryproject/notation/internal/x509"
+	"github.com/notaryproject/notation/pkg/configutil"
+	"github.com/notaryproject/tspclient-go"
+	"github.com/spf13/pflag"
+)
+
+// ValidateFlags validates the timestamping flags "--timestamp-url",
+// "--timestamp-root-cert" and "--timestamp-trust-store" set in flags with
+// values url, rootCert and trustStore. The TSA url must be specified along
+// with either the root certificate or the trust store. None of them can be
+// specified if timestamping is disabled by "--timestamp=false".
+func ValidateFlags(flags *pflag.FlagSet, url, rootCert, trustStore string) error {
+	rootCertSet := flags.Changed("timestamp-root-cert")
+	trustStoreSet := flags.Changed("timestamp-trust-store")
+	if enabled, err := flags.GetBool("timestamp"); err == nil && !enabled {
+		if flags.Changed("timestamp-url") || rootCertSet || trustStoreSet {
+			return errors.New("timestamping: --timestamp=false cannot be used with --timestamp-url, --timestamp-root-cert or --timestamp-trust-store")
+		}
+		return nil
+	}
+	if !flags.Changed("timestamp-url") {
+		if rootCertSet || trustStoreSet {
+			return errors.New("timestamping: --timestamp-url is required when --timestamp-root-cert or --timestamp-trust-store is specified")
+		}
+		return nil
+	}
+	if url == "" {
+		return errors.New("timestamping: tsa url cannot be empty")
+	}
+	switch {
+	case rootCertSet:
+		if rootCert == "" {
+			return errors.New("timestamping: tsa root certificate path cannot be empty")
+		}
+	case trustStoreSet:
+		if trustStore == "" {
+			return errors.New("timestamping: tsa trust store name cannot be empty")
+		}
+	default:
+		return errors.New("timestamping: either --timestamp-root-cert or --timestamp-trust-store is required when --timestamp-url is specified")
+	}
+	return nil
+}
+
+// Servers returns the TSA servers to timestamp with. If enabled is false,
+// no server is returned. If url is not empty, the server specified by url and
+// either rootCert or trustStore is returned. Otherwise, the servers
+// configured in config.json are returned. An empty result means that
+// timestamping is not requested.
+func Servers(enabled bool, url, rootCert, trustStore string) ([]configutil.TimestampServer, error) {
+	if !enabled {
+		return nil, nil
+	}
+	if url != "" {
+		return []configutil.TimestampServer{{URL: url, RootCert: rootCert, TrustStore: trustStore}}, nil
+	}
+	settings, err := configutil.LoadSettingsOnce()
+	if err != nil {
+		return nil, err
+	}
+	return settings.Timestamp.Servers, nil
+}
+
+// New returns a timestamper trying servers in order with a timeout of each
+// request, and the root certificate pool containing the root certificates of
+// all servers, read from either the root certificate files or the trust
+// stores of type tsa.
+//
+// The timestamper verifies the timestamp of each server against the root
+// certificates of that server, so that a server cannot be trusted with the
+// root certificates of another. The returned pool of all root certificates
+// is required along with the timestamper by the signers of notation-go.
+func New(ctx context.Context, servers []configutil.TimestampServer, timeout time.Duration) (tspclient.Timestamper, *x509.CertPool, error) {
+	if len(servers) == 0 {
+		return nil, nil, errors.New("timestamping: no tsa server is specified")
+	}
+	httpClient, err := httputil.NewClientForEndpoint(ctx, configutil.EndpointTimestamp, timeout)
+	if err != nil {
+		return nil, nil, err
+	}
+	timestamper := &FailoverTimestamper{}
+	allRootCAs := x509.NewCertPool()
+	x509TrustStore := truststore.NewX509TrustStore(dir.ConfigFS())
+	for _, server := range servers {
+		t, err := tspclient.NewHTTPTimestamper(httpClient, server.URL)
+		if err != nil {
+			return nil, nil, fmt.Errorf("cannot get http timestamper for timestamping: %w", err)
+		}
+		var rootCerts []*x509.Certificate
+		if server.TrustStore != "" {
+			rootCerts, err = x509TrustStore.GetCertificates(ctx, notationgoTruststore.TypeTSA, server.TrustStore)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get tsa root certificates from trust store %q: %w", server.TrustStore, err)
+			}
+		} else {
+			rootCerts, err = nx509.ReadRootCertificate(server.RootCert)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+		rootCAs := x509.NewCertPool()
+		for _, rootCert := range rootCerts {
+			rootCAs.AddCert(rootCert)
+			allRootCAs.AddCert(rootCert)
+		}
+		timestamper.URLs = append(timestamper.URLs, server.URL)
+		timestamper.Timestampers = append(timestamper.Timestampers, t)
+		timestamper.RootCAs = append(timestamper.RootCAs, rootCAs)
+	}
+	return timestamper, allRootCAs, nil
+}
+
+// FailoverTimestamper is a tspclient.Timestamper that requests the
+// timestamp from the underlying timestampers in order, until one of them
+// succeeds.
+type FailoverTimestamper struct {
+	// Timestampers are the underlying timestampers tried in order.
+	Timestampers []tspclient.Timestamper
+
+	// URLs are the URLs of the TSA servers of Timestampers for logging.
+	URLs []string
+
+	// RootCAs are the root certificates of the TSA servers of Timestampers.
+	// If set for a timestamper, its timestamp is verified against its root
+	// certificates, and the next timestamper is tried if the verification
+	// fails.
+	RootCAs []*x509.CertPool
+}
+
+// Timestamp sends the timestamping request to the underlying timestampers in
+// order and returns the first successful response. If all of them fail, the
+// errors of all timestampers are returned.
+func (t *FailoverTimestamper) Timestamp(ctx context.Context, req *tspclient.Request) (*tspclient.Response, error) {
+	logger := log.GetLogger(ctx)
+	var errs []error
+	for i, timestamper := range t.Timestampers {
+		url := t.url(i)
+		logger.Infof("Timestamping with TSA %q", url)
+		resp, err := timestamper.Timestamp(ctx, req)
+		if err == nil {
+			if err = t.verify(ctx, i, resp); err == nil {
+				return resp, nil
+			}
+		}
+		if ctx.Err() != nil {
+			return nil, err
+		}
+		logger.Warnf("Failed to timestamp with TSA %q: %v", url, err)
+		errs = append(errs, fmt.Errorf("tsa %q: %w", url, err))
+	}
+	if len(errs) == 0 {
+		return nil, errors.New("no tsa server is available")
+	}
+	return nil, fmt.Errorf("all tsa servers failed: %w", errors.Join(errs...))
+}
+
+// verify verifies the timestamp in resp of the i-th timestamper against the
+// root certificates of the i-th timestamper, if set.
+func (t *FailoverTimestamper) verify(ctx context.Context, i int, resp *tspclient.Response) error {
+	if i >= len(t.RootCAs) || t.RootCAs[i] == nil {
+		return nil
+	}
+	token, err := resp.SignedToken()
+	if err != nil {
+		return err
+	}
+	if _, err := token.Verify(ctx, x509.VerifyOptions{Roots: t.RootCAs[i]}); err != nil {
+		return fmt.Errorf("failed to verify the timestamp against the root certificates of the tsa: %w", err)
+	}
+	return nil
+}
+
+// url returns the URL of the i-th timestamper.
+func (t *FailoverTimestamper) url(i int) string {
+	if i < len(t.URLs) {
+		return t.URLs[i]
+	}
+	return fmt.Sprintf("#%d", i+1)
+}
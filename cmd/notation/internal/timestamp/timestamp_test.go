@@ -0,0 +1,360 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package timestamp
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"errors"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/notaryproject/notation-go/dir"
+	"github.com/notaryproject/notation/internal/tsa"
+	"github.com/notaryproject/notation/internal/tsa/tsatest"
+	"github.com/notaryproject/notation/pkg/configutil"
+	"github.com/notaryproject/tspclient-go"
+	"github.com/spf13/pflag"
+)
+
+type mockTimestamper struct {
+	resp  *tspclient.Response
+	err   error
+	calls int
+}
+
+func (m *mockTimestamper) Timestamp(context.Context, *tspclient.Request) (*tspclient.Response, error) {
+	m.calls++
+	return m.resp, m.err
+}
+
+func TestFailoverTimestamper(t *testing.T) {
+	resp := &tspclient.Response{}
+
+	t.Run("first succeeds", func(t *testing.T) {
+		first := &mockTimestamper{resp: resp}
+		second := &mockTimestamper{resp: resp}
+		timestamper := &FailoverTimestamper{
+			Timestampers: []tspclient.Timestamper{first, second},
+			URLs:         []string{"http://tsa1.example", "http://tsa2.example"},
+		}
+		got, err := timestamper.Timestamp(context.Background(), &tspclient.Request{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != resp {
+			t.Fatal("expected the response of the first timestamper")
+		}
+		if first.calls != 1 || second.calls != 0 {
+			t.Fatalf("expected only the first timestamper to be called, but got %d and %d calls", first.calls, second.calls)
+		}
+	})
+
+	t.Run("failover", func(t *testing.T) {
+		first := &mockTimestamper{err: errors.New("unavailable")}
+		second := &mockTimestamper{resp: resp}
+		timestamper := &FailoverTimestamper{
+			Timestampers: []tspclient.Timestamper{first, second},
+			URLs:         []string{"http://tsa1.example", "http://tsa2.example"},
+		}
+		got, err := timestamper.Timestamp(context.Background(), &tspclient.Request{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != resp {
+			t.Fatal("expected the response of the second timestamper")
+		}
+		if first.calls != 1 || second.calls != 1 {
+			t.Fatalf("expected both timestampers to be called once, but got %d and %d calls", first.calls, second.calls)
+		}
+	})
+
+	t.Run("all fail", func(t *testing.T) {
+		errFirst := errors.New("unavailable")
+		errSecond := errors.New("bad response")
+		timestamper := &FailoverTimestamper{
+			Timestampers: []tspclient.Timestamper{
+				&mockTimestamper{err: errFirst},
+				&mockTimestamper{err: errSecond},
+			},
+			URLs: []string{"http://tsa1.example", "http://tsa2.example"},
+		}
+		_, err := timestamper.Timestamp(context.Background(), &tspclient.Request{})
+		if !errors.Is(err, errFirst) || !errors.Is(err, errSecond) {
+			t.Fatalf("expected errors of all timestampers, but got %v", err)
+		}
+		if !strings.Contains(err.Error(), "http://tsa2.example") {
+			t.Fatalf("expected error to contain the tsa url, but got %v", err)
+		}
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		second := &mockTimestamper{resp: resp}
+		timestamper := &FailoverTimestamper{
+			Timestampers: []tspclient.Timestamper{
+				&mockTimestamper{err: context.Canceled},
+				second,
+			},
+		}
+		if _, err := timestamper.Timestamp(ctx, &tspclient.Request{}); !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected context.Canceled, but got %v", err)
+		}
+		if second.calls != 0 {
+			t.Fatal("expected the second timestamper not to be called")
+		}
+	})
+
+	t.Run("verify against roots of each server", func(t *testing.T) {
+		var timestampers []tspclient.Timestamper
+		var rootCAs []*x509.CertPool
+		for i := 0; i < 2; i++ {
+			tsaKey, tsaCertChain := tsatest.GenerateCertChain(t)
+			server, err := tsa.New(tsaKey, tsaCertChain)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ts := httptest.NewServer(server)
+			defer ts.Close()
+			timestamper, err := tspclient.NewHTTPTimestamper(nil, ts.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			timestampers = append(timestampers, timestamper)
+			pool := x509.NewCertPool()
+			pool.AddCert(tsaCertChain[1])
+			rootCAs = append(rootCAs, pool)
+		}
+		req, err := tspclient.NewRequest(tspclient.RequestOptions{
+			Content:       []byte("signature"),
+			HashAlgorithm: crypto.SHA256,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// the first server is trusted with the roots of the second server
+		timestamper := &FailoverTimestamper{
+			Timestampers: timestampers,
+			URLs:         []string{"http://tsa1.example", "http://tsa2.example"},
+			RootCAs:      []*x509.CertPool{rootCAs[1], rootCAs[1]},
+		}
+		resp, err := timestamper.Timestamp(context.Background(), req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		token, err := resp.SignedToken()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := token.Verify(context.Background(), x509.VerifyOptions{Roots: rootCAs[1]}); err != nil {
+			t.Fatalf("expected the timestamp of the second server, but got %v", err)
+		}
+
+		// no server is trusted with its own roots
+		timestamper.RootCAs = []*x509.CertPool{rootCAs[1], rootCAs[0]}
+		_, err = timestamper.Timestamp(context.Background(), req)
+		if err == nil || !strings.Contains(err.Error(), "failed to verify the timestamp against the root certificates of the tsa") {
+			t.Fatalf("expected verification error, but got %v", err)
+		}
+	})
+
+	t.Run("no timestampers", func(t *testing.T) {
+		if _, err := (&FailoverTimestamper{}).Timestamp(context.Background(), &tspclient.Request{}); err == nil {
+			t.Fatal("expected error when no timestamper is available")
+		}
+	})
+}
+
+func TestValidateFlags(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{name: "no timestamping"},
+		{name: "root cert", args: []string{"--timestamp-url", "http://tsa.example", "--timestamp-root-cert", "/tsa.crt"}},
+		{name: "trust store", args: []string{"--timestamp-url", "http://tsa.example", "--timestamp-trust-store", "tsa"}},
+		{name: "empty url", args: []string{"--timestamp-url", "", "--timestamp-root-cert", "/tsa.crt"}, wantErr: "timestamping: tsa url cannot be empty"},
+		{name: "empty root cert", args: []string{"--timestamp-url", "http://tsa.example", "--timestamp-root-cert", ""}, wantErr: "timestamping: tsa root certificate path cannot be empty"},
+		{name: "empty trust store", args: []string{"--timestamp-url", "http://tsa.example", "--timestamp-trust-store", ""}, wantErr: "timestamping: tsa trust store name cannot be empty"},
+		{name: "url only", args: []string{"--timestamp-url", "http://tsa.example"}, wantErr: "timestamping: either --timestamp-root-cert or --timestamp-trust-store is required when --timestamp-url is specified"},
+		{name: "root cert only", args: []string{"--timestamp-root-cert", "/tsa.crt"}, wantErr: "timestamping: --timestamp-url is required when --timestamp-root-cert or --timestamp-trust-store is specified"},
+		{name: "trust store only", args: []string{"--timestamp-trust-store", "tsa"}, wantErr: "timestamping: --timestamp-url is required when --timestamp-root-cert or --timestamp-trust-store is specified"},
+		{name: "disabled", args: []string{"--timestamp=false"}},
+		{name: "disabled with url", args: []string{"--timestamp=false", "--timestamp-url", "http://tsa.example", "--timestamp-root-cert", "/tsa.crt"}, wantErr: "timestamping: --timestamp=false cannot be used with --timestamp-url, --timestamp-root-cert or --timestamp-trust-store"},
+		{name: "disabled with trust store", args: []string{"--timestamp=false", "--timestamp-trust-store", "tsa"}, wantErr: "timestamping: --timestamp=false cannot be used with --timestamp-url, --timestamp-root-cert or --timestamp-trust-store"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var url, rootCert, trustStore string
+			var enabled bool
+			fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+			fs.BoolVar(&enabled, "timestamp", true, "")
+			fs.StringVar(&url, "timestamp-url", "", "")
+			fs.StringVar(&rootCert, "timestamp-root-cert", "", "")
+			fs.StringVar(&trustStore, "timestamp-trust-store", "", "")
+			if err := fs.Parse(tt.args); err != nil {
+				t.Fatal(err)
+			}
+			err := ValidateFlags(fs, url, rootCert, trustStore)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("expected no error, but got %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Fatalf("expected error %q, but got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestServers(t *testing.T) {
+	defer func(oldDir string) {
+		dir.UserConfigDir = oldDir
+	}(dir.UserConfigDir)
+	dir.UserConfigDir = t.TempDir()
+	content := `{"timestamp":{"servers":[{"url":"http://tsa1.example","rootCert":"/tsa1.crt"},{"url":"http://tsa2.example","rootCert":"/tsa2.crt"}]}}`
+	if err := os.WriteFile(filepath.Join(dir.UserConfigDir, dir.PathConfigFile), []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("flag", func(t *testing.T) {
+		servers, err := Servers(true, "http://tsa.example", "/tsa.crt", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := []configutil.TimestampServer{{URL: "http://tsa.example", RootCert: "/tsa.crt"}}
+		if !reflect.DeepEqual(servers, expected) {
+			t.Fatalf("expected %v, but got %v", expected, servers)
+		}
+	})
+
+	t.Run("config", func(t *testing.T) {
+		servers, err := Servers(true, "", "", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := []configutil.TimestampServer{
+			{URL: "http://tsa1.example", RootCert: "/tsa1.crt"},
+			{URL: "http://tsa2.example", RootCert: "/tsa2.crt"},
+		}
+		if !reflect.DeepEqual(servers, expected) {
+			t.Fatalf("expected %v, but got %v", expected, servers)
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		servers, err := Servers(false, "", "", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(servers) != 0 {
+			t.Fatalf("expected no server, but got %v", servers)
+		}
+	})
+}
+
+func TestNew(t *testing.T) {
+	t.Run("no servers", func(t *testing.T) {
+		if _, _, err := New(context.Background(), nil, time.Second); err == nil {
+			t.Fatal("expected error when no server is specified")
+		}
+	})
+
+	t.Run("multiple servers", func(t *testing.T) {
+		servers := []configutil.TimestampServer{
+			{URL: "http://tsa1.example", RootCert: "../../../../internal/testdata/tsaRootCA.cer"},
+			{URL: "http://tsa2.example", RootCert: "../../../../internal/testdata/GlobalSignRootCA.crt"},
+		}
+		timestamper, rootCAs, err := New(context.Background(), servers, time.Second)
+		if err != nil {
+			t.Fatal(err)
+		}
+		failover, ok := timestamper.(*FailoverTimestamper)
+		if !ok {
+			t.Fatalf("expected *FailoverTimestamper, but got %T", timestamper)
+		}
+		if len(failover.Timestampers) != 2 || !reflect.DeepEqual(failover.URLs, []string{"http://tsa1.example", "http://tsa2.example"}) {
+			t.Fatalf("unexpected timestamper %+v", failover)
+		}
+		if got := len(rootCAs.Subjects()); got != 2 {
+			t.Fatalf("expected 2 root certificates, but got %d", got)
+		}
+		if len(failover.RootCAs) != 2 {
+			t.Fatalf("expected root certificates of 2 servers, but got %d", len(failover.RootCAs))
+		}
+		for i, pool := range failover.RootCAs {
+			if got := len(pool.Subjects()); got != 1 {
+				t.Fatalf("expected 1 root certificate of server %d, but got %d", i, got)
+			}
+		}
+	})
+
+	t.Run("trust store", func(t *testing.T) {
+		defer func(oldDir string) {
+			dir.UserConfigDir = oldDir
+		}(dir.UserConfigDir)
+		dir.UserConfigDir = t.TempDir()
+		storeDir := filepath.Join(dir.UserConfigDir, dir.X509TrustStoreDir("tsa", "test"))
+		if err := os.MkdirAll(storeDir, 0700); err != nil {
+			t.Fatal(err)
+		}
+		for _, name := range []string{"tsaRootCA.cer", "GlobalSignRootCA.crt"} {
+			data, err := os.ReadFile(filepath.Join("../../../../internal/testdata", name))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(storeDir, name), data, 0600); err != nil {
+				t.Fatal(err)
+			}
+		}
+		servers := []configutil.TimestampServer{{URL: "http://tsa.example", TrustStore: "test"}}
+		_, rootCAs, err := New(context.Background(), servers, time.Second)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := len(rootCAs.Subjects()); got != 2 {
+			t.Fatalf("expected 2 root certificates, but got %d", got)
+		}
+
+		servers = []configutil.TimestampServer{{URL: "http://tsa.example", TrustStore: "nonexistent"}}
+		if _, _, err := New(context.Background(), servers, time.Second); err == nil {
+			t.Fatal("expected error for non-existent trust store")
+		}
+	})
+
+	t.Run("invalid url", func(t *testing.T) {
+		servers := []configutil.TimestampServer{{URL: "tsa.example", RootCert: "../../../../internal/testdata/tsaRootCA.cer"}}
+		if _, _, err := New(context.Background(), servers, time.Second); err == nil {
+			t.Fatal("expected error for invalid url")
+		}
+	})
+
+	t.Run("invalid root cert", func(t *testing.T) {
+		servers := []configutil.TimestampServer{{URL: "http://tsa.example", RootCert: "../../../../internal/testdata/intermediate.pem"}}
+		if _, _, err := New(context.Background(), servers, time.Second); err == nil {
+			t.Fatal("expected error for non-root certificate")
+		}
+	})
+}
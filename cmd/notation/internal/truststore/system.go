@@ -0,0 +1,100 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package truststore
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/notaryproject/notation-go/dir"
+	"github.com/notaryproject/notation-go/log"
+	"github.com/notaryproject/notation-go/verifier/truststore"
+	nx509 "github.com/notaryproject/notation/internal/x509"
+)
+
+// x509TrustStore is a truststore.X509TrustStore resolving the named store
+// nx509.SystemStoreName of type tsa from the system root certificate bundle
+// and any other named store from the notation trust store.
+type x509TrustStore struct {
+	truststore.X509TrustStore
+
+	// trustStorefs is the file system of the notation trust store.
+	trustStorefs dir.SysFS
+}
+
+// NewX509TrustStore creates a truststore.X509TrustStore given the file system
+// of the notation trust store, with the named store nx509.SystemStoreName of
+// type tsa resolved from the system root certificate bundle.
+func NewX509TrustStore(trustStorefs dir.SysFS) truststore.X509TrustStore {
+	return &x509TrustStore{
+		X509TrustStore: truststore.NewX509TrustStore(trustStorefs),
+		trustStorefs:   trustStorefs,
+	}
+}
+
+// GetCertificates returns certificates under storeType/namedStore.
+func (s *x509TrustStore) GetCertificates(ctx context.Context, storeType truststore.Type, namedStore string) ([]*x509.Certificate, error) {
+	if storeType != truststore.TypeTSA || namedStore != nx509.SystemStoreName {
+		return s.X509TrustStore.GetCertificates(ctx, storeType, namedStore)
+	}
+	if err := CheckReservedSystemStore(s.trustStorefs); err != nil {
+		return nil, err
+	}
+	_, certs, err := SystemCerts(ctx)
+	return certs, err
+}
+
+// CheckReservedSystemStore returns an error if the named store
+// nx509.SystemStoreName of type tsa, which is reserved for the system root
+// certificate bundle, exists in the notation trust store with file system
+// trustStorefs, so that its certificates are not silently ignored.
+func CheckReservedSystemStore(trustStorefs dir.SysFS) error {
+	path, err := trustStorefs.SysPath(dir.X509TrustStoreDir(string(truststore.TypeTSA), nx509.SystemStoreName))
+	if err != nil {
+		return truststore.TrustStoreError{InnerError: err, Msg: fmt.Sprintf("failed to get the path of the trust store %q", nx509.SystemStoreName)}
+	}
+	if _, err := os.Lstat(path); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return truststore.TrustStoreError{InnerError: err, Msg: fmt.Sprintf("failed to access the trust store %q", nx509.SystemStoreName)}
+	}
+	return truststore.TrustStoreError{Msg: fmt.Sprintf("the trust store %s/%s is reserved for the system root certificate bundle, but the directory %s exists. Move its certificates to another trust store and remove the directory", truststore.TypeTSA, nx509.SystemStoreName, path)}
+}
+
+// SystemCerts returns the path of the system root certificate bundle along
+// with its certificates that are valid trusted certificates. Invalid
+// certificates are skipped.
+func SystemCerts(ctx context.Context) (string, []*x509.Certificate, error) {
+	logger := log.GetLogger(ctx)
+
+	path, certs, err := nx509.ReadSystemCertificates()
+	if err != nil {
+		return "", nil, truststore.TrustStoreError{InnerError: err, Msg: fmt.Sprintf("failed to access the trust store %q", nx509.SystemStoreName)}
+	}
+	logger.Debugln("Reading system root certificates from:", path)
+	var validCerts []*x509.Certificate
+	for _, cert := range certs {
+		if err := truststore.ValidateCertificates([]*x509.Certificate{cert}); err != nil {
+			logger.Debugf("Skipped system root certificate %q: %v", cert.Subject, err)
+			continue
+		}
+		validCerts = append(validCerts, cert)
+	}
+	return path, validCerts, nil
+}
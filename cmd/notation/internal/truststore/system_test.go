@@ -0,0 +1,97 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package truststore
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/notaryproject/notation-go/dir"
+	"github.com/notaryproject/notation-go/verifier/truststore"
+	nx509 "github.com/notaryproject/notation/internal/x509"
+)
+
+func TestX509TrustStore_System(t *testing.T) {
+	t.Setenv("SSL_CERT_FILE", "./testdata/NotationTestRoot.pem")
+	trustStore := NewX509TrustStore(dir.NewSysFS(t.TempDir()))
+
+	certs, err := trustStore.GetCertificates(context.Background(), truststore.TypeTSA, nx509.SystemStoreName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(certs) != 1 {
+		t.Fatalf("expected 1 system certificate, but got %d", len(certs))
+	}
+
+	// the system root certificate bundle is only used for type tsa
+	if _, err := trustStore.GetCertificates(context.Background(), truststore.TypeCA, nx509.SystemStoreName); err == nil {
+		t.Fatal("expected error for missing named store ca/system, but got nil")
+	}
+	if _, err := trustStore.GetCertificates(context.Background(), truststore.TypeCA, "test"); err == nil {
+		t.Fatal("expected error for missing named store, but got nil")
+	}
+}
+
+func TestX509TrustStore_SystemOnDisk(t *testing.T) {
+	t.Setenv("SSL_CERT_FILE", "./testdata/NotationTestRoot.pem")
+	root := t.TempDir()
+	trustStoreFS := dir.NewSysFS(root)
+	for _, storeType := range []string{"ca", "tsa"} {
+		storeDir := filepath.Join(root, dir.X509TrustStoreDir(storeType, nx509.SystemStoreName))
+		if err := os.MkdirAll(storeDir, 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := copyFile("./testdata/NotationTestRoot.pem", filepath.Join(storeDir, "root.pem")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	trustStore := NewX509TrustStore(trustStoreFS)
+
+	// the on-disk store of type ca is used as is
+	certs, err := trustStore.GetCertificates(context.Background(), truststore.TypeCA, nx509.SystemStoreName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(certs) != 1 {
+		t.Fatalf("expected 1 certificate, but got %d", len(certs))
+	}
+
+	// the on-disk store of type tsa is not shadowed silently
+	if _, err := trustStore.GetCertificates(context.Background(), truststore.TypeTSA, nx509.SystemStoreName); err == nil || !strings.Contains(err.Error(), "is reserved for the system root certificate bundle") {
+		t.Fatalf("expected error for on-disk store tsa/system, but got %v", err)
+	}
+	if err := CheckReservedSystemStore(trustStoreFS); err == nil {
+		t.Fatal("expected error for on-disk store tsa/system, but got nil")
+	}
+}
+
+func copyFile(src, dst string) error {
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(dst, data, 0600)
+}
+
+func TestAddCert_SystemStore(t *testing.T) {
+	if err := AddCert("./testdata/NotationTestRoot.pem", "tsa", nx509.SystemStoreName, false); err != ErrReservedSystemStore {
+		t.Fatalf("expected err: %v, but got: %v", ErrReservedSystemStore, err)
+	}
+	if _, _, err := AddCertSplit("./testdata/NotationTestRoot.pem", "tsa", nx509.SystemStoreName); err != ErrReservedSystemStore {
+		t.Fatalf("expected err: %v, but got: %v", ErrReservedSystemStore, err)
+	}
+}
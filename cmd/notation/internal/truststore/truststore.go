@@ -17,6 +17,7 @@ import (
 	"crypto/sha256"
 	"crypto/x509"
 	"encoding/hex"
+	"encoding/pem"
 	"errors"
 	"fmt"
 	"io/fs"
@@ -24,17 +25,39 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
+	"time"
 
 	corex509 "github.com/notaryproject/notation-core-go/x509"
 	"github.com/notaryproject/notation-go/dir"
 	"github.com/notaryproject/notation-go/verifier/truststore"
 	"github.com/notaryproject/notation/cmd/notation/internal/cmdutil"
 	"github.com/notaryproject/notation/internal/osutil"
+	nx509 "github.com/notaryproject/notation/internal/x509"
 )
 
+// ErrReservedSystemStore is returned when adding certificates to the named
+// store of type tsa reserved for the system root certificate bundle.
+var ErrReservedSystemStore = fmt.Errorf("named store %q of type %q is reserved for the system root certificate bundle and cannot be modified", nx509.SystemStoreName, truststore.TypeTSA)
+
+// IsReservedSystemStore returns true if namedStore of type storeType is
+// reserved for the system root certificate bundle.
+func IsReservedSystemStore(storeType, namedStore string) bool {
+	return storeType == string(truststore.TypeTSA) && namedStore == nx509.SystemStoreName
+}
+
 // AddCert adds a single cert file at path to the trust store
 // under dir truststore/x509/storeType/namedStore
 func AddCert(path, storeType, namedStore string, display bool) error {
+	return AddCertWithName(path, storeType, namedStore, "", display)
+}
+
+// AddCertWithName adds a single cert file at path to the trust store
+// under dir truststore/x509/storeType/namedStore as fileName. The base name of
+// path is used if fileName is empty.
+//
+// The cert file is not added if any of its certificates is already in the
+// named store, detected by SHA-256 fingerprints regardless of file names.
+func AddCertWithName(path, storeType, namedStore, fileName string, display bool) error {
 	// initialize
 	certPath, err := filepath.Abs(path)
 	if err != nil {
@@ -49,6 +72,14 @@ func AddCert(path, storeType, namedStore string, display bool) error {
 	if !IsValidFileName(namedStore) {
 		return errors.New("named store name needs to follow [a-zA-Z0-9_.-]+ format")
 	}
+	if IsReservedSystemStore(storeType, namedStore) {
+		return ErrReservedSystemStore
+	}
+	if fileName == "" {
+		fileName = filepath.Base(certPath)
+	} else if !IsValidFileName(fileName) || fileName == "." || fileName == ".." {
+		return errors.New("certificate file name needs to follow [a-zA-Z0-9_.-]+ format")
+	}
 
 	// check if the target path is a x509 certificate
 	// (support PEM and DER formats)
@@ -68,28 +99,164 @@ func AddCert(path, storeType, namedStore string, display bool) error {
 	}
 
 	// check if certificate already in the trust store
-	if _, err := os.Stat(filepath.Join(trustStorePath, filepath.Base(certPath))); err == nil {
-		return errors.New("certificate already exists in the Trust Store")
+	existing, err := certFingerprints(trustStorePath)
+	if err != nil {
+		return err
+	}
+	for _, cert := range certs {
+		fingerprint := CertFingerprint(cert)
+		if existingFile, ok := existing[fingerprint]; ok {
+			return fmt.Errorf("certificate already exists in the Trust Store: %q with SHA256 fingerprint %s is stored in %s", cert.Subject, fingerprint, existingFile)
+		}
 	}
 	// add cert to trust store
-	_, err = osutil.CopyToDir(certPath, trustStorePath)
+	data, err := os.ReadFile(certPath)
 	if err != nil {
 		return err
 	}
+	if err := osutil.WriteFileWithPermission(filepath.Join(trustStorePath, fileName), data, 0600, false); err != nil {
+		if errors.Is(err, fs.ErrExist) {
+			return fmt.Errorf("a different certificate file named %s already exists in the Trust Store", fileName)
+		}
+		return err
+	}
 
 	// write out
 	if display {
-		fmt.Printf("Successfully added %s to named store %s of type %s\n", filepath.Base(certPath), namedStore, storeType)
+		fmt.Printf("Successfully added %s to named store %s of type %s\n", fileName, namedStore, storeType)
 	}
 
 	return nil
 }
 
+// AddCertSplit adds each certificate of the cert file at path to the trust
+// store under dir truststore/x509/storeType/namedStore as its own PEM file
+// named by the subject common name and the SHA-256 fingerprint of the
+// certificate. Certificates already in the named store are skipped by
+// comparing SHA-256 fingerprints rather than file names.
+//
+// The file names of the added certificates and the skipped certificates are
+// returned.
+func AddCertSplit(path, storeType, namedStore string) ([]string, []*x509.Certificate, error) {
+	// initialize
+	if storeType == "" {
+		return nil, nil, errors.New("store type cannot be empty")
+	}
+	if !IsValidStoreType(storeType) {
+		return nil, nil, fmt.Errorf("unsupported store type: %s", storeType)
+	}
+	if !IsValidFileName(namedStore) {
+		return nil, nil, errors.New("named store name needs to follow [a-zA-Z0-9_.-]+ format")
+	}
+	if IsReservedSystemStore(storeType, namedStore) {
+		return nil, nil, ErrReservedSystemStore
+	}
+	certs, err := corex509.ReadCertificateFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(certs) == 0 {
+		return nil, nil, errors.New("no valid certificate found in the file")
+	}
+
+	// core process
+	trustStorePath, err := dir.ConfigFS().SysPath(dir.TrustStoreDir, "x509", storeType, namedStore)
+	if err := CheckNonErrNotExistError(err); err != nil {
+		return nil, nil, err
+	}
+	existing, err := certFingerprints(trustStorePath)
+	if err != nil {
+		return nil, nil, err
+	}
+	var added []string
+	var skipped []*x509.Certificate
+	for _, cert := range certs {
+		fingerprint := CertFingerprint(cert)
+		if _, ok := existing[fingerprint]; ok {
+			skipped = append(skipped, cert)
+			continue
+		}
+		fileName := splitCertFileName(cert)
+		certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+		if err := osutil.WriteFileWithPermission(filepath.Join(trustStorePath, fileName), certPEM, 0600, false); err != nil {
+			if errors.Is(err, fs.ErrExist) {
+				return added, skipped, fmt.Errorf("failed to add certificate %q: file %s already exists in the Trust Store", cert.Subject, fileName)
+			}
+			return added, skipped, err
+		}
+		existing[fingerprint] = fileName
+		added = append(added, fileName)
+	}
+	return added, skipped, nil
+}
+
+// CertFingerprint returns the SHA-256 fingerprint of cert in lowercase hex.
+func CertFingerprint(cert *x509.Certificate) string {
+	h := sha256.Sum256(cert.Raw)
+	return strings.ToLower(hex.EncodeToString(h[:]))
+}
+
+// certFingerprints returns the SHA-256 fingerprints of the certificates in
+// the named store at trustStorePath mapped to the names of the cert files
+// containing them. Sub-dirs are ignored.
+func certFingerprints(trustStorePath string) (map[string]string, error) {
+	fingerprints := make(map[string]string)
+	certPaths, err := ListCerts(trustStorePath, 0)
+	if err := CheckNonErrNotExistError(err); err != nil {
+		return nil, err
+	}
+	for _, certPath := range certPaths {
+		certs, err := corex509.ReadCertificateFile(certPath)
+		if err != nil {
+			return nil, err
+		}
+		for _, cert := range certs {
+			fingerprints[CertFingerprint(cert)] = filepath.Base(certPath)
+		}
+	}
+	return fingerprints, nil
+}
+
+// splitCertFileName returns the file name of cert split from a certificate
+// file, in the format of <common_name>_<sha256_fingerprint>.pem. Characters
+// of the common name not in [a-zA-Z0-9_.-] are replaced by "_".
+func splitCertFileName(cert *x509.Certificate) string {
+	commonName := regexp.MustCompile(`[^a-zA-Z0-9_.-]`).ReplaceAllString(cert.Subject.CommonName, "_")
+	commonName = strings.Trim(commonName, ".")
+	if len(commonName) > 64 {
+		commonName = commonName[:64]
+	}
+	if commonName == "" {
+		commonName = "certificate"
+	}
+	return commonName + "_" + CertFingerprint(cert) + ".pem"
+}
+
 // ListCerts walks through root and returns all x509 certificates in it,
 // sub-dirs are ignored.
 func ListCerts(root string, depth int) ([]string, error) {
-	maxDepth := strings.Count(root, string(os.PathSeparator)) + depth
+	files, err := ListCertFiles(root, depth)
+	if err != nil {
+		return nil, err
+	}
 	var certPaths []string
+	for _, path := range files {
+		certs, err := corex509.ReadCertificateFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if len(certs) != 0 {
+			certPaths = append(certPaths, path)
+		}
+	}
+	return certPaths, nil
+}
+
+// ListCertFiles walks through root and returns all regular files in it
+// without parsing them, sub-dirs are ignored.
+func ListCertFiles(root string, depth int) ([]string, error) {
+	maxDepth := strings.Count(root, string(os.PathSeparator)) + depth
+	var files []string
 	if err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
@@ -102,44 +269,55 @@ func ListCerts(root string, depth int) ([]string, error) {
 			return err
 		}
 		if info.Mode().IsRegular() {
-			certs, err := corex509.ReadCertificateFile(path)
-			if err != nil {
-				return err
-			}
-			if len(certs) != 0 {
-				certPaths = append(certPaths, path)
-			}
+			files = append(files, path)
 		}
 		return nil
 	}); err != nil {
 		return nil, err
 	}
+	return files, nil
+}
 
-	return certPaths, nil
+// ExpiringCert is a certificate in a certificate file of the trust store that
+// is expired or expiring, or a certificate file that cannot be read.
+type ExpiringCert struct {
+	// Path is the path of the certificate file.
+	Path string
+
+	// Cert is the expired or expiring certificate. It is nil if Err is set.
+	Cert *x509.Certificate
+
+	// Err is the error of reading the certificate file, if any.
+	Err error
 }
 
-// ShowCerts writes out details of certificates
-func ShowCerts(certs []*x509.Certificate) {
-	fmt.Println("Certificate details")
-	fmt.Println("--------------------------------------------------------------------------------")
-	for ind, cert := range certs {
-		showCert(cert)
-		if ind != len(certs)-1 {
-			fmt.Println("--------------------------------------------------------------------------------")
+// FindExpiringCerts parses the certificate files at certPaths and returns the
+// certificates that are expired at time now or expire within the duration
+// after now, in the order of certPaths. A certificate file that cannot be read
+// is returned as an entry with Err set, and the remaining files are still
+// checked.
+func FindExpiringCerts(certPaths []string, now time.Time, within time.Duration) []ExpiringCert {
+	deadline := now.Add(within)
+	var expiringCerts []ExpiringCert
+	for _, path := range certPaths {
+		certs, err := corex509.ReadCertificateFile(path)
+		if err != nil {
+			expiringCerts = append(expiringCerts, ExpiringCert{
+				Path: path,
+				Err:  fmt.Errorf("failed to read certificate file %s: %w", path, err),
+			})
+			continue
+		}
+		for _, cert := range certs {
+			if cert.NotAfter.Before(deadline) {
+				expiringCerts = append(expiringCerts, ExpiringCert{
+					Path: path,
+					Cert: cert,
+				})
+			}
 		}
 	}
-}
-
-// showCert displays details of a certificate
-func showCert(cert *x509.Certificate) {
-	fmt.Println("Issuer:", cert.Issuer)
-	fmt.Println("Subject:", cert.Subject)
-	fmt.Println("Valid from:", cert.NotBefore)
-	fmt.Println("Valid to:", cert.NotAfter)
-	fmt.Println("IsCA:", cert.IsCA)
-
-	h := sha256.Sum256(cert.Raw)
-	fmt.Println("SHA256 Thumbprint:", strings.ToLower(hex.EncodeToString(h[:])))
+	return expiringCerts
 }
 
 // DeleteAllCerts deletes all certificate files from the trust store
@@ -194,6 +372,92 @@ func DeleteCert(storeType, namedStore, cert string, confirmed bool) error {
 	return nil
 }
 
+// DeleteCertByFingerprint deletes the certificate with the SHA-256
+// fingerprint from the trust store under dir
+// truststore/x509/storeType/namedStore regardless of the names of the cert
+// files containing it. A cert file is deleted if it only contains the
+// certificate, otherwise the certificate is removed from the cert file.
+func DeleteCertByFingerprint(storeType, namedStore, fingerprint string, confirmed bool) error {
+	fingerprint, err := normalizeFingerprint(fingerprint)
+	if err != nil {
+		return err
+	}
+	trustStorePath, err := dir.ConfigFS().SysPath(dir.TrustStoreDir, "x509", storeType, namedStore)
+	if err != nil {
+		return err
+	}
+	certPaths, err := ListCerts(trustStorePath, 0)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("no certificate with SHA256 fingerprint %s found in %q of type %q", fingerprint, namedStore, storeType)
+		}
+		return err
+	}
+
+	// find cert files containing the certificate
+	remaining := make(map[string][]*x509.Certificate)
+	var matchedPaths []string
+	for _, certPath := range certPaths {
+		certs, err := corex509.ReadCertificateFile(certPath)
+		if err != nil {
+			return err
+		}
+		var others []*x509.Certificate
+		for _, cert := range certs {
+			if CertFingerprint(cert) != fingerprint {
+				others = append(others, cert)
+			}
+		}
+		if len(others) != len(certs) {
+			matchedPaths = append(matchedPaths, certPath)
+			remaining[certPath] = others
+		}
+	}
+	if len(matchedPaths) == 0 {
+		return fmt.Errorf("no certificate with SHA256 fingerprint %s found in %q of type %q", fingerprint, namedStore, storeType)
+	}
+
+	prompt := fmt.Sprintf("Are you sure you want to delete the certificate with SHA256 fingerprint %s in %q of type %q?", fingerprint, namedStore, storeType)
+	confirmed, err = cmdutil.AskForConfirmation(os.Stdin, prompt, confirmed)
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		return nil
+	}
+	for _, certPath := range matchedPaths {
+		others := remaining[certPath]
+		if len(others) == 0 {
+			if err := os.Remove(certPath); err != nil {
+				return err
+			}
+			// write out on success
+			fmt.Printf("Successfully deleted %s\n", filepath.Base(certPath))
+			continue
+		}
+		var certPEM []byte
+		for _, cert := range others {
+			certPEM = append(certPEM, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})...)
+		}
+		if err := osutil.WriteFileWithPermission(certPath, certPEM, 0600, true); err != nil {
+			return err
+		}
+		// write out on success
+		fmt.Printf("Successfully deleted the certificate from %s\n", filepath.Base(certPath))
+	}
+	return nil
+}
+
+// normalizeFingerprint returns the SHA-256 fingerprint in lowercase hex
+// without colons.
+func normalizeFingerprint(fingerprint string) (string, error) {
+	normalized := strings.ToLower(strings.ReplaceAll(fingerprint, ":", ""))
+	if !regexp.MustCompile(`^[0-9a-f]{64}$`).MatchString(normalized) {
+		return "", fmt.Errorf("invalid SHA256 fingerprint %q, expecting 64 hexadecimal characters", fingerprint)
+	}
+	return normalized, nil
+}
+
 // CheckNonErrNotExistError returns nil when err is nil or err is fs.ErrNotExist
 func CheckNonErrNotExistError(err error) error {
 	if err != nil && !errors.Is(err, fs.ErrNotExist) {
@@ -14,13 +14,17 @@
 package truststore
 
 import (
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"errors"
 	"os"
 	"path/filepath"
 	"runtime"
 	"strings"
 	"testing"
+	"time"
 
+	corex509 "github.com/notaryproject/notation-core-go/x509"
 	"github.com/notaryproject/notation-go/dir"
 )
 
@@ -65,13 +69,47 @@ func TestAddCert(t *testing.T) {
 	t.Run("cert already exists", func(t *testing.T) {
 		dir.UserConfigDir = "testdata"
 		path := filepath.FromSlash("testdata/self-signed.crt")
-		expectedErrMsg := "certificate already exists in the Trust Store"
+		expectedErrMsg := `certificate already exists in the Trust Store: "CN=alpine,O=Notary,L=Seattle,ST=WA,C=US" with SHA256 fingerprint 01937612e3e24e6496b325fb899fdb3b7045350ab2d91141c6310a917d5cebe2 is stored in self-signed.crt`
 		err := AddCert(path, "ca", "test", false)
 		if err == nil || err.Error() != expectedErrMsg {
 			t.Fatalf("expected err: %v, but got: %v", expectedErrMsg, err)
 		}
 	})
 
+	t.Run("cert already exists with a different name", func(t *testing.T) {
+		dir.UserConfigDir = "testdata"
+		path := filepath.FromSlash("testdata/self-signed.crt")
+		expectedErrMsg := "certificate already exists in the Trust Store"
+		err := AddCertWithName(path, "ca", "test", "another-name.crt", false)
+		if err == nil || !strings.HasPrefix(err.Error(), expectedErrMsg) {
+			t.Fatalf("expected err: %v, but got: %v", expectedErrMsg, err)
+		}
+	})
+
+	t.Run("different cert with the same name", func(t *testing.T) {
+		dir.UserConfigDir = t.TempDir()
+		if err := AddCertWithName(filepath.FromSlash("testdata/NotationTestRoot.pem"), "ca", "test", "root.pem", false); err != nil {
+			t.Fatalf("AddCertWithName() failed: %v", err)
+		}
+		expectedErrMsg := "a different certificate file named root.pem already exists in the Trust Store"
+		err := AddCertWithName(filepath.FromSlash("testdata/self-signed.crt"), "ca", "test", "root.pem", false)
+		if err == nil || err.Error() != expectedErrMsg {
+			t.Fatalf("expected err: %v, but got: %v", expectedErrMsg, err)
+		}
+		// the same cert can be added with a different name
+		if err := AddCertWithName(filepath.FromSlash("testdata/self-signed.crt"), "ca", "test", "self-signed.pem", false); err != nil {
+			t.Fatalf("AddCertWithName() failed: %v", err)
+		}
+	})
+
+	t.Run("invalid file name", func(t *testing.T) {
+		expectedErrMsg := "certificate file name needs to follow [a-zA-Z0-9_.-]+ format"
+		err := AddCertWithName(filepath.FromSlash("testdata/self-signed.crt"), "ca", "test", "../root.pem", false)
+		if err == nil || err.Error() != expectedErrMsg {
+			t.Fatalf("expected err: %v, but got: %v", expectedErrMsg, err)
+		}
+	})
+
 	t.Run("empty file", func(t *testing.T) {
 		path := filepath.FromSlash("../../../../internal/testdata/Empty.txt")
 		expectedErr := errors.New("no valid certificate found in the empty file")
@@ -101,6 +139,107 @@ func TestAddCert(t *testing.T) {
 	})
 }
 
+func TestAddCertSplit(t *testing.T) {
+	defer func(oldDir string) {
+		dir.UserConfigDir = oldDir
+	}(dir.UserConfigDir)
+	dir.UserConfigDir = t.TempDir()
+
+	root, err := os.ReadFile(filepath.FromSlash("testdata/NotationTestRoot.pem"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	selfSigned, err := os.ReadFile(filepath.FromSlash("testdata/self-signed.crt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	bundlePath := filepath.Join(t.TempDir(), "bundle.pem")
+	if err := os.WriteFile(bundlePath, []byte(string(root)+string(selfSigned)+string(root)), 0600); err != nil {
+		t.Fatal(err)
+	}
+	certs, err := corex509.ReadCertificateFile(bundlePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootFileName := splitCertFileName(certs[0])
+	selfSignedFileName := splitCertFileName(certs[1])
+
+	added, skipped, err := AddCertSplit(bundlePath, "ca", "test")
+	if err != nil {
+		t.Fatalf("AddCertSplit() failed: %v", err)
+	}
+	if len(added) != 2 || added[0] != rootFileName || added[1] != selfSignedFileName {
+		t.Fatalf("expected certificates %s and %s to be added, but got %v", rootFileName, selfSignedFileName, added)
+	}
+	// the duplicate in the bundle is skipped
+	if len(skipped) != 1 || CertFingerprint(skipped[0]) != CertFingerprint(certs[0]) {
+		t.Fatalf("expected the duplicate root certificate to be skipped, but got %d skipped", len(skipped))
+	}
+	for _, fileName := range added {
+		certs, err := corex509.ReadCertificateFile(filepath.Join(dir.UserConfigDir, "truststore", "x509", "ca", "test", fileName))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(certs) != 1 {
+			t.Fatalf("expected %s to contain 1 certificate, but got %d", fileName, len(certs))
+		}
+	}
+
+	// certificates already in the store are skipped regardless of file names
+	if err := AddCert(filepath.FromSlash("testdata/self-signed.crt"), "ca", "existing", false); err != nil {
+		t.Fatal(err)
+	}
+	added, skipped, err = AddCertSplit(bundlePath, "ca", "existing")
+	if err != nil {
+		t.Fatalf("AddCertSplit() failed: %v", err)
+	}
+	if len(added) != 1 || added[0] != rootFileName {
+		t.Fatalf("expected only %s to be added, but got %v", rootFileName, added)
+	}
+	if len(skipped) != 2 {
+		t.Fatalf("expected 2 certificates to be skipped, but got %d", len(skipped))
+	}
+
+	t.Run("invalid store type", func(t *testing.T) {
+		expectedErrMsg := "unsupported store type: invalid"
+		if _, _, err := AddCertSplit(bundlePath, "invalid", "test"); err == nil || err.Error() != expectedErrMsg {
+			t.Fatalf("expected error %q, but got %v", expectedErrMsg, err)
+		}
+	})
+
+	t.Run("invalid certificate file", func(t *testing.T) {
+		if _, _, err := AddCertSplit(filepath.FromSlash("testdata/invalid.txt"), "ca", "test"); err == nil {
+			t.Fatal("expected error for invalid certificate file, but ok")
+		}
+	})
+}
+
+func TestSplitCertFileName(t *testing.T) {
+	cert := &x509.Certificate{
+		Raw: []byte("test"),
+	}
+	fingerprint := CertFingerprint(cert)
+	tests := []struct {
+		commonName string
+		expected   string
+	}{
+		{commonName: "wabbit-networks.io", expected: "wabbit-networks.io_" + fingerprint + ".pem"},
+		{commonName: "Acme Rockets Root CA", expected: "Acme_Rockets_Root_CA_" + fingerprint + ".pem"},
+		{commonName: "../evil", expected: "_evil_" + fingerprint + ".pem"},
+		{commonName: "", expected: "certificate_" + fingerprint + ".pem"},
+		{commonName: strings.Repeat("a", 100), expected: strings.Repeat("a", 64) + "_" + fingerprint + ".pem"},
+	}
+	for _, tt := range tests {
+		cert.Subject = pkix.Name{CommonName: tt.commonName}
+		if got := splitCertFileName(cert); got != tt.expected {
+			t.Errorf("splitCertFileName() with common name %q = %q, want %q", tt.commonName, got, tt.expected)
+		}
+		if !IsValidFileName(splitCertFileName(cert)) {
+			t.Errorf("splitCertFileName() with common name %q is not a valid file name", tt.commonName)
+		}
+	}
+}
+
 func TestDeleteAllCerts(t *testing.T) {
 	defer func(oldDir string) {
 		dir.UserConfigDir = oldDir
@@ -119,3 +258,116 @@ func TestDeleteAllCerts(t *testing.T) {
 		}
 	})
 }
+
+func TestDeleteCertByFingerprint(t *testing.T) {
+	defer func(oldDir string) {
+		dir.UserConfigDir = oldDir
+	}(dir.UserConfigDir)
+	dir.UserConfigDir = t.TempDir()
+
+	root, err := os.ReadFile(filepath.FromSlash("testdata/NotationTestRoot.pem"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	selfSigned, err := os.ReadFile(filepath.FromSlash("testdata/self-signed.crt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	bundlePath := filepath.Join(t.TempDir(), "bundle.pem")
+	if err := os.WriteFile(bundlePath, []byte(string(root)+string(selfSigned)), 0600); err != nil {
+		t.Fatal(err)
+	}
+	certs, err := corex509.ReadCertificateFile(bundlePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootFingerprint := CertFingerprint(certs[0])
+	selfSignedFingerprint := CertFingerprint(certs[1])
+	if err := AddCert(bundlePath, "ca", "test", false); err != nil {
+		t.Fatal(err)
+	}
+	storedPath := filepath.Join(dir.UserConfigDir, "truststore", "x509", "ca", "test", "bundle.pem")
+
+	t.Run("invalid fingerprint", func(t *testing.T) {
+		expectedErrMsg := `invalid SHA256 fingerprint "abcd", expecting 64 hexadecimal characters`
+		if err := DeleteCertByFingerprint("ca", "test", "abcd", true); err == nil || err.Error() != expectedErrMsg {
+			t.Fatalf("expected err: %v, but got: %v", expectedErrMsg, err)
+		}
+	})
+
+	t.Run("certificate not found", func(t *testing.T) {
+		fingerprint := strings.Repeat("0", 64)
+		expectedErrMsg := `no certificate with SHA256 fingerprint ` + fingerprint + ` found in "test" of type "ca"`
+		if err := DeleteCertByFingerprint("ca", "test", fingerprint, true); err == nil || err.Error() != expectedErrMsg {
+			t.Fatalf("expected err: %v, but got: %v", expectedErrMsg, err)
+		}
+		if err := DeleteCertByFingerprint("ca", "non-existent", fingerprint, true); err == nil {
+			t.Fatal("expected error for non-existent named store, but ok")
+		}
+	})
+
+	t.Run("remove certificate from cert file", func(t *testing.T) {
+		if err := DeleteCertByFingerprint("ca", "test", strings.ToUpper(rootFingerprint), true); err != nil {
+			t.Fatalf("DeleteCertByFingerprint() failed: %v", err)
+		}
+		certs, err := corex509.ReadCertificateFile(storedPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(certs) != 1 || CertFingerprint(certs[0]) != selfSignedFingerprint {
+			t.Fatalf("expected only the self-signed certificate to remain in %s", storedPath)
+		}
+	})
+
+	t.Run("delete cert file", func(t *testing.T) {
+		if err := DeleteCertByFingerprint("ca", "test", selfSignedFingerprint, true); err != nil {
+			t.Fatalf("DeleteCertByFingerprint() failed: %v", err)
+		}
+		if _, err := os.Stat(storedPath); !errors.Is(err, os.ErrNotExist) {
+			t.Fatalf("expected %s to be deleted, but got: %v", storedPath, err)
+		}
+	})
+}
+
+func TestFindExpiringCerts(t *testing.T) {
+	certPaths := []string{
+		filepath.FromSlash("testdata/NotationTestRoot.pem"),
+		filepath.FromSlash("testdata/self-signed.crt"),
+	}
+	now := time.Date(2033, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("expiring", func(t *testing.T) {
+		expiringCerts := FindExpiringCerts(certPaths, now, 365*24*time.Hour)
+		if len(expiringCerts) != 1 || expiringCerts[0].Path != certPaths[1] || expiringCerts[0].Cert.Subject.CommonName != "alpine" {
+			t.Fatalf("expected self-signed.crt to be expiring, got %v", expiringCerts)
+		}
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		expiringCerts := FindExpiringCerts(certPaths, now.AddDate(1, 0, 0), 0)
+		if len(expiringCerts) != 1 || expiringCerts[0].Path != certPaths[1] {
+			t.Fatalf("expected self-signed.crt to be expired, got %v", expiringCerts)
+		}
+	})
+
+	t.Run("none", func(t *testing.T) {
+		expiringCerts := FindExpiringCerts(certPaths, now, 24*time.Hour)
+		if len(expiringCerts) != 0 {
+			t.Fatalf("expected no expiring certificate, got %v", expiringCerts)
+		}
+	})
+
+	t.Run("invalid certificate file", func(t *testing.T) {
+		paths := []string{filepath.FromSlash("testdata/invalid.txt"), certPaths[1]}
+		expiringCerts := FindExpiringCerts(paths, now.AddDate(1, 0, 0), 0)
+		if len(expiringCerts) != 2 {
+			t.Fatalf("expected an error entry and an expired certificate, got %v", expiringCerts)
+		}
+		if err := expiringCerts[0].Err; err == nil || !strings.Contains(err.Error(), "failed to read certificate file") {
+			t.Fatalf("expected failure of reading certificate file, got %v", err)
+		}
+		if expiringCerts[1].Path != certPaths[1] || expiringCerts[1].Cert == nil {
+			t.Fatalf("expected self-signed.crt to be expired, got %v", expiringCerts[1])
+		}
+	})
+}
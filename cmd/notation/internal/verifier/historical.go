@@ -0,0 +1,557 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verifier
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/notaryproject/notation-core-go/revocation"
+	corecrl "github.com/notaryproject/notation-core-go/revocation/crl"
+	"github.com/notaryproject/notation-core-go/revocation/result"
+	"github.com/notaryproject/notation-core-go/signature"
+	nx509 "github.com/notaryproject/notation-core-go/x509"
+	"github.com/notaryproject/notation-go"
+	"github.com/notaryproject/notation-go/dir"
+	"github.com/notaryproject/notation-go/log"
+	"github.com/notaryproject/notation-go/verifier"
+	"github.com/notaryproject/notation-go/verifier/trustpolicy"
+	notationgoTruststore "github.com/notaryproject/notation-go/verifier/truststore"
+	"github.com/notaryproject/tspclient-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"golang.org/x/crypto/ocsp"
+
+	"github.com/notaryproject/notation/cmd/notation/internal/truststore"
+	clirev "github.com/notaryproject/notation/internal/revocation"
+	cliocsp "github.com/notaryproject/notation/internal/revocation/ocsp"
+)
+
+// GetHistoricalVerifier creates a Verifier evaluating certificate validity,
+// signature expiry and revocation at the time at instead of now. The
+// revocation checks are configured by revocationOpts.
+func GetHistoricalVerifier(ctx context.Context, revocationOpts clirev.Options, at time.Time) (Verifier, error) {
+	verifierOptions, err := newVerifierOptions(ctx, revocationOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	// trust policy and trust store
+	x509TrustStore := truststore.NewX509TrustStore(dir.ConfigFS())
+	policyDocument, err := trustpolicy.LoadOCIDocument()
+	if err != nil {
+		return nil, err
+	}
+	relaxedDocument := *policyDocument
+	relaxedDocument.TrustPolicies = slices.Clone(policyDocument.TrustPolicies)
+	for i := range relaxedDocument.TrustPolicies {
+		relaxedDocument.TrustPolicies[i].SignatureVerification = relaxSignatureVerification(relaxedDocument.TrustPolicies[i].SignatureVerification)
+	}
+	verifierOptions.OCITrustPolicy = &relaxedDocument
+	base, err := verifier.NewVerifierWithOptions(x509TrustStore, verifierOptions)
+	if err != nil {
+		return nil, err
+	}
+	return newHistoricalVerifier(base, x509TrustStore, at, verifierOptions.RevocationCodeSigningValidator, func(artifactReference string) (trustpolicy.SignatureVerification, []string, error) {
+		statement, err := policyDocument.GetApplicableTrustPolicy(artifactReference)
+		if err != nil {
+			return trustpolicy.SignatureVerification{}, nil, err
+		}
+		return statement.SignatureVerification, statement.TrustStores, nil
+	}), nil
+}
+
+// GetHistoricalBlobVerifier creates a BlobVerifier evaluating certificate
+// validity, signature expiry and revocation at the time at instead of now.
+// The revocation checks are configured by revocationOpts.
+func GetHistoricalBlobVerifier(ctx context.Context, revocationOpts clirev.Options, at time.Time) (Verifier, error) {
+	verifierOptions, err := newVerifierOptions(ctx, revocationOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	// trust policy and trust store
+	x509TrustStore := truststore.NewX509TrustStore(dir.ConfigFS())
+	blobPolicyDocument, err := trustpolicy.LoadBlobDocument()
+	if err != nil {
+		return nil, err
+	}
+	relaxedDocument := *blobPolicyDocument
+	relaxedDocument.TrustPolicies = slices.Clone(blobPolicyDocument.TrustPolicies)
+	for i := range relaxedDocument.TrustPolicies {
+		relaxedDocument.TrustPolicies[i].SignatureVerification = relaxSignatureVerification(relaxedDocument.TrustPolicies[i].SignatureVerification)
+	}
+	verifierOptions.BlobTrustPolicy = &relaxedDocument
+	base, err := verifier.NewVerifierWithOptions(x509TrustStore, verifierOptions)
+	if err != nil {
+		return nil, err
+	}
+	return newHistoricalVerifier(base, x509TrustStore, at, verifierOptions.RevocationCodeSigningValidator, func(policyName string) (trustpolicy.SignatureVerification, []string, error) {
+		var statement *trustpolicy.BlobTrustPolicy
+		var err error
+		if policyName == "" {
+			statement, err = blobPolicyDocument.GetGlobalTrustPolicy()
+		} else {
+			statement, err = blobPolicyDocument.GetApplicableTrustPolicy(policyName)
+		}
+		if err != nil {
+			return trustpolicy.SignatureVerification{}, nil, err
+		}
+		return statement.SignatureVerification, statement.TrustStores, nil
+	}), nil
+}
+
+// relaxSignatureVerification returns a copy of signatureVerification which
+// only logs the failures of the authentic timestamp and expiry verifications
+// and skips the revocation check, so that they can be evaluated at a
+// different time afterwards.
+func relaxSignatureVerification(signatureVerification trustpolicy.SignatureVerification) trustpolicy.SignatureVerification {
+	if signatureVerification.VerificationLevel == trustpolicy.LevelSkip.Name {
+		// overriding is not allowed at the skip level
+		return signatureVerification
+	}
+	override := make(map[trustpolicy.ValidationType]trustpolicy.ValidationAction, len(signatureVerification.Override)+3)
+	maps.Copy(override, signatureVerification.Override)
+	override[trustpolicy.TypeAuthenticTimestamp] = trustpolicy.ActionLog
+	override[trustpolicy.TypeExpiry] = trustpolicy.ActionLog
+	override[trustpolicy.TypeRevocation] = trustpolicy.ActionSkip
+	signatureVerification.Override = override
+	return signatureVerification
+}
+
+// verifySkipper is implemented by the notation-go verifier to check if the
+// verification level is skip.
+type verifySkipper interface {
+	SkipVerify(ctx context.Context, opts notation.VerifierVerifyOptions) (bool, *trustpolicy.VerificationLevel, error)
+}
+
+// historicalVerifier re-evaluates the time dependent verifications relaxed
+// in the trust policy of the embedded Verifier at a historical time.
+type historicalVerifier struct {
+	Verifier
+
+	// at is the time of the evaluation.
+	at time.Time
+
+	// revocationValidator validates the revocation status of the code signing
+	// certificate chain.
+	revocationValidator revocation.Validator
+
+	// crlCache is the CRL cache to look up the revocation status of the
+	// certificates at the historical time, if set.
+	crlCache crlBundleReader
+
+	// ocspCache is the OCSP response cache to look up the revocation status
+	// of the certificates at the historical time, if set.
+	ocspCache ocspEntryReader
+
+	// x509TrustStore is the trust store to load the TSA root certificates
+	// for verifying the timestamp countersignature.
+	x509TrustStore notationgoTruststore.X509TrustStore
+
+	// trustPolicy returns the signature verification and the trust stores of
+	// the original trust policy statement applicable to the artifact
+	// reference, or of the blob trust policy statement with the given name.
+	trustPolicy func(policyKey string) (trustpolicy.SignatureVerification, []string, error)
+}
+
+// newHistoricalVerifier creates a historicalVerifier on top of base.
+func newHistoricalVerifier(base Verifier, x509TrustStore notationgoTruststore.X509TrustStore, at time.Time, revocationValidator revocation.Validator, trustPolicy func(string) (trustpolicy.SignatureVerification, []string, error)) *historicalVerifier {
+	v := &historicalVerifier{
+		Verifier:            base,
+		at:                  at,
+		revocationValidator: revocationValidator,
+		x509TrustStore:      x509TrustStore,
+		trustPolicy:         trustPolicy,
+	}
+	// the caches are optional for looking up the revocation status
+	if crlCache, err := clirev.NewCRLFileCache(); err == nil {
+		v.crlCache = crlCache
+	}
+	if ocspCache, err := clirev.NewOCSPFileCache(); err == nil {
+		v.ocspCache = ocspCache
+	}
+	return v
+}
+
+// SkipVerify validates whether the verification level is skip.
+func (v *historicalVerifier) SkipVerify(ctx context.Context, opts notation.VerifierVerifyOptions) (bool, *trustpolicy.VerificationLevel, error) {
+	if skipper, ok := v.Verifier.(verifySkipper); ok {
+		return skipper.SkipVerify(ctx, opts)
+	}
+	return false, nil, nil
+}
+
+// Verify verifies the signature associated with the target OCI artifact at
+// the historical time.
+func (v *historicalVerifier) Verify(ctx context.Context, desc ocispec.Descriptor, signature []byte, opts notation.VerifierVerifyOptions) (*notation.VerificationOutcome, error) {
+	outcome, err := v.Verifier.Verify(ctx, desc, signature, opts)
+	if err != nil {
+		return outcome, err
+	}
+	return v.evaluate(ctx, outcome, opts.ArtifactReference)
+}
+
+// VerifyBlob verifies the signature against the target blob at the
+// historical time.
+func (v *historicalVerifier) VerifyBlob(ctx context.Context, descGenFunc notation.BlobDescriptorGenerator, signature []byte, opts notation.BlobVerifierVerifyOptions) (*notation.VerificationOutcome, error) {
+	outcome, err := v.Verifier.VerifyBlob(ctx, descGenFunc, signature, opts)
+	if err != nil {
+		return outcome, err
+	}
+	return v.evaluate(ctx, outcome, opts.TrustPolicyName)
+}
+
+// evaluate performs the authentic timestamp, expiry and revocation
+// verifications of outcome at the historical time, with the verification
+// actions of the original trust policy statement selected by policyKey.
+func (v *historicalVerifier) evaluate(ctx context.Context, outcome *notation.VerificationOutcome, policyKey string) (*notation.VerificationOutcome, error) {
+	logger := log.GetLogger(ctx)
+
+	if outcome.EnvelopeContent == nil {
+		// nothing to evaluate at the skip level
+		return outcome, nil
+	}
+	signatureVerification, trustStores, err := v.trustPolicy(policyKey)
+	if err != nil {
+		outcome.Error = err
+		return outcome, err
+	}
+	level, err := signatureVerification.GetVerificationLevel()
+	if err != nil {
+		outcome.Error = err
+		return outcome, err
+	}
+	outcome.VerificationLevel = level
+
+	signerInfo := &outcome.EnvelopeContent.SignerInfo
+	checks := []struct {
+		validationType trustpolicy.ValidationType
+		verify         func() error
+	}{
+		{trustpolicy.TypeAuthenticTimestamp, func() error {
+			return v.verifyCertificateValidityAt(ctx, signerInfo, signatureVerification, trustStores)
+		}},
+		{trustpolicy.TypeExpiry, func() error { return verifyExpiryAt(signerInfo, v.at) }},
+		{trustpolicy.TypeRevocation, func() error { return v.verifyRevocation(ctx, signerInfo.CertificateChain) }},
+	}
+	for _, check := range checks {
+		action := level.Enforcement[check.validationType]
+		// drop the result of the relaxed verification at the current time
+		outcome.VerificationResults = slices.DeleteFunc(outcome.VerificationResults, func(r *notation.ValidationResult) bool {
+			return r.Type == check.validationType
+		})
+		if action == trustpolicy.ActionSkip {
+			continue
+		}
+		logger.Debugf("Validating %s at %s", check.validationType, v.at.Format(time.RFC3339))
+		validationResult := &notation.ValidationResult{
+			Type:   check.validationType,
+			Action: action,
+			Error:  check.verify(),
+		}
+		outcome.VerificationResults = append(outcome.VerificationResults, validationResult)
+		if validationResult.Error == nil {
+			continue
+		}
+		if action == trustpolicy.ActionEnforce {
+			outcome.Error = validationResult.Error
+			return outcome, outcome.Error
+		}
+		logger.Warnf("%s validation failed at %s with validation action set to %q. Failure reason: %v", check.validationType, v.at.Format(time.RFC3339), action, validationResult.Error)
+	}
+	return outcome, nil
+}
+
+// verifyCertificateValidityAt verifies that the signature had been created
+// at the historical time, and that every certificate in its certificate chain
+// was valid at the time of the signing.
+//
+// The time of the signing is the genTime of the timestamp countersignature,
+// if the signature is timestamped, the timestamp was already issued at the
+// historical time, and it is verified against the tsa trust stores in
+// trustStores. Otherwise, it is the historical time.
+func (v *historicalVerifier) verifyCertificateValidityAt(ctx context.Context, signerInfo *signature.SignerInfo, signatureVerification trustpolicy.SignatureVerification, trustStores []string) error {
+	logger := log.GetLogger(ctx)
+
+	if signingTime := signerInfo.SignedAttributes.SigningTime; v.at.Before(signingTime) {
+		return fmt.Errorf("signature was not yet created at %q, signing time is %q", v.at.Format(time.RFC1123Z), signingTime.Format(time.RFC1123Z))
+	}
+	if signerInfo.SignedAttributes.SigningScheme != signature.SigningSchemeX509 || len(signerInfo.UnsignedAttributes.TimestampSignature) == 0 {
+		return verifyCertificateValidityAt(signerInfo, v.at)
+	}
+	if signatureVerification.VerifyTimestamp == trustpolicy.OptionAfterCertExpiry && verifyCertificateValidityAt(signerInfo, v.at) == nil {
+		logger.Infof("Timestamp verification disabled: verifyTimestamp is set to %q and signing cert chain unexpired at %q", trustpolicy.OptionAfterCertExpiry, v.at.Format(time.RFC1123Z))
+		return nil
+	}
+	rootCAs, err := v.tsaRootCAs(ctx, trustStores)
+	if err != nil {
+		return err
+	}
+	if rootCAs == nil {
+		logger.Info("Timestamp verification disabled: no tsa trust store is configured in trust policy")
+		return verifyCertificateValidityAt(signerInfo, v.at)
+	}
+	timestamp, err := verifyTimestamp(ctx, signerInfo, rootCAs)
+	if err != nil {
+		return err
+	}
+	if !timestamp.BoundedBefore(v.at) {
+		logger.Infof("Timestamp %s was not yet issued at %q, checking the certificate validity at that time", timestamp.Format(time.RFC3339), v.at.Format(time.RFC1123Z))
+		return verifyCertificateValidityAt(signerInfo, v.at)
+	}
+	logger.Debugf("Checking the certificate validity at the timestamp %s", timestamp.Format(time.RFC3339))
+	for _, cert := range signerInfo.CertificateChain {
+		if !timestamp.BoundedAfter(cert.NotBefore) {
+			return fmt.Errorf("timestamp can be before certificate %q validity period, it will be valid from %q", cert.Subject, cert.NotBefore.Format(time.RFC1123Z))
+		}
+		if !timestamp.BoundedBefore(cert.NotAfter) {
+			return fmt.Errorf("timestamp can be after certificate %q validity period, it was expired at %q", cert.Subject, cert.NotAfter.Format(time.RFC1123Z))
+		}
+	}
+	return nil
+}
+
+// tsaRootCAs returns the root certificates in the tsa trust stores of
+// trustStores, or nil if no tsa trust store is configured.
+func (v *historicalVerifier) tsaRootCAs(ctx context.Context, trustStores []string) (*x509.CertPool, error) {
+	var rootCAs *x509.CertPool
+	for _, trustStore := range trustStores {
+		storeType, namedStore, found := strings.Cut(trustStore, ":")
+		if !found || notationgoTruststore.Type(storeType) != notationgoTruststore.TypeTSA {
+			continue
+		}
+		if v.x509TrustStore == nil {
+			return nil, errors.New("failed to load tsa trust store: trust store cannot be nil")
+		}
+		certs, err := v.x509TrustStore.GetCertificates(ctx, notationgoTruststore.TypeTSA, namedStore)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load tsa trust store with error: %w", err)
+		}
+		if rootCAs == nil {
+			rootCAs = x509.NewCertPool()
+		}
+		for _, cert := range certs {
+			rootCAs.AddCert(cert)
+		}
+	}
+	return rootCAs, nil
+}
+
+// verifyTimestamp verifies the timestamp countersignature of signerInfo
+// against rootCAs and returns the timestamp.
+func verifyTimestamp(ctx context.Context, signerInfo *signature.SignerInfo, rootCAs *x509.CertPool) (*tspclient.Timestamp, error) {
+	signedToken, err := tspclient.ParseSignedToken(signerInfo.UnsignedAttributes.TimestampSignature)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse timestamp countersignature with error: %w", err)
+	}
+	info, err := signedToken.Info()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the timestamp TSTInfo with error: %w", err)
+	}
+	timestamp, err := info.Validate(signerInfo.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get timestamp from timestamp countersignature with error: %w", err)
+	}
+	tsaCertChain, err := signedToken.Verify(ctx, x509.VerifyOptions{
+		CurrentTime: timestamp.Value,
+		Roots:       rootCAs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify the timestamp countersignature with error: %w", err)
+	}
+	if !timestamp.BoundedAfter(signerInfo.SignedAttributes.SigningTime) {
+		return nil, fmt.Errorf("timestamp %s is not bounded after the signing time %q", timestamp.Format(time.RFC3339), signerInfo.SignedAttributes.SigningTime.Format(time.RFC1123Z))
+	}
+	if err := nx509.ValidateTimestampingCertChain(tsaCertChain); err != nil {
+		return nil, fmt.Errorf("failed to validate the timestamping certificate chain with error: %w", err)
+	}
+	return timestamp, nil
+}
+
+// verifyCertificateValidityAt verifies that every certificate in the
+// certificate chain of signerInfo was valid at the time at.
+func verifyCertificateValidityAt(signerInfo *signature.SignerInfo, at time.Time) error {
+	for _, cert := range signerInfo.CertificateChain {
+		if at.Before(cert.NotBefore) {
+			return fmt.Errorf("certificate with subject %q was not yet valid at %q, it is valid from %q", cert.Subject, at.Format(time.RFC1123Z), cert.NotBefore.Format(time.RFC1123Z))
+		}
+		if at.After(cert.NotAfter) {
+			return fmt.Errorf("certificate with subject %q was expired at %q, it is valid until %q", cert.Subject, at.Format(time.RFC1123Z), cert.NotAfter.Format(time.RFC1123Z))
+		}
+	}
+	return nil
+}
+
+// verifyExpiryAt verifies that the signature was not expired at the time at.
+func verifyExpiryAt(signerInfo *signature.SignerInfo, at time.Time) error {
+	if expiry := signerInfo.SignedAttributes.Expiry; !expiry.IsZero() && !at.Before(expiry) {
+		return fmt.Errorf("digital signature has expired on %q", expiry.Format(time.RFC1123Z))
+	}
+	return nil
+}
+
+// verifyRevocation verifies that no certificate in certChain was revoked at
+// the historical time.
+//
+// A certificate is revoked at the historical time if its revocation date is
+// not after that time. The revocation date is read from the cached OCSP
+// responses and CRLs, which are used even if they have expired. If a
+// certificate is reported as revoked but the revocation date is unknown, it
+// is considered revoked. If the revocation status of a certificate is
+// unknown, for example in the offline revocation mode, the cached OCSP
+// responses and CRLs valid at the historical time are used.
+func (v *historicalVerifier) verifyRevocation(ctx context.Context, certChain []*x509.Certificate) error {
+	logger := log.GetLogger(ctx)
+
+	if v.revocationValidator == nil {
+		return fmt.Errorf("unable to check revocation status, code signing revocation validator cannot be nil")
+	}
+	certResults, err := v.revocationValidator.ValidateContext(ctx, revocation.ValidateContextOptions{
+		CertChain:            certChain,
+		AuthenticSigningTime: v.at,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to check revocation status, err: %w", err)
+	}
+	if len(certResults) != len(certChain) {
+		return fmt.Errorf("length of certificate revocation result %d does not match length of the certificate chain %d", len(certResults), len(certChain))
+	}
+	var unknownSubject string
+	for i := len(certResults) - 1; i >= 0; i-- {
+		certResult := certResults[i].Result
+		if certResult == result.ResultOK || certResult == result.ResultNonRevokable {
+			continue
+		}
+		cert := certChain[i]
+		var issuer *x509.Certificate
+		if i+1 < len(certChain) {
+			issuer = certChain[i+1]
+		}
+		status, revokedAt := v.cachedRevocationStatus(ctx, cert, issuer)
+		switch {
+		case status == result.ResultRevoked && revokedAt.After(v.at):
+			logger.Infof("Certificate with subject %q was revoked on %q, after the evaluation time", cert.Subject, revokedAt.Format(time.RFC1123Z))
+		case status == result.ResultRevoked:
+			return fmt.Errorf("signing certificate with subject %q was revoked on %q", cert.Subject, revokedAt.Format(time.RFC1123Z))
+		case status == result.ResultOK:
+			logger.Infof("Certificate with subject %q was not revoked at the evaluation time according to the cached revocation information", cert.Subject)
+		case certResult == result.ResultRevoked:
+			return fmt.Errorf("signing certificate with subject %q is revoked", cert.Subject)
+		default:
+			if unknownSubject == "" {
+				unknownSubject = cert.Subject.String()
+			}
+		}
+	}
+	if unknownSubject != "" {
+		return fmt.Errorf("signing certificate with subject %q revocation status is unknown", unknownSubject)
+	}
+	return nil
+}
+
+// crlBundleReader reads the cached CRL bundles, even if they have expired.
+type crlBundleReader interface {
+	Bundle(url string) (*corecrl.Bundle, error)
+}
+
+// ocspEntryReader reads the cached OCSP responses, even if they have expired.
+type ocspEntryReader interface {
+	Entry(key cliocsp.Key) (*cliocsp.Entry, error)
+}
+
+// cachedRevocationStatus looks up the revocation status of cert issued by
+// issuer at the historical time in the cached OCSP responses and CRLs.
+//
+// If cert is revoked in any cached OCSP response or CRL, ResultRevoked is
+// returned along with the revocation time. Otherwise, ResultOK is returned if
+// an OCSP response or a CRL valid at the historical time reports cert as not
+// revoked, or ResultUnknown if no such information is cached.
+func (v *historicalVerifier) cachedRevocationStatus(ctx context.Context, cert, issuer *x509.Certificate) (result.Result, time.Time) {
+	logger := log.GetLogger(ctx)
+
+	if issuer == nil {
+		return result.ResultUnknown, time.Time{}
+	}
+	status := result.ResultUnknown
+	if v.ocspCache != nil && len(cert.OCSPServer) > 0 {
+		resp, err := v.cachedOCSPResponse(cert, issuer)
+		switch {
+		case err != nil:
+			logger.Debugf("Cached OCSP response of certificate with subject %q is not available: %v", cert.Subject, err)
+		case resp.Status == ocsp.Revoked:
+			return result.ResultRevoked, resp.RevokedAt
+		case resp.Status == ocsp.Good && validAt(resp.ThisUpdate, resp.NextUpdate, v.at):
+			status = result.ResultOK
+		}
+	}
+	if v.crlCache != nil {
+		for _, url := range cert.CRLDistributionPoints {
+			bundle, err := v.crlCache.Bundle(url)
+			if err == nil {
+				err = bundle.BaseCRL.CheckSignatureFrom(issuer)
+			}
+			if err != nil {
+				logger.Debugf("Cached CRL %s of certificate with subject %q is not available: %v", url, cert.Subject, err)
+				continue
+			}
+			for _, crl := range []*x509.RevocationList{bundle.BaseCRL, bundle.DeltaCRL} {
+				if crl == nil {
+					continue
+				}
+				for _, entry := range crl.RevokedCertificateEntries {
+					if entry.SerialNumber.Cmp(cert.SerialNumber) == 0 {
+						return result.ResultRevoked, entry.RevocationTime
+					}
+				}
+			}
+			if validAt(bundle.BaseCRL.ThisUpdate, bundle.BaseCRL.NextUpdate, v.at) {
+				status = result.ResultOK
+			}
+		}
+	}
+	return status, time.Time{}
+}
+
+// cachedOCSPResponse returns the cached OCSP response of cert issued by
+// issuer, verified against issuer.
+func (v *historicalVerifier) cachedOCSPResponse(cert, issuer *x509.Certificate) (*ocsp.Response, error) {
+	// notation-core-go requests the revocation status with the SHA-1 hashes
+	// of the issuer
+	reqBytes, err := ocsp.CreateRequest(cert, issuer, &ocsp.RequestOptions{Hash: crypto.SHA1})
+	if err != nil {
+		return nil, err
+	}
+	req, err := ocsp.ParseRequest(reqBytes)
+	if err != nil {
+		return nil, err
+	}
+	entry, err := v.ocspCache.Entry(cliocsp.KeyFromRequest(req))
+	if err != nil {
+		return nil, err
+	}
+	return ocsp.ParseResponseForCert(entry.Response, cert, issuer)
+}
+
+// validAt returns true if the revocation information issued at thisUpdate
+// with the next update at nextUpdate is valid at the time at.
+func validAt(thisUpdate, nextUpdate, at time.Time) bool {
+	return !at.Before(thisUpdate) && (nextUpdate.IsZero() || !at.After(nextUpdate))
+}
@@ -0,0 +1,579 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verifier
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/notaryproject/notation-core-go/revocation"
+	corecrl "github.com/notaryproject/notation-core-go/revocation/crl"
+	"github.com/notaryproject/notation-core-go/revocation/purpose"
+	"github.com/notaryproject/notation-core-go/revocation/result"
+	"github.com/notaryproject/notation-core-go/signature"
+	"github.com/notaryproject/notation-core-go/signature/jws"
+	"github.com/notaryproject/notation-core-go/testhelper"
+	"github.com/notaryproject/notation-go"
+	"github.com/notaryproject/notation-go/dir"
+	"github.com/notaryproject/notation-go/verifier/trustpolicy"
+	"github.com/notaryproject/notation-go/verifier/truststore"
+	"github.com/notaryproject/notation/internal/envelope"
+	clirev "github.com/notaryproject/notation/internal/revocation"
+	cliocsp "github.com/notaryproject/notation/internal/revocation/ocsp"
+	"github.com/notaryproject/notation/internal/tsa"
+	"github.com/notaryproject/notation/internal/tsa/tsatest"
+	"github.com/notaryproject/tspclient-go"
+	"golang.org/x/crypto/ocsp"
+)
+
+const testCRLURL = "http://example.com/crl"
+
+type mockRevocationValidator struct {
+	result result.Result
+}
+
+func (v *mockRevocationValidator) Validate(certChain []*x509.Certificate, signingTime time.Time) ([]*result.CertRevocationResult, error) {
+	return v.ValidateContext(context.Background(), revocation.ValidateContextOptions{CertChain: certChain})
+}
+
+func (v *mockRevocationValidator) ValidateContext(_ context.Context, opts revocation.ValidateContextOptions) ([]*result.CertRevocationResult, error) {
+	results := make([]*result.CertRevocationResult, len(opts.CertChain))
+	for i := range results {
+		results[i] = &result.CertRevocationResult{Result: result.ResultOK}
+	}
+	results[0] = &result.CertRevocationResult{
+		Result: v.result,
+		ServerResults: []*result.ServerResult{{
+			Result:           v.result,
+			Server:           testCRLURL,
+			RevocationMethod: result.RevocationMethodCRL,
+		}},
+		RevocationMethod: result.RevocationMethodCRL,
+	}
+	return results, nil
+}
+
+type mockTrustStore map[truststore.Type][]*x509.Certificate
+
+func (s mockTrustStore) GetCertificates(_ context.Context, storeType truststore.Type, _ string) ([]*x509.Certificate, error) {
+	return s[storeType], nil
+}
+
+func TestGetHistoricalVerifier(t *testing.T) {
+	defer func(oldConfiDir, oldCacheDir string) {
+		dir.UserConfigDir = oldConfiDir
+		dir.UserCacheDir = oldCacheDir
+	}(dir.UserConfigDir, dir.UserCacheDir)
+	at := time.Now().Add(-time.Hour)
+
+	t.Run("oci success", func(t *testing.T) {
+		tempRoot := t.TempDir()
+		dir.UserConfigDir = tempRoot
+		dir.UserCacheDir = tempRoot
+		policyJson, _ := json.Marshal(dummyOCIPolicyDocument(false))
+		if err := os.WriteFile(filepath.Join(tempRoot, "trustpolicy.oci.json"), policyJson, 0600); err != nil {
+			t.Fatalf("write oci policy file failed. Error: %v", err)
+		}
+		if _, err := GetHistoricalVerifier(context.Background(), clirev.Options{}, at); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("blob success", func(t *testing.T) {
+		tempRoot := t.TempDir()
+		dir.UserConfigDir = tempRoot
+		dir.UserCacheDir = tempRoot
+		policyJson, _ := json.Marshal(dummyBlobPolicyDocument(false))
+		if err := os.WriteFile(filepath.Join(tempRoot, "trustpolicy.blob.json"), policyJson, 0600); err != nil {
+			t.Fatalf("write blob policy file failed. Error: %v", err)
+		}
+		if _, err := GetHistoricalBlobVerifier(context.Background(), clirev.Options{}, at); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("invalid oci trust policy", func(t *testing.T) {
+		tempRoot := t.TempDir()
+		dir.UserConfigDir = tempRoot
+		dir.UserCacheDir = tempRoot
+		policyJson, _ := json.Marshal(dummyOCIPolicyDocument(true))
+		if err := os.WriteFile(filepath.Join(tempRoot, "trustpolicy.oci.json"), policyJson, 0600); err != nil {
+			t.Fatalf("write oci policy file failed. Error: %v", err)
+		}
+		expectedErrMsg := "oci trust policy document has empty version, version must be specified"
+		if _, err := GetHistoricalVerifier(context.Background(), clirev.Options{}, at); err == nil || err.Error() != expectedErrMsg {
+			t.Fatalf("expected %s, but got %v", expectedErrMsg, err)
+		}
+	})
+}
+
+func TestRelaxSignatureVerification(t *testing.T) {
+	t.Run("override time dependent verifications", func(t *testing.T) {
+		original := trustpolicy.SignatureVerification{
+			VerificationLevel: trustpolicy.LevelStrict.Name,
+			Override: map[trustpolicy.ValidationType]trustpolicy.ValidationAction{
+				trustpolicy.TypeAuthenticity: trustpolicy.ActionLog,
+			},
+		}
+		relaxed := relaxSignatureVerification(original)
+		if len(original.Override) != 1 {
+			t.Fatalf("expected the original override to be kept, but got %v", original.Override)
+		}
+		level, err := relaxed.GetVerificationLevel()
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := map[trustpolicy.ValidationType]trustpolicy.ValidationAction{
+			trustpolicy.TypeIntegrity:          trustpolicy.ActionEnforce,
+			trustpolicy.TypeAuthenticity:       trustpolicy.ActionLog,
+			trustpolicy.TypeAuthenticTimestamp: trustpolicy.ActionLog,
+			trustpolicy.TypeExpiry:             trustpolicy.ActionLog,
+			trustpolicy.TypeRevocation:         trustpolicy.ActionSkip,
+		}
+		for validationType, action := range expected {
+			if level.Enforcement[validationType] != action {
+				t.Fatalf("expected %s to be %q, but got %q", validationType, action, level.Enforcement[validationType])
+			}
+		}
+	})
+
+	t.Run("skip level", func(t *testing.T) {
+		original := trustpolicy.SignatureVerification{VerificationLevel: trustpolicy.LevelSkip.Name}
+		relaxed := relaxSignatureVerification(original)
+		if len(relaxed.Override) != 0 {
+			t.Fatalf("expected no override at the skip level, but got %v", relaxed.Override)
+		}
+	})
+}
+
+func TestHistoricalVerifierEvaluate(t *testing.T) {
+	leaf := testhelper.GetRSALeafCertificate().Cert
+	certChain := []*x509.Certificate{leaf, testhelper.GetRSARootCertificate().Cert}
+	at := leaf.NotBefore.Add(time.Hour)
+	newOutcome := func(signingTime, expiry time.Time) *notation.VerificationOutcome {
+		return &notation.VerificationOutcome{
+			EnvelopeContent: &signature.EnvelopeContent{
+				SignerInfo: signature.SignerInfo{
+					SignedAttributes: signature.SignedAttributes{
+						SigningScheme: signature.SigningSchemeX509,
+						SigningTime:   signingTime,
+						Expiry:        expiry,
+					},
+					CertificateChain: certChain,
+				},
+			},
+			VerificationResults: []*notation.ValidationResult{
+				{Type: trustpolicy.TypeIntegrity, Action: trustpolicy.ActionEnforce},
+				{Type: trustpolicy.TypeAuthenticity, Action: trustpolicy.ActionEnforce},
+				{Type: trustpolicy.TypeAuthenticTimestamp, Action: trustpolicy.ActionLog, Error: errors.New("certificate expired now")},
+				{Type: trustpolicy.TypeExpiry, Action: trustpolicy.ActionLog, Error: errors.New("signature expired now")},
+			},
+		}
+	}
+	newVerifier := func(level string, revocationResult result.Result) *historicalVerifier {
+		return &historicalVerifier{
+			at:                  at,
+			revocationValidator: &mockRevocationValidator{result: revocationResult},
+			trustPolicy: func(string) (trustpolicy.SignatureVerification, []string, error) {
+				return trustpolicy.SignatureVerification{VerificationLevel: level}, nil, nil
+			},
+		}
+	}
+	t.Run("valid at the time", func(t *testing.T) {
+		v := newVerifier(trustpolicy.LevelStrict.Name, result.ResultOK)
+		outcome, err := v.evaluate(context.Background(), newOutcome(at.Add(-time.Minute), at.Add(time.Minute)), "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if outcome.VerificationLevel != trustpolicy.LevelStrict {
+			t.Fatalf("expected the original verification level, but got %v", outcome.VerificationLevel)
+		}
+		if len(outcome.VerificationResults) != 5 {
+			t.Fatalf("expected 5 verification results, but got %d", len(outcome.VerificationResults))
+		}
+		for _, r := range outcome.VerificationResults {
+			if r.Error != nil {
+				t.Fatalf("expected %s to succeed, but got %v", r.Type, r.Error)
+			}
+			if r.Action != trustpolicy.ActionEnforce {
+				t.Fatalf("expected %s to be enforced, but got %q", r.Type, r.Action)
+			}
+		}
+	})
+
+	t.Run("certificate expired at the time", func(t *testing.T) {
+		v := newVerifier(trustpolicy.LevelStrict.Name, result.ResultOK)
+		v.at = leaf.NotAfter.Add(time.Hour)
+		_, err := v.evaluate(context.Background(), newOutcome(at, time.Time{}), "")
+		if err == nil || !strings.Contains(err.Error(), "was expired at") {
+			t.Fatalf("expected certificate expired error, but got %v", err)
+		}
+	})
+
+	t.Run("certificate not yet valid at the time", func(t *testing.T) {
+		v := newVerifier(trustpolicy.LevelStrict.Name, result.ResultOK)
+		v.at = leaf.NotBefore.Add(-time.Hour)
+		_, err := v.evaluate(context.Background(), newOutcome(time.Time{}, time.Time{}), "")
+		if err == nil || !strings.Contains(err.Error(), "was not yet valid at") {
+			t.Fatalf("expected certificate not yet valid error, but got %v", err)
+		}
+	})
+
+	t.Run("signature not yet created at the time", func(t *testing.T) {
+		v := newVerifier(trustpolicy.LevelStrict.Name, result.ResultOK)
+		_, err := v.evaluate(context.Background(), newOutcome(at.Add(time.Minute), time.Time{}), "")
+		if err == nil || !strings.Contains(err.Error(), "signature was not yet created") {
+			t.Fatalf("expected signature not yet created error, but got %v", err)
+		}
+	})
+
+	t.Run("signature expired at the time", func(t *testing.T) {
+		v := newVerifier(trustpolicy.LevelStrict.Name, result.ResultOK)
+		_, err := v.evaluate(context.Background(), newOutcome(at.Add(-time.Minute), at), "")
+		if err == nil || !strings.Contains(err.Error(), "digital signature has expired") {
+			t.Fatalf("expected signature expired error, but got %v", err)
+		}
+	})
+
+	t.Run("logged failures at permissive level", func(t *testing.T) {
+		v := newVerifier(trustpolicy.LevelPermissive.Name, result.ResultOK)
+		outcome, err := v.evaluate(context.Background(), newOutcome(at.Add(-time.Minute), at), "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		var failed []trustpolicy.ValidationType
+		for _, r := range outcome.VerificationResults {
+			if r.Error != nil {
+				failed = append(failed, r.Type)
+			}
+		}
+		if len(failed) != 1 || failed[0] != trustpolicy.TypeExpiry {
+			t.Fatalf("expected only the expiry verification to fail, but got %v", failed)
+		}
+	})
+
+	t.Run("unknown revocation status", func(t *testing.T) {
+		v := newVerifier(trustpolicy.LevelStrict.Name, result.ResultUnknown)
+		_, err := v.evaluate(context.Background(), newOutcome(at, time.Time{}), "")
+		if err == nil || !strings.Contains(err.Error(), "revocation status is unknown") {
+			t.Fatalf("expected unknown revocation status error, but got %v", err)
+		}
+	})
+
+	t.Run("revocation skipped", func(t *testing.T) {
+		v := newVerifier(trustpolicy.LevelStrict.Name, result.ResultRevoked)
+		v.trustPolicy = func(string) (trustpolicy.SignatureVerification, []string, error) {
+			return trustpolicy.SignatureVerification{
+				VerificationLevel: trustpolicy.LevelStrict.Name,
+				Override: map[trustpolicy.ValidationType]trustpolicy.ValidationAction{
+					trustpolicy.TypeRevocation: trustpolicy.ActionSkip,
+				},
+			}, nil, nil
+		}
+		outcome, err := v.evaluate(context.Background(), newOutcome(at, time.Time{}), "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, r := range outcome.VerificationResults {
+			if r.Type == trustpolicy.TypeRevocation {
+				t.Fatal("expected revocation check to be skipped")
+			}
+		}
+	})
+
+	t.Run("no applicable trust policy", func(t *testing.T) {
+		v := newVerifier(trustpolicy.LevelStrict.Name, result.ResultOK)
+		v.trustPolicy = func(string) (trustpolicy.SignatureVerification, []string, error) {
+			return trustpolicy.SignatureVerification{}, nil, errors.New("no applicable trust policy")
+		}
+		outcome, err := v.evaluate(context.Background(), newOutcome(at, time.Time{}), "")
+		if err == nil || outcome.Error == nil {
+			t.Fatalf("expected error for no applicable trust policy, but got %v", err)
+		}
+	})
+
+	t.Run("skip level", func(t *testing.T) {
+		v := newVerifier(trustpolicy.LevelSkip.Name, result.ResultOK)
+		outcome := &notation.VerificationOutcome{VerificationLevel: trustpolicy.LevelSkip}
+		if _, err := v.evaluate(context.Background(), outcome, ""); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func TestHistoricalVerifierRevocation(t *testing.T) {
+	defer func(oldCacheDir string) {
+		dir.UserCacheDir = oldCacheDir
+	}(dir.UserCacheDir)
+	ctx := context.Background()
+	chain := testhelper.GetRevokableRSAChainWithRevocations(2, true, true)
+	leaf, issuer := chain[0], chain[1]
+	certChain := []*x509.Certificate{leaf.Cert, issuer.Cert}
+	now := time.Now()
+	at := now.Add(-48 * time.Hour)
+
+	newOCSPCache := func(t *testing.T, revokedAt time.Time) *cliocsp.FileCache {
+		t.Helper()
+		cache, err := cliocsp.NewFileCache(t.TempDir())
+		if err != nil {
+			t.Fatal(err)
+		}
+		response, err := ocsp.CreateResponse(issuer.Cert, issuer.Cert, ocsp.Response{
+			Status:       ocsp.Revoked,
+			SerialNumber: leaf.Cert.SerialNumber,
+			RevokedAt:    revokedAt,
+			ThisUpdate:   now.Add(-time.Hour),
+			NextUpdate:   now.Add(time.Hour),
+		}, issuer.PrivateKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		reqBytes, err := ocsp.CreateRequest(leaf.Cert, issuer.Cert, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req, err := ocsp.ParseRequest(reqBytes)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := cache.Set(cliocsp.KeyFromRequest(req), leaf.Cert.OCSPServer[0], response, issuer.Cert); err != nil {
+			t.Fatal(err)
+		}
+		return cache
+	}
+	newCRLBundle := func(t *testing.T, thisUpdate, nextUpdate time.Time, revoked ...x509.RevocationListEntry) *corecrl.Bundle {
+		t.Helper()
+		crlBytes, err := x509.CreateRevocationList(rand.Reader, &x509.RevocationList{
+			Number:                    big.NewInt(1),
+			ThisUpdate:                thisUpdate,
+			NextUpdate:                nextUpdate,
+			RevokedCertificateEntries: revoked,
+		}, issuer.Cert, issuer.PrivateKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		baseCRL, err := x509.ParseRevocationList(crlBytes)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &corecrl.Bundle{BaseCRL: baseCRL}
+	}
+
+	t.Run("ocsp revoked after the time", func(t *testing.T) {
+		v := &historicalVerifier{
+			at:                  at,
+			revocationValidator: &mockRevocationValidator{result: result.ResultRevoked},
+			ocspCache:           newOCSPCache(t, at.Add(time.Hour)),
+		}
+		if err := v.verifyRevocation(ctx, certChain); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("ocsp revoked before the time", func(t *testing.T) {
+		v := &historicalVerifier{
+			at:                  at,
+			revocationValidator: &mockRevocationValidator{result: result.ResultRevoked},
+			ocspCache:           newOCSPCache(t, at.Add(-time.Hour)),
+		}
+		err := v.verifyRevocation(ctx, certChain)
+		if err == nil || !strings.Contains(err.Error(), "was revoked on") {
+			t.Fatalf("expected revoked error, but got %v", err)
+		}
+	})
+
+	t.Run("revoked with unknown revocation time", func(t *testing.T) {
+		v := &historicalVerifier{
+			at:                  at,
+			revocationValidator: &mockRevocationValidator{result: result.ResultRevoked},
+		}
+		err := v.verifyRevocation(ctx, certChain)
+		if err == nil || !strings.Contains(err.Error(), "is revoked") {
+			t.Fatalf("expected revoked error, but got %v", err)
+		}
+	})
+
+	// the CRLs have expired now, so that they are not used by the offline
+	// revocation validator
+	tests := []struct {
+		name    string
+		bundle  func(t *testing.T) *corecrl.Bundle
+		wantErr string
+	}{
+		{
+			name: "expired crl valid at the time",
+			bundle: func(t *testing.T) *corecrl.Bundle {
+				return newCRLBundle(t, at.Add(-time.Hour), at.Add(time.Hour))
+			},
+		},
+		{
+			name: "expired crl revoked after the time",
+			bundle: func(t *testing.T) *corecrl.Bundle {
+				return newCRLBundle(t, at.Add(2*time.Hour), at.Add(3*time.Hour), x509.RevocationListEntry{
+					SerialNumber:   leaf.Cert.SerialNumber,
+					RevocationTime: at.Add(time.Hour),
+				})
+			},
+		},
+		{
+			name: "expired crl revoked before the time",
+			bundle: func(t *testing.T) *corecrl.Bundle {
+				return newCRLBundle(t, at.Add(-time.Hour), at.Add(time.Hour), x509.RevocationListEntry{
+					SerialNumber:   leaf.Cert.SerialNumber,
+					RevocationTime: at.Add(-2 * time.Hour),
+				})
+			},
+			wantErr: "was revoked on",
+		},
+		{
+			name: "expired crl issued after the time",
+			bundle: func(t *testing.T) *corecrl.Bundle {
+				return newCRLBundle(t, at.Add(time.Hour), at.Add(2*time.Hour))
+			},
+			wantErr: "revocation status is unknown",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir.UserCacheDir = t.TempDir()
+			crlCache, err := clirev.NewCRLFileCache()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := crlCache.Set(ctx, leaf.Cert.CRLDistributionPoints[0], tt.bundle(t)); err != nil {
+				t.Fatal(err)
+			}
+			validator, err := clirev.NewRevocationValidatorWithOptions(ctx, purpose.CodeSigning, clirev.Options{Mode: clirev.ModeOffline})
+			if err != nil {
+				t.Fatal(err)
+			}
+			v := &historicalVerifier{
+				at:                  at,
+				revocationValidator: validator,
+				crlCache:            crlCache,
+			}
+			err = v.verifyRevocation(ctx, certChain)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, but got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestHistoricalVerifierEvaluateTimestamped(t *testing.T) {
+	leaf := testhelper.GetRSALeafCertificate()
+	certChain := []*x509.Certificate{leaf.Cert, testhelper.GetRSARootCertificate().Cert}
+	signer, err := signature.NewLocalSigner(certChain, leaf.PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tsaKey, tsaCertChain := tsatest.GenerateCertChain(t)
+	server, err := tsa.New(tsaKey, tsaCertChain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+	timestamper, err := tspclient.NewHTTPTimestamper(nil, ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tsaRootCAs := x509.NewCertPool()
+	tsaRootCAs.AddCert(tsaCertChain[1])
+
+	// sign and timestamp an envelope within the validity of the leaf
+	// certificate
+	env, err := signature.NewEnvelope(jws.MediaTypeEnvelope)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sigBlob, err := env.Sign(&signature.SignRequest{
+		Payload: signature.Payload{
+			ContentType: envelope.MediaTypePayloadV1,
+			Content:     []byte(`{"targetArtifact":{}}`),
+		},
+		Signer:        signer,
+		SigningTime:   leaf.Cert.NotBefore,
+		SigningScheme: signature.SigningSchemeX509,
+		Timestamper:   timestamper,
+		TSARootCAs:    tsaRootCAs,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sigEnv, err := signature.ParseEnvelope(jws.MediaTypeEnvelope, sigBlob)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newOutcome := func() *notation.VerificationOutcome {
+		content, err := sigEnv.Verify()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(content.SignerInfo.UnsignedAttributes.TimestampSignature) == 0 {
+			t.Fatal("expected a timestamped envelope")
+		}
+		return &notation.VerificationOutcome{EnvelopeContent: content}
+	}
+	newVerifier := func(trustStore mockTrustStore, trustStores ...string) *historicalVerifier {
+		return &historicalVerifier{
+			// the leaf certificate was expired at the time
+			at:                  leaf.Cert.NotAfter.Add(time.Hour),
+			revocationValidator: &mockRevocationValidator{result: result.ResultOK},
+			x509TrustStore:      trustStore,
+			trustPolicy: func(string) (trustpolicy.SignatureVerification, []string, error) {
+				return trustpolicy.SignatureVerification{VerificationLevel: trustpolicy.LevelStrict.Name}, trustStores, nil
+			},
+		}
+	}
+
+	t.Run("valid at the timestamp", func(t *testing.T) {
+		v := newVerifier(mockTrustStore{truststore.TypeTSA: {tsaCertChain[1]}}, "ca:test", "tsa:test")
+		if _, err := v.evaluate(context.Background(), newOutcome(), ""); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("untrusted timestamp", func(t *testing.T) {
+		v := newVerifier(mockTrustStore{truststore.TypeTSA: {testhelper.GetRSARootCertificate().Cert}}, "ca:test", "tsa:test")
+		_, err := v.evaluate(context.Background(), newOutcome(), "")
+		if err == nil || !strings.Contains(err.Error(), "failed to verify the timestamp countersignature") {
+			t.Fatalf("expected timestamp verification error, but got %v", err)
+		}
+	})
+
+	t.Run("no tsa trust store", func(t *testing.T) {
+		v := newVerifier(mockTrustStore{}, "ca:test")
+		_, err := v.evaluate(context.Background(), newOutcome(), "")
+		if err == nil || !strings.Contains(err.Error(), "was expired at") {
+			t.Fatalf("expected certificate expired error, but got %v", err)
+		}
+	})
+}
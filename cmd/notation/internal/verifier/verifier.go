@@ -22,8 +22,8 @@ import (
 	"github.com/notaryproject/notation-go/plugin"
 	"github.com/notaryproject/notation-go/verifier"
 	"github.com/notaryproject/notation-go/verifier/trustpolicy"
-	"github.com/notaryproject/notation-go/verifier/truststore"
 
+	"github.com/notaryproject/notation/cmd/notation/internal/truststore"
 	clirev "github.com/notaryproject/notation/internal/revocation"
 )
 
@@ -33,9 +33,10 @@ type Verifier interface {
 	notation.Verifier
 }
 
-// GetVerifier creates a Verifier.
-func GetVerifier(ctx context.Context) (Verifier, error) {
-	verifierOptions, err := newVerifierOptions(ctx)
+// GetVerifier creates a Verifier with revocation checks configured by
+// revocationOpts.
+func GetVerifier(ctx context.Context, revocationOpts clirev.Options) (Verifier, error) {
+	verifierOptions, err := newVerifierOptions(ctx, revocationOpts)
 	if err != nil {
 		return nil, err
 	}
@@ -50,9 +51,10 @@ func GetVerifier(ctx context.Context) (Verifier, error) {
 	return verifier.NewVerifierWithOptions(x509TrustStore, verifierOptions)
 }
 
-// GetBlobVerifier creates a BlobVerifier.
-func GetBlobVerifier(ctx context.Context) (Verifier, error) {
-	verifierOptions, err := newVerifierOptions(ctx)
+// GetBlobVerifier creates a BlobVerifier with revocation checks configured by
+// revocationOpts.
+func GetBlobVerifier(ctx context.Context, revocationOpts clirev.Options) (Verifier, error) {
+	verifierOptions, err := newVerifierOptions(ctx, revocationOpts)
 	if err != nil {
 		return nil, err
 	}
@@ -68,12 +70,12 @@ func GetBlobVerifier(ctx context.Context) (Verifier, error) {
 }
 
 // newVerifierOptions creates a verifier.VerifierOptions.
-func newVerifierOptions(ctx context.Context) (verifier.VerifierOptions, error) {
-	revocationCodeSigningValidator, err := clirev.NewRevocationValidator(ctx, purpose.CodeSigning)
+func newVerifierOptions(ctx context.Context, revocationOpts clirev.Options) (verifier.VerifierOptions, error) {
+	revocationCodeSigningValidator, err := clirev.NewRevocationValidatorWithOptions(ctx, purpose.CodeSigning, revocationOpts)
 	if err != nil {
 		return verifier.VerifierOptions{}, err
 	}
-	revocationTimestampingValidator, err := clirev.NewRevocationValidator(ctx, purpose.Timestamping)
+	revocationTimestampingValidator, err := clirev.NewRevocationValidatorWithOptions(ctx, purpose.Timestamping, revocationOpts)
 	if err != nil {
 		return verifier.VerifierOptions{}, err
 	}
@@ -14,18 +14,30 @@
 package main
 
 import (
+	"bufio"
 	"context"
+	"crypto/x509"
+	"encoding/pem"
 	"errors"
 	"fmt"
 	"os"
 
+	"github.com/notaryproject/notation-core-go/signature"
+	corex509 "github.com/notaryproject/notation-core-go/x509"
 	"github.com/notaryproject/notation-go/config"
-
+	"github.com/notaryproject/notation-go/dir"
 	"github.com/notaryproject/notation-go/log"
+	"github.com/notaryproject/notation-go/plugin"
+	"github.com/notaryproject/notation-go/plugin/proto"
+	"github.com/notaryproject/notation/cmd/notation/internal/display"
+	"github.com/notaryproject/notation/cmd/notation/internal/option"
+	"github.com/notaryproject/notation/cmd/notation/internal/truststore"
 	"github.com/notaryproject/notation/internal/cmd"
-	"github.com/notaryproject/notation/internal/ioutil"
+	"github.com/notaryproject/notation/internal/osutil"
+	nx509 "github.com/notaryproject/notation/internal/x509"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+	"golang.org/x/term"
 )
 
 var (
@@ -58,6 +70,26 @@ type keyDeleteOpts struct {
 	names []string
 }
 
+type keyListOpts struct {
+	option.Common
+	option.Format
+}
+
+type keyShowOpts struct {
+	cmd.LoggingFlagOpts
+	option.Common
+	option.Format
+	name string
+}
+
+type keyImportOpts struct {
+	cmd.LoggingFlagOpts
+	name          string
+	pkcs12Path    string
+	passwordStdin bool
+	isDefault     bool
+}
+
 func keyCommand() *cobra.Command {
 	command := &cobra.Command{
 		Use:   "key",
@@ -70,14 +102,23 @@ Example - Add a key to signing key list:
 Example - List keys used for signing:
   notation key ls
 
+Example - Test the default signing key:
+  notation key test
+
 Example - Update the default signing key:
   notation key set --default <key_name>
 
 Example - Delete the key from signing key list:
   notation key delete <key_name>...
+
+Example - Import a signing key and certificate chain from a PKCS#12 file:
+  notation key import --pkcs12 <file_path> <key_name>
+
+Example - Show the details of a signing key:
+  notation key show <key_name>
 `,
 	}
-	command.AddCommand(keyAddCommand(nil), keyUpdateCommand(nil), keyListCommand(), keyDeleteCommand(nil))
+	command.AddCommand(keyAddCommand(nil), keyUpdateCommand(nil), keyListCommand(nil), keyDeleteCommand(nil), keyImportCommand(nil), keyShowCommand(nil), keyTestCommand(nil))
 
 	return command
 }
@@ -138,15 +179,36 @@ func keyUpdateCommand(opts *keyUpdateOpts) *cobra.Command {
 	return command
 }
 
-func keyListCommand() *cobra.Command {
-	return &cobra.Command{
+func keyListCommand(opts *keyListOpts) *cobra.Command {
+	if opts == nil {
+		opts = &keyListOpts{}
+	}
+	command := &cobra.Command{
 		Use:     "list [flags]",
 		Aliases: []string{"ls"},
 		Short:   "List keys used for signing",
+		Long: `List keys used for signing
+
+Example - List keys used for signing:
+  notation key ls
+
+Example - List keys used for signing in JSON format:
+  notation key ls --output json
+`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.Format.Parse(cmd); err != nil {
+				return err
+			}
+			opts.Common.Parse(cmd)
+			return nil
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return listKeys()
+			return listKeys(opts)
 		},
 	}
+	opts.Format.ApplyFlags(command.Flags(), option.FormatTypeText, option.FormatTypeJSON)
+
+	return command
 }
 
 func keyDeleteCommand(opts *keyDeleteOpts) *cobra.Command {
@@ -173,6 +235,84 @@ func keyDeleteCommand(opts *keyDeleteOpts) *cobra.Command {
 	return command
 }
 
+func keyShowCommand(opts *keyShowOpts) *cobra.Command {
+	if opts == nil {
+		opts = &keyShowOpts{}
+	}
+	command := &cobra.Command{
+		Use:   "show [flags] <key_name>",
+		Short: "Show the details of a signing key",
+		Long: `Show the details of a signing key
+
+For a local key, the key spec and the certificate chain are displayed. For a key managed by a plugin, the key spec is retrieved from the plugin.
+
+Example - Show the details of a signing key:
+  notation key show wabbit-networks
+
+Example - Show the details of a signing key in JSON format:
+  notation key show --output json wabbit-networks
+`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return errors.New("either missing key name or unnecessary parameters passed")
+			}
+			opts.name = args[0]
+			return nil
+		},
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.Format.Parse(cmd); err != nil {
+				return err
+			}
+			opts.Common.Parse(cmd)
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return showKey(cmd.Context(), opts)
+		},
+	}
+	opts.LoggingFlagOpts.ApplyFlags(command.Flags())
+	opts.Format.ApplyFlags(command.Flags(), option.FormatTypeTree, option.FormatTypeJSON)
+
+	return command
+}
+
+func keyImportCommand(opts *keyImportOpts) *cobra.Command {
+	if opts == nil {
+		opts = &keyImportOpts{}
+	}
+	command := &cobra.Command{
+		Use:   "import --pkcs12 <file_path> [flags] <key_name>",
+		Short: "Import a local signing key and its certificate chain",
+		Long: `Import a local signing key and its certificate chain
+
+The private key and the certificate chain are extracted from the PKCS#12 file and stored in the Notation configuration directory. The leaf certificate must have the code signing extended key usage.
+
+Example - Import a signing key from a PKCS#12 file, prompting for the password:
+  notation key import --pkcs12 wabbit-networks.p12 wabbit-networks
+
+Example - Import a signing key from a PKCS#12 file with the password read from stdin, and mark it as default:
+  cat password.txt | notation key import --pkcs12 wabbit-networks.pfx --password-stdin --default wabbit-networks
+`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return errors.New("either missing key name or unnecessary parameters passed")
+			}
+			opts.name = args[0]
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return importKey(cmd.Context(), opts)
+		},
+	}
+	opts.LoggingFlagOpts.ApplyFlags(command.Flags())
+	command.Flags().StringVar(&opts.pkcs12Path, "pkcs12", "", "filepath of the PKCS#12 (.p12/.pfx) file")
+	command.Flags().BoolVar(&opts.passwordStdin, "password-stdin", false, "take the PKCS#12 password from stdin")
+	command.MarkFlagRequired("pkcs12")
+	setKeyDefaultFlag(command.Flags(), &opts.isDefault)
+
+	return command
+}
+
 func addKey(ctx context.Context, opts *keyAddOpts) error {
 	// set log level
 	ctx = opts.LoggingFlagOpts.InitializeLogger(ctx)
@@ -222,7 +362,12 @@ func updateKey(ctx context.Context, opts *keyUpdateOpts) error {
 	return nil
 }
 
-func listKeys() error {
+func listKeys(opts *keyListOpts) error {
+	displayHandler, err := display.NewKeyListHandler(opts.Printer, opts.Format)
+	if err != nil {
+		return err
+	}
+
 	// core process
 	signingKeys, err := config.LoadSigningKeys()
 	if err != nil {
@@ -230,7 +375,8 @@ func listKeys() error {
 	}
 
 	// write out
-	return ioutil.PrintKeyMap(os.Stdout, signingKeys.Default, signingKeys.Keys)
+	displayHandler.OnKeysLoaded(signingKeys.Keys, signingKeys.Default)
+	return displayHandler.Render()
 }
 
 func deleteKeys(ctx context.Context, opts *keyDeleteOpts) error {
@@ -276,3 +422,149 @@ func deleteKeys(ctx context.Context, opts *keyDeleteOpts) error {
 	}
 	return nil
 }
+
+func showKey(ctx context.Context, opts *keyShowOpts) error {
+	// set log level
+	ctx = opts.LoggingFlagOpts.InitializeLogger(ctx)
+
+	displayHandler, err := display.NewKeyShowHandler(opts.Printer, opts.Format)
+	if err != nil {
+		return err
+	}
+
+	// core process
+	signingKeys, err := config.LoadSigningKeys()
+	if err != nil {
+		return err
+	}
+	key, err := signingKeys.Get(opts.name)
+	if err != nil {
+		return err
+	}
+	isDefault := signingKeys.Default != nil && *signingKeys.Default == key.Name
+	switch {
+	case key.X509KeyPair != nil:
+		certChain, err := corex509.ReadCertificateFile(key.CertificatePath)
+		if err != nil {
+			return err
+		}
+		if len(certChain) == 0 {
+			return fmt.Errorf("no certificate found in %s", key.CertificatePath)
+		}
+		keySpec, err := signature.ExtractKeySpec(certChain[0])
+		if err != nil {
+			return err
+		}
+		encodedKeySpec, err := proto.EncodeKeySpec(keySpec)
+		if err != nil {
+			return err
+		}
+		displayHandler.OnLocalKeyLoaded(key, isDefault, string(encodedKeySpec), certChain)
+	case key.ExternalKey != nil:
+		mgr := plugin.NewCLIManager(dir.PluginFS())
+		signPlugin, err := mgr.Get(ctx, key.PluginName)
+		if err != nil {
+			return err
+		}
+		resp, err := signPlugin.DescribeKey(ctx, &proto.DescribeKeyRequest{
+			KeyID:        key.ID,
+			PluginConfig: key.PluginConfig,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to describe key %q with plugin %q: %w", key.Name, key.PluginName, err)
+		}
+		displayHandler.OnPluginKeyDescribed(key, isDefault, string(resp.KeySpec))
+	default:
+		return fmt.Errorf("signing key %q is neither a local key nor a plugin key", key.Name)
+	}
+
+	// write out
+	return displayHandler.Render()
+}
+
+func importKey(ctx context.Context, opts *keyImportOpts) error {
+	// set log level
+	ctx = opts.LoggingFlagOpts.InitializeLogger(ctx)
+	logger := log.GetLogger(ctx)
+
+	// initialize
+	name := opts.name
+	if !truststore.IsValidFileName(name) {
+		return errors.New("key name needs to follow [a-zA-Z0-9_.-]+ format")
+	}
+	data, err := os.ReadFile(opts.pkcs12Path)
+	if err != nil {
+		return err
+	}
+	var password string
+	if opts.passwordStdin {
+		password, err = readLine(os.Stdin)
+	} else if term.IsTerminal(int(os.Stdin.Fd())) {
+		password, err = readPasswordFromPrompt(bufio.NewReader(os.Stdin), false)
+	}
+	if err != nil {
+		return err
+	}
+
+	// extract and validate the key and the certificate chain
+	key, certChain, err := nx509.ParsePKCS12(data, password)
+	if err != nil {
+		return err
+	}
+	logger.Infof("Extracted %d certificate(s) from %s", len(certChain), opts.pkcs12Path)
+	if err := nx509.ValidateCodeSigningEKU(certChain[0]); err != nil {
+		return err
+	}
+	if err := corex509.ValidateCodeSigningCertChain(certChain, nil); err != nil {
+		return fmt.Errorf("invalid certificate chain in %s: %w", opts.pkcs12Path, err)
+	}
+	keyBytes, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return err
+	}
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyBytes})
+	var certPEM []byte
+	for _, cert := range certChain {
+		certPEM = append(certPEM, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})...)
+	}
+
+	// core process
+	relativeKeyPath, relativeCertPath := dir.LocalKeyPath(name)
+	configFS := dir.ConfigFS()
+	keyPath, err := configFS.SysPath(relativeKeyPath)
+	if err != nil {
+		return err
+	}
+	certPath, err := configFS.SysPath(relativeCertPath)
+	if err != nil {
+		return err
+	}
+	exec := func(s *config.SigningKeys) error {
+		// check the key list before writing any file so that an existing key
+		// is left untouched
+		if _, err := s.Get(name); err == nil {
+			return fmt.Errorf("signing key with name %q already exists", name)
+		}
+		if err := osutil.WriteFileWithPermission(keyPath, keyPEM, 0600, false); err != nil {
+			return fmt.Errorf("failed to write key file: %w", err)
+		}
+		logger.Infof("Wrote key: %s", keyPath)
+		if err := osutil.WriteFileWithPermission(certPath, certPEM, 0644, false); err != nil {
+			os.Remove(keyPath)
+			return fmt.Errorf("failed to write certificate file: %w", err)
+		}
+		logger.Infof("Wrote certificate chain: %s", certPath)
+		return s.Add(name, keyPath, certPath, opts.isDefault)
+	}
+	if err := config.LoadExecSaveSigningKeys(exec); err != nil {
+		return err
+	}
+
+	// write out
+	if opts.isDefault {
+		fmt.Printf("%s: marked as default\n", name)
+	} else {
+		fmt.Println(name)
+	}
+	return nil
+}
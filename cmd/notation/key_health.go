@@ -0,0 +1,303 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/notaryproject/notation-core-go/signature"
+	corex509 "github.com/notaryproject/notation-core-go/x509"
+	"github.com/notaryproject/notation-go"
+	"github.com/notaryproject/notation-go/plugin/proto"
+	"github.com/notaryproject/notation/cmd/notation/internal/cmdutil"
+	"github.com/notaryproject/notation/cmd/notation/internal/signer"
+	"github.com/notaryproject/notation/internal/cmd"
+	"github.com/notaryproject/notation/internal/envelope"
+	nx509 "github.com/notaryproject/notation/internal/x509"
+	"github.com/notaryproject/notation/pkg/configutil"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// keyTestPayloadMediaType is the media type of the throwaway payload
+	// signed by the key test command.
+	keyTestPayloadMediaType = "application/vnd.cncf.notary.key-test"
+
+	// keyTestExpiryWarningDays is the number of days before the expiry of the
+	// certificate chain from which the key test command warns.
+	keyTestExpiryWarningDays = 30
+)
+
+type keyTestOpts struct {
+	cmd.LoggingFlagOpts
+	name string
+}
+
+func keyTestCommand(opts *keyTestOpts) *cobra.Command {
+	if opts == nil {
+		opts = &keyTestOpts{}
+	}
+	command := &cobra.Command{
+		Use:   "test [flags] [key_name]",
+		Short: "Test a signing key by signing and verifying a throwaway payload",
+		Long: `Test a signing key by signing and verifying a throwaway payload
+
+The signing key is used to sign a throwaway payload in both JWS and COSE signature envelope formats, and the signatures are verified locally against the returned certificate chain. The key algorithm, the validity of the certificate chain, the days to expiry and the extended key usages of the signing certificate are reported. The default signing key is tested if the key name is not specified.
+
+Example - Test the default signing key:
+  notation key test
+
+Example - Test a signing key:
+  notation key test wabbit-networks
+`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 1 {
+				return errors.New("unnecessary parameters passed")
+			}
+			if len(args) == 1 {
+				opts.name = args[0]
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return testKey(cmd.Context(), opts)
+		},
+	}
+	opts.LoggingFlagOpts.ApplyFlags(command.Flags())
+
+	return command
+}
+
+func testKey(ctx context.Context, opts *keyTestOpts) error {
+	// set log level
+	ctx = opts.LoggingFlagOpts.InitializeLogger(ctx)
+
+	// core process
+	key, err := configutil.ResolveKey(opts.name)
+	if err != nil {
+		return err
+	}
+	s, err := signer.GetSignerFromKey(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	var signatureResults []cmdutil.CheckResult
+	var certChain []*x509.Certificate
+	for _, format := range []string{envelope.JWS, envelope.COSE} {
+		result := cmdutil.CheckResult{
+			Check:  strings.ToUpper(format) + " signature",
+			Status: cmdutil.CheckStatusOK,
+			Detail: "signed and verified",
+		}
+		chain, err := signAndVerifyTestPayload(ctx, s, format)
+		if err != nil {
+			result.Status = cmdutil.CheckStatusFailed
+			result.Detail = err.Error()
+		} else if certChain == nil {
+			certChain = chain
+		}
+		signatureResults = append(signatureResults, result)
+	}
+
+	// the certificate chain of a local key is still available when signing
+	// fails
+	var chainErr error
+	if certChain == nil {
+		if key.X509KeyPair != nil {
+			certChain, chainErr = corex509.ReadCertificateFile(key.CertificatePath)
+		} else {
+			chainErr = errors.New("certificate chain is not available as signing failed")
+		}
+	}
+	var results []cmdutil.CheckResult
+	if chainErr != nil {
+		results = append(results, cmdutil.CheckResult{
+			Check:  "certificate chain",
+			Status: cmdutil.CheckStatusFailed,
+			Detail: chainErr.Error(),
+		})
+	} else {
+		results = append(results, checkSigningCertChain(certChain, time.Now())...)
+	}
+	results = append(results, signatureResults...)
+
+	// write out
+	if err := cmdutil.PrintCheckResults(os.Stdout, results); err != nil {
+		return err
+	}
+	failed, warnings := cmdutil.CountCheckResults(results)
+	if failed > 0 {
+		return fmt.Errorf("signing key %s failed %d of %d checks", key.Name, failed, len(results))
+	}
+	if warnings > 0 {
+		fmt.Printf("Successfully tested signing key %s with %d warning(s)\n", key.Name, warnings)
+		return nil
+	}
+	fmt.Printf("Successfully tested signing key %s\n", key.Name)
+	return nil
+}
+
+// signAndVerifyTestPayload signs a throwaway payload in the signature envelope
+// format and verifies the signature against the certificate chain in the
+// signature envelope. The certificate chain is returned on success.
+func signAndVerifyTestPayload(ctx context.Context, s notation.Signer, format string) ([]*x509.Certificate, error) {
+	mediaType, err := envelope.GetEnvelopeMediaType(format)
+	if err != nil {
+		return nil, err
+	}
+	payload := []byte(fmt.Sprintf("notation key test %s", time.Now().UTC().Format(time.RFC3339Nano)))
+	desc := ocispec.Descriptor{
+		MediaType: keyTestPayloadMediaType,
+		Digest:    digest.FromBytes(payload),
+		Size:      int64(len(payload)),
+	}
+	sig, _, err := s.Sign(ctx, desc, notation.SignerSignOptions{
+		SignatureMediaType: mediaType,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign: %w", err)
+	}
+	sigEnv, err := signature.ParseEnvelope(mediaType, sig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse signature: %w", err)
+	}
+	envContent, err := sigEnv.Verify()
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify signature: %w", err)
+	}
+	signedDesc, err := envelope.DescriptorFromSignaturePayload(&envContent.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify signature: %w", err)
+	}
+	if signedDesc.Digest != desc.Digest || signedDesc.Size != desc.Size {
+		return nil, errors.New("failed to verify signature: signed payload does not match the test payload")
+	}
+	return envContent.SignerInfo.CertificateChain, nil
+}
+
+// checkSigningCertChain checks the key algorithm, the validity, the expiry and
+// the extended key usages of the signing certificate chain at time now.
+//
+// certChain must not be empty.
+func checkSigningCertChain(certChain []*x509.Certificate, now time.Time) []cmdutil.CheckResult {
+	leaf := certChain[0]
+	results := make([]cmdutil.CheckResult, 0, 4)
+
+	// key algorithm
+	algorithm := cmdutil.CheckResult{
+		Check:  "key algorithm",
+		Status: cmdutil.CheckStatusOK,
+	}
+	if keySpec, err := signature.ExtractKeySpec(leaf); err != nil {
+		algorithm.Status = cmdutil.CheckStatusFailed
+		algorithm.Detail = err.Error()
+	} else if encoded, err := proto.EncodeKeySpec(keySpec); err != nil {
+		algorithm.Status = cmdutil.CheckStatusFailed
+		algorithm.Detail = err.Error()
+	} else {
+		algorithm.Detail = string(encoded)
+	}
+	results = append(results, algorithm)
+
+	// chain validity
+	validity := cmdutil.CheckResult{
+		Check:  "certificate chain",
+		Status: cmdutil.CheckStatusOK,
+		Detail: fmt.Sprintf("%d certificate(s)", len(certChain)),
+	}
+	if err := corex509.ValidateCodeSigningCertChain(certChain, &now); err != nil {
+		validity.Status = cmdutil.CheckStatusFailed
+		validity.Detail = err.Error()
+	}
+	results = append(results, validity)
+
+	// days to expiry of the certificate that expires first
+	expiring := leaf
+	for _, cert := range certChain[1:] {
+		if cert.NotAfter.Before(expiring.NotAfter) {
+			expiring = cert
+		}
+	}
+	expiry := cmdutil.CheckResult{
+		Check:  "certificate expiry",
+		Status: cmdutil.CheckStatusOK,
+	}
+	days := int(expiring.NotAfter.Sub(now).Hours() / 24)
+	switch {
+	case now.After(expiring.NotAfter):
+		expiry.Status = cmdutil.CheckStatusFailed
+		expiry.Detail = fmt.Sprintf("expired on %s", expiring.NotAfter.Format(time.ANSIC))
+	case days < keyTestExpiryWarningDays:
+		expiry.Status = cmdutil.CheckStatusWarning
+		expiry.Detail = fmt.Sprintf("expires in %d days on %s", days, expiring.NotAfter.Format(time.ANSIC))
+	default:
+		expiry.Detail = fmt.Sprintf("expires in %d days on %s", days, expiring.NotAfter.Format(time.ANSIC))
+	}
+	if expiring != leaf {
+		expiry.Detail += fmt.Sprintf(", certificate %s", expiring.Subject)
+	}
+	results = append(results, expiry)
+
+	// code signing extended key usage
+	usage := cmdutil.CheckResult{
+		Check:  "code signing usage",
+		Status: cmdutil.CheckStatusOK,
+	}
+	ekus := strings.Join(nx509.ExtKeyUsageNames(leaf), ", ")
+	excluded := &x509.Certificate{}
+	for _, eku := range leaf.ExtKeyUsage {
+		if isExcludedCodeSigningExtKeyUsage(eku) {
+			excluded.ExtKeyUsage = append(excluded.ExtKeyUsage, eku)
+		}
+	}
+	switch {
+	case len(leaf.ExtKeyUsage) == 0 && len(leaf.UnknownExtKeyUsage) == 0:
+		usage.Detail = "not restricted by extended key usage"
+	case len(excluded.ExtKeyUsage) > 0:
+		usage.Status = cmdutil.CheckStatusFailed
+		usage.Detail = fmt.Sprintf("extended key usages must not contain %s", strings.Join(nx509.ExtKeyUsageNames(excluded), ", "))
+	case !slices.Contains(leaf.ExtKeyUsage, x509.ExtKeyUsageCodeSigning):
+		usage.Status = cmdutil.CheckStatusWarning
+		usage.Detail = fmt.Sprintf("extended key usages do not contain codeSigning: %s", ekus)
+	default:
+		usage.Detail = ekus
+	}
+	results = append(results, usage)
+
+	return results
+}
+
+// isExcludedCodeSigningExtKeyUsage returns true if eku is not allowed in a
+// code signing certificate.
+func isExcludedCodeSigningExtKeyUsage(eku x509.ExtKeyUsage) bool {
+	switch eku {
+	case x509.ExtKeyUsageServerAuth,
+		x509.ExtKeyUsageClientAuth,
+		x509.ExtKeyUsageEmailProtection,
+		x509.ExtKeyUsageTimeStamping,
+		x509.ExtKeyUsageOCSPSigning:
+		return true
+	}
+	return false
+}
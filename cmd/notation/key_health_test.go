@@ -0,0 +1,136 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"crypto/x509"
+	"testing"
+	"time"
+
+	"github.com/notaryproject/notation-core-go/testhelper"
+	"github.com/notaryproject/notation-go/signer"
+	"github.com/notaryproject/notation/cmd/notation/internal/cmdutil"
+	"github.com/notaryproject/notation/internal/envelope"
+)
+
+func TestKeyTestCommand(t *testing.T) {
+	opts := &keyTestOpts{}
+	cmd := keyTestCommand(opts)
+	if err := cmd.ParseFlags([]string{"name"}); err != nil {
+		t.Fatalf("Parse Flag failed: %v", err)
+	}
+	if err := cmd.Args(cmd, cmd.Flags().Args()); err != nil {
+		t.Fatalf("Parse Args failed: %v", err)
+	}
+	if opts.name != "name" {
+		t.Fatalf("Expect key test opts with name %q, got: %v", "name", opts)
+	}
+}
+
+func TestKeyTestCommand_DefaultKey(t *testing.T) {
+	opts := &keyTestOpts{}
+	cmd := keyTestCommand(opts)
+	if err := cmd.ParseFlags(nil); err != nil {
+		t.Fatalf("Parse Flag failed: %v", err)
+	}
+	if err := cmd.Args(cmd, cmd.Flags().Args()); err != nil {
+		t.Fatalf("Parse Args failed: %v", err)
+	}
+	if opts.name != "" {
+		t.Fatalf("Expect key test opts without name, got: %v", opts)
+	}
+}
+
+func TestKeyTestCommand_UnnecessaryArgs(t *testing.T) {
+	cmd := keyTestCommand(nil)
+	if err := cmd.ParseFlags([]string{"name1", "name2"}); err != nil {
+		t.Fatalf("Parse Flag failed: %v", err)
+	}
+	if err := cmd.Args(cmd, cmd.Flags().Args()); err == nil {
+		t.Fatal("Parse Args expected error, but ok")
+	}
+}
+
+func TestSignAndVerifyTestPayload(t *testing.T) {
+	certTuple := testhelper.GetRSALeafCertificate()
+	certChain := []*x509.Certificate{certTuple.Cert, testhelper.GetRSARootCertificate().Cert}
+	s, err := signer.NewGenericSigner(certTuple.PrivateKey, certChain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, format := range []string{envelope.JWS, envelope.COSE} {
+		t.Run(format, func(t *testing.T) {
+			chain, err := signAndVerifyTestPayload(context.Background(), s, format)
+			if err != nil {
+				t.Fatalf("signAndVerifyTestPayload() error = %v", err)
+			}
+			if len(chain) != 2 || !chain[0].Equal(certTuple.Cert) {
+				t.Fatalf("expected the signing certificate chain, got %v", chain)
+			}
+		})
+	}
+}
+
+func TestCheckSigningCertChain(t *testing.T) {
+	leaf := testhelper.GetRSALeafCertificate().Cert
+	certChain := []*x509.Certificate{leaf, testhelper.GetRSARootCertificate().Cert}
+
+	t.Run("valid", func(t *testing.T) {
+		results := checkSigningCertChain(certChain, leaf.NotBefore.Add(time.Hour))
+		for _, result := range results {
+			if result.Status == cmdutil.CheckStatusFailed {
+				t.Fatalf("expected check %q not to fail, got: %s", result.Check, result.Detail)
+			}
+		}
+	})
+
+	t.Run("expiring", func(t *testing.T) {
+		results := checkSigningCertChain(certChain, leaf.NotAfter.Add(-24*time.Hour))
+		expiry := findKeyTestResult(t, results, "certificate expiry")
+		if expiry.Status != cmdutil.CheckStatusWarning {
+			t.Fatalf("expected expiry warning, got %v", expiry)
+		}
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		results := checkSigningCertChain(certChain, leaf.NotAfter.Add(time.Hour))
+		for _, check := range []string{"certificate chain", "certificate expiry"} {
+			if result := findKeyTestResult(t, results, check); result.Status != cmdutil.CheckStatusFailed {
+				t.Fatalf("expected check %q to fail, got %v", check, result)
+			}
+		}
+	})
+
+	t.Run("excluded extended key usage", func(t *testing.T) {
+		cert := *leaf
+		cert.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning, x509.ExtKeyUsageServerAuth}
+		results := checkSigningCertChain([]*x509.Certificate{&cert}, leaf.NotBefore.Add(time.Hour))
+		usage := findKeyTestResult(t, results, "code signing usage")
+		if usage.Status != cmdutil.CheckStatusFailed || usage.Detail != "extended key usages must not contain serverAuth" {
+			t.Fatalf("expected code signing usage to fail, got %v", usage)
+		}
+	})
+}
+
+func findKeyTestResult(t *testing.T, results []cmdutil.CheckResult, check string) cmdutil.CheckResult {
+	t.Helper()
+	for _, result := range results {
+		if result.Check == check {
+			return result
+		}
+	}
+	t.Fatalf("check %q not found in %v", check, results)
+	return cmdutil.CheckResult{}
+}
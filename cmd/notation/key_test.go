@@ -98,3 +98,78 @@ func TestKeyRemoveCommand_MissingArgs(t *testing.T) {
 		t.Fatal("Parse Args expected error, but ok")
 	}
 }
+
+func TestKeyImportCommand_BasicArgs(t *testing.T) {
+	opts := &keyImportOpts{}
+	cmd := keyImportCommand(opts)
+	expected := &keyImportOpts{
+		name:          "name",
+		pkcs12Path:    "wabbit-networks.p12",
+		passwordStdin: true,
+		isDefault:     true,
+	}
+	if err := cmd.ParseFlags([]string{
+		"--pkcs12", expected.pkcs12Path,
+		"--password-stdin",
+		"--default",
+		expected.name}); err != nil {
+		t.Fatalf("Parse Flag failed: %v", err)
+	}
+	if err := cmd.Args(cmd, cmd.Flags().Args()); err != nil {
+		t.Fatalf("Parse Args failed: %v", err)
+	}
+	if !reflect.DeepEqual(*expected, *opts) {
+		t.Fatalf("Expect key import opts: %v, got: %v", expected, opts)
+	}
+}
+
+func TestKeyImportCommand_MissingArgs(t *testing.T) {
+	cmd := keyImportCommand(nil)
+	if err := cmd.ParseFlags([]string{"--pkcs12", "wabbit-networks.p12"}); err != nil {
+		t.Fatalf("Parse Flag failed: %v", err)
+	}
+	if err := cmd.Args(cmd, cmd.Flags().Args()); err == nil {
+		t.Fatal("Parse Args expected error, but ok")
+	}
+}
+
+func TestKeyShowCommand(t *testing.T) {
+	opts := &keyShowOpts{}
+	cmd := keyShowCommand(opts)
+	if err := cmd.ParseFlags([]string{
+		"--output", "json",
+		"name"}); err != nil {
+		t.Fatalf("Parse Flag failed: %v", err)
+	}
+	if err := cmd.Args(cmd, cmd.Flags().Args()); err != nil {
+		t.Fatalf("Parse Args failed: %v", err)
+	}
+	if opts.name != "name" || opts.Format.CurrentType != "json" {
+		t.Fatalf("Expect key show opts with name %q and output %q, got: %v", "name", "json", opts)
+	}
+}
+
+func TestKeyShowCommand_MissingArgs(t *testing.T) {
+	cmd := keyShowCommand(nil)
+	if err := cmd.ParseFlags(nil); err != nil {
+		t.Fatalf("Parse Flag failed: %v", err)
+	}
+	if err := cmd.Args(cmd, cmd.Flags().Args()); err == nil {
+		t.Fatal("Parse Args expected error, but ok")
+	}
+}
+
+func TestKeyListCommand(t *testing.T) {
+	opts := &keyListOpts{}
+	cmd := keyListCommand(opts)
+	if err := cmd.ParseFlags([]string{
+		"--output", "json"}); err != nil {
+		t.Fatalf("Parse Flag failed: %v", err)
+	}
+	if err := cmd.PreRunE(cmd, cmd.Flags().Args()); err != nil {
+		t.Fatalf("PreRunE failed: %v", err)
+	}
+	if opts.Format.CurrentType != "json" {
+		t.Fatalf("Expect key list opts with output %q, got: %v", "json", opts)
+	}
+}
@@ -30,7 +30,7 @@ import (
 
 type listOpts struct {
 	cmd.LoggingFlagOpts
-	SecureFlagOpts
+	option.Secure
 	option.Common
 	reference     string
 	ociLayout     bool
@@ -86,7 +86,7 @@ Example - [Experimental] List signatures of an OCI artifact identified by a tag
 		},
 	}
 	opts.LoggingFlagOpts.ApplyFlags(command.Flags())
-	opts.SecureFlagOpts.ApplyFlags(command.Flags())
+	opts.Secure.ApplyFlags(command.Flags())
 	command.Flags().BoolVar(&opts.ociLayout, "oci-layout", false, "[Experimental] list signatures stored in OCI image layout")
 	command.Flags().IntVar(&opts.maxSignatures, "max-signatures", 100, "maximum number of signatures to evaluate or examine")
 	experimental.HideFlags(command, experimentalExamples, []string{"oci-layout"})
@@ -102,7 +102,7 @@ func runList(ctx context.Context, opts *listOpts) error {
 	reference := opts.reference
 	// always use the Referrers API, if not supported, automatically fallback to
 	// the referrers tag schema
-	sigRepo, err := getRepository(ctx, opts.inputType, reference, &opts.SecureFlagOpts, false)
+	sigRepo, err := getRepository(ctx, opts.inputType, reference, &opts.Secure, false)
 	if err != nil {
 		return err
 	}
@@ -23,6 +23,8 @@ import (
 	"strings"
 
 	"github.com/notaryproject/notation-go/log"
+	"github.com/notaryproject/notation/cmd/notation/internal/option"
+	"github.com/notaryproject/notation/cmd/notation/internal/registryutil"
 	"github.com/notaryproject/notation/internal/auth"
 	"github.com/notaryproject/notation/internal/cmd"
 	"github.com/spf13/cobra"
@@ -34,7 +36,7 @@ const urlDocHowToAuthenticate = "https://notaryproject.dev/docs/how-to/registry-
 
 type loginOpts struct {
 	cmd.LoggingFlagOpts
-	SecureFlagOpts
+	option.Secure
 	passwordStdin bool
 	server        string
 }
@@ -71,7 +73,7 @@ Example - Login using $NOTATION_USERNAME $NOTATION_PASSWORD variables:
 		},
 	}
 	opts.LoggingFlagOpts.ApplyFlags(command.Flags())
-	opts.SecureFlagOpts.ApplyFlags(command.Flags())
+	opts.Secure.ApplyFlags(command.Flags())
 	command.Flags().BoolVar(&opts.passwordStdin, "password-stdin", false, "take the password from stdin")
 	return command
 }
@@ -115,7 +117,7 @@ func runLogin(ctx context.Context, opts *loginOpts) error {
 	if err != nil {
 		return fmt.Errorf("failed to get credentials store: %v", err)
 	}
-	registry, err := getRegistryLoginClient(ctx, &opts.SecureFlagOpts, serverAddress)
+	registry, err := registryutil.NewRegistryLoginClient(ctx, &opts.Secure, serverAddress)
 	if err != nil {
 		return fmt.Errorf("failed to get registry client: %v", err)
 	}
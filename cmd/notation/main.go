@@ -18,9 +18,12 @@ import (
 
 	"github.com/notaryproject/notation-go/dir"
 	"github.com/notaryproject/notation/cmd/notation/blob"
+	"github.com/notaryproject/notation/cmd/notation/cache"
 	"github.com/notaryproject/notation/cmd/notation/cert"
+	"github.com/notaryproject/notation/cmd/notation/internal/option"
 	"github.com/notaryproject/notation/cmd/notation/plugin"
 	"github.com/notaryproject/notation/cmd/notation/policy"
+	"github.com/notaryproject/notation/cmd/notation/tsa"
 	"github.com/spf13/cobra"
 )
 
@@ -32,8 +35,8 @@ func main() {
 		PersistentPreRun: func(cmd *cobra.Command, args []string) {
 			// unset registry credentials after read the value from environment
 			// to avoid leaking credentials
-			os.Unsetenv(defaultUsernameEnv)
-			os.Unsetenv(defaultPasswordEnv)
+			os.Unsetenv(option.DefaultUsernameEnv)
+			os.Unsetenv(option.DefaultPasswordEnv)
 
 			// update Notation config directory
 			if notationConfig := os.Getenv("NOTATION_CONFIG"); notationConfig != "" {
@@ -60,10 +63,13 @@ func main() {
 		policy.Cmd(),
 		keyCommand(),
 		plugin.Cmd(),
+		tsa.Cmd(),
+		cache.Cmd(),
 		loginCommand(nil),
 		logoutCommand(nil),
 		versionCommand(),
 		inspectCommand(nil),
+		signatureCommand(),
 	)
 	if err := cmd.Execute(); err != nil {
 		os.Exit(1)
@@ -22,7 +22,7 @@ func Cmd() *cobra.Command {
 	}
 
 	command.AddCommand(
-		listCommand(),
+		listCommand(nil),
 		installCommand(nil),
 		uninstallCommand(nil),
 	)
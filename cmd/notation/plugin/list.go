@@ -16,17 +16,25 @@ package plugin
 import (
 	"errors"
 	"fmt"
-	"os"
-	"text/tabwriter"
 
 	"github.com/notaryproject/notation-go/dir"
 	"github.com/notaryproject/notation-go/plugin"
 	"github.com/notaryproject/notation-go/plugin/proto"
+	"github.com/notaryproject/notation/cmd/notation/internal/display"
+	"github.com/notaryproject/notation/cmd/notation/internal/option"
 	"github.com/spf13/cobra"
 )
 
-func listCommand() *cobra.Command {
-	return &cobra.Command{
+type pluginListOpts struct {
+	option.Common
+	option.Format
+}
+
+func listCommand(opts *pluginListOpts) *cobra.Command {
+	if opts == nil {
+		opts = &pluginListOpts{}
+	}
+	command := &cobra.Command{
 		Use:     "list [flags]",
 		Aliases: []string{"ls"},
 		Short:   "List installed plugins",
@@ -34,14 +42,31 @@ func listCommand() *cobra.Command {
 
 Example - List installed Notation plugins:
   notation plugin ls
+
+Example - List installed Notation plugins in JSON format:
+  notation plugin ls --output json
 `,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.Format.Parse(cmd); err != nil {
+				return err
+			}
+			opts.Common.Parse(cmd)
+			return nil
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return listPlugins(cmd)
+			return listPlugins(cmd, opts)
 		},
 	}
+	opts.Format.ApplyFlags(command.Flags(), option.FormatTypeText, option.FormatTypeJSON)
+	return command
 }
 
-func listPlugins(command *cobra.Command) error {
+func listPlugins(command *cobra.Command, opts *pluginListOpts) error {
+	displayHandler, err := display.NewPluginListHandler(opts.Printer, opts.Format)
+	if err != nil {
+		return err
+	}
+
 	mgr := plugin.NewCLIManager(dir.PluginFS())
 	pluginNames, err := mgr.List(command.Context())
 	if err != nil {
@@ -53,22 +78,16 @@ func listPlugins(command *cobra.Command) error {
 		return err
 	}
 
-	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
-	fmt.Fprintln(tw, "NAME\tDESCRIPTION\tVERSION\tCAPABILITIES\tERROR\t")
-
-	var pl plugin.Plugin
-	var resp *proto.GetMetadataResponse
 	for _, n := range pluginNames {
-		pl, err = mgr.Get(command.Context(), n)
-		metaData := &proto.GetMetadataResponse{}
+		var metadata *proto.GetMetadataResponse
+		pl, err := mgr.Get(command.Context(), n)
 		if err == nil {
-			resp, err = pl.GetMetadata(command.Context(), &proto.GetMetadataRequest{})
-			if err == nil {
-				metaData = resp
-			}
+			metadata, err = pl.GetMetadata(command.Context(), &proto.GetMetadataRequest{})
+		}
+		if err != nil {
+			metadata = nil
 		}
-		fmt.Fprintf(tw, "%s\t%s\t%s\t%v\t%v\t\n",
-			n, metaData.Description, metaData.Version, metaData.Capabilities, err)
+		displayHandler.OnPluginListed(n, metadata, err)
 	}
-	return tw.Flush()
+	return displayHandler.Render()
 }
@@ -100,7 +100,7 @@ func runImport(command *cobra.Command, opts importOpts) error {
 	}
 	// user has confirmed to overwrite the existing trust policy configuration,
 	// delete the old trust policy file `trustpolicy.json` if exists
-	if err := deleteOldTrustPolicyFile(); err != nil {
+	if err := cmdutil.DeleteOldTrustPolicyFile(); err != nil {
 		if !errors.Is(err, os.ErrNotExist) {
 			fmt.Fprintf(os.Stderr, "Warning: failed to delete old trust policy configuration trustpolicy.json: %s\n", err)
 		}
@@ -111,15 +111,3 @@ func runImport(command *cobra.Command, opts importOpts) error {
 	_, err = fmt.Fprintf(os.Stdout, "Successfully imported OCI trust policy configuration to %s.\n", policyPath)
 	return err
 }
-
-// deleteOldTrustPolicyFile deletes the old trust policy configuration if exists.
-func deleteOldTrustPolicyFile() error {
-	oldPolicyPath, err := dir.ConfigFS().SysPath(dir.PathTrustPolicy)
-	if err != nil {
-		return err
-	}
-	if _, err := os.Stat(oldPolicyPath); err != nil {
-		return err
-	}
-	return os.Remove(oldPolicyPath)
-}
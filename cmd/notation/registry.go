@@ -17,17 +17,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"net"
 
 	"github.com/notaryproject/notation-go/log"
 	notationregistry "github.com/notaryproject/notation-go/registry"
-	notationauth "github.com/notaryproject/notation/internal/auth"
-	"github.com/notaryproject/notation/internal/httputil"
-	"github.com/notaryproject/notation/pkg/configutil"
+	"github.com/notaryproject/notation/cmd/notation/internal/option"
+	"github.com/notaryproject/notation/cmd/notation/internal/registryutil"
 	"oras.land/oras-go/v2/registry"
-	"oras.land/oras-go/v2/registry/remote"
-	"oras.land/oras-go/v2/registry/remote/auth"
-	"oras.land/oras-go/v2/registry/remote/credentials"
 )
 
 // inputType denotes the user input type
@@ -40,7 +35,7 @@ const (
 
 // getRepository returns a notationregistry.Repository given user input
 // type and user input reference
-func getRepository(ctx context.Context, inputType inputType, reference string, opts *SecureFlagOpts, forceReferrersTag bool) (notationregistry.Repository, error) {
+func getRepository(ctx context.Context, inputType inputType, reference string, opts *option.Secure, forceReferrersTag bool) (notationregistry.Repository, error) {
 	switch inputType {
 	case inputTypeRegistry:
 		return getRemoteRepository(ctx, opts, reference, forceReferrersTag)
@@ -67,7 +62,7 @@ func getRepository(ctx context.Context, inputType inputType, reference string, o
 // References:
 // https://github.com/opencontainers/distribution-spec/blob/v1.1.0/spec.md#listing-referrers
 // https://github.com/opencontainers/distribution-spec/blob/v1.1.0/spec.md#referrers-tag-schema
-func getRemoteRepository(ctx context.Context, opts *SecureFlagOpts, reference string, forceReferrersTag bool) (notationregistry.Repository, error) {
+func getRemoteRepository(ctx context.Context, opts *option.Secure, reference string, forceReferrersTag bool) (notationregistry.Repository, error) {
 	logger := log.GetLogger(ctx)
 	ref, err := registry.ParseReference(reference)
 	if err != nil {
@@ -77,7 +72,7 @@ func getRemoteRepository(ctx context.Context, opts *SecureFlagOpts, reference st
 		return nil, fmt.Errorf("%q: invalid reference: no tag or digest. Expecting <registry>/<repository>:<tag> or <registry>/<repository>@<digest>", reference)
 	}
 	// generate notation repository
-	remoteRepo, err := getRepositoryClient(ctx, opts, ref)
+	remoteRepo, err := registryutil.NewRepositoryClient(ctx, opts, ref)
 	if err != nil {
 		return nil, err
 	}
@@ -90,71 +85,3 @@ func getRemoteRepository(ctx context.Context, opts *SecureFlagOpts, reference st
 	}
 	return notationregistry.NewRepository(remoteRepo), nil
 }
-
-func getRepositoryClient(ctx context.Context, opts *SecureFlagOpts, ref registry.Reference) (*remote.Repository, error) {
-	authClient, insecureRegistry, err := getAuthClient(ctx, opts, ref, true)
-	if err != nil {
-		return nil, err
-	}
-
-	return &remote.Repository{
-		Client:    authClient,
-		Reference: ref,
-		PlainHTTP: insecureRegistry,
-	}, nil
-}
-
-func getRegistryLoginClient(ctx context.Context, opts *SecureFlagOpts, serverAddress string) (*remote.Registry, error) {
-	reg, err := remote.NewRegistry(serverAddress)
-	if err != nil {
-		return nil, err
-	}
-
-	reg.Client, reg.PlainHTTP, err = getAuthClient(ctx, opts, reg.Reference, false)
-	if err != nil {
-		return nil, err
-	}
-	return reg, nil
-}
-
-// getAuthClient returns an *auth.Client and a bool indicating if the registry
-// is insecure.
-//
-// If withCredential is true, the returned *auth.Client will have its Credential
-// function configured.
-//
-// If withCredential is false, the returned *auth.Client will have a nil
-// Credential function.
-func getAuthClient(ctx context.Context, opts *SecureFlagOpts, ref registry.Reference, withCredential bool) (*auth.Client, bool, error) {
-	var insecureRegistry bool
-	if opts.InsecureRegistry {
-		insecureRegistry = opts.InsecureRegistry
-	} else {
-		insecureRegistry = configutil.IsRegistryInsecure(ref.Registry)
-		if !insecureRegistry {
-			if host, _, _ := net.SplitHostPort(ref.Registry); host == "localhost" {
-				insecureRegistry = true
-			}
-		}
-	}
-
-	// build authClient
-	authClient := httputil.NewAuthClient(ctx, nil)
-	if !withCredential {
-		return authClient, insecureRegistry, nil
-	}
-
-	cred := opts.Credential()
-	if cred != auth.EmptyCredential {
-		// use the specified credential
-		authClient.Credential = auth.StaticCredential(ref.Host(), cred)
-	} else {
-		// use saved credentials
-		credsStore, err := notationauth.NewCredentialsStore()
-		if err != nil {
-			return nil, false, fmt.Errorf("failed to get credentials store: %w", err)
-		}
-		authClient.Credential = credentials.Credential(credsStore)
-	}
-	return authClient, insecureRegistry, nil
-}
@@ -19,6 +19,8 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"testing"
+
+	"github.com/notaryproject/notation/cmd/notation/internal/option"
 )
 
 const (
@@ -40,7 +42,7 @@ func TestRegistry_getRemoteRepositoryWithReferrersAPISupported(t *testing.T) {
 	if err != nil {
 		t.Fatalf("invalid test http server: %v", err)
 	}
-	secureOpts := SecureFlagOpts{
+	secureOpts := option.Secure{
 		InsecureRegistry: true,
 	}
 	_, err = getRemoteRepository(context.Background(), &secureOpts, uri.Host+"/test:v1", true)
@@ -63,7 +65,7 @@ func TestRegistry_getRemoteRepositoryWithReferrersAPINotSupported(t *testing.T)
 	if err != nil {
 		t.Fatalf("invalid test http server: %v", err)
 	}
-	secureOpts := SecureFlagOpts{
+	secureOpts := option.Secure{
 		InsecureRegistry: true,
 	}
 	_, err = getRemoteRepository(context.Background(), &secureOpts, uri.Host+"/test:v1", true)
@@ -87,7 +89,7 @@ func TestRegistry_getRemoteRepositoryWithReferrersTagSchema(t *testing.T) {
 	if err != nil {
 		t.Fatalf("invalid test http server: %v", err)
 	}
-	secureOpts := SecureFlagOpts{
+	secureOpts := option.Secure{
 		InsecureRegistry: true,
 	}
 	_, err = getRemoteRepository(context.Background(), &secureOpts, uri.Host+"/test:v1", false)
@@ -17,7 +17,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"net/http"
 	"os"
 	"strings"
 	"time"
@@ -26,27 +25,26 @@ import (
 	"github.com/notaryproject/notation-go"
 	"github.com/notaryproject/notation-go/log"
 	"github.com/notaryproject/notation/cmd/notation/internal/experimental"
+	"github.com/notaryproject/notation/cmd/notation/internal/option"
 	"github.com/notaryproject/notation/cmd/notation/internal/signer"
+	"github.com/notaryproject/notation/cmd/notation/internal/timestamp"
 	"github.com/notaryproject/notation/internal/cmd"
 	"github.com/notaryproject/notation/internal/envelope"
-	"github.com/notaryproject/notation/internal/httputil"
 	clirev "github.com/notaryproject/notation/internal/revocation"
-	nx509 "github.com/notaryproject/notation/internal/x509"
-	"github.com/notaryproject/tspclient-go"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/spf13/cobra"
 )
 
 const referrersTagSchemaDeleteError = "failed to delete dangling referrers index"
 
-// timestampingTimeout is the timeout when requesting timestamp countersignature
-// from a TSA
-const timestampingTimeout = 15 * time.Second
+// defaultSigningCertExpiryWindow is the default duration before the expiry of
+// the signing certificate within which a warning is printed out
+const defaultSigningCertExpiryWindow = "7d"
 
 type signOpts struct {
 	cmd.LoggingFlagOpts
 	cmd.SignerFlagOpts
-	SecureFlagOpts
+	option.Secure
 	expiry                 time.Duration
 	pluginConfig           []string
 	userMetadata           []string
@@ -54,8 +52,13 @@ type signOpts struct {
 	forceReferrersTag      bool
 	ociLayout              bool
 	inputType              inputType
+	timestamp              bool
 	tsaServerURL           string
 	tsaRootCertificatePath string
+	tsaTrustStore          string
+	tsaTimeout             time.Duration
+	strictSigningCert      bool
+	signingCertExpiry      string
 }
 
 func signCommand(opts *signOpts) *cobra.Command {
@@ -91,6 +94,21 @@ Example - Sign an OCI artifact and store signature using the Referrers API. If i
 
 Example - Sign an OCI artifact with timestamping:
   notation sign --timestamp-url <TSA_url> --timestamp-root-cert <TSA_root_certificate_filepath> <registry>/<repository>@<digest> 
+
+Example - Sign an OCI artifact with timestamping and trust the TSA root certificates from the system root certificate bundle:
+  notation sign --timestamp-url <TSA_url> --timestamp-trust-store system <registry>/<repository>@<digest>
+
+Example - Sign an OCI artifact with timestamping and trust the TSA root certificates in the trust store "tsa/<store_name>":
+  notation sign --timestamp-url <TSA_url> --timestamp-trust-store <store_name> <registry>/<repository>@<digest>
+
+Example - Sign an OCI artifact with timestamping by the TSA servers configured in config.json, trying them in order:
+  notation sign <registry>/<repository>@<digest>
+
+Example - Sign an OCI artifact without timestamping even if TSA servers are configured in config.json:
+  notation sign --timestamp=false <registry>/<repository>@<digest>
+
+Example - Sign an OCI artifact and fail if the signing certificate is expired, revoked or expires within 30 days:
+  notation sign --strict-signing-cert --signing-cert-expiry-window 30d <registry>/<repository>@<digest>
 `
 	experimentalExamples := `
 Example - [Experimental] Sign an OCI artifact referenced in an OCI layout
@@ -119,13 +137,8 @@ Example - [Experimental] Sign an OCI artifact identified by a tag and referenced
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// timestamping
-			if cmd.Flags().Changed("timestamp-url") {
-				if opts.tsaServerURL == "" {
-					return errors.New("timestamping: tsa url cannot be empty")
-				}
-				if opts.tsaRootCertificatePath == "" {
-					return errors.New("timestamping: tsa root certificate path cannot be empty")
-				}
+			if err := timestamp.ValidateFlags(cmd.Flags(), opts.tsaServerURL, opts.tsaRootCertificatePath, opts.tsaTrustStore); err != nil {
+				return err
 			}
 
 			return runSign(cmd, opts)
@@ -133,16 +146,21 @@ Example - [Experimental] Sign an OCI artifact identified by a tag and referenced
 	}
 	opts.LoggingFlagOpts.ApplyFlags(command.Flags())
 	opts.SignerFlagOpts.ApplyFlagsToCommand(command)
-	opts.SecureFlagOpts.ApplyFlags(command.Flags())
+	opts.Secure.ApplyFlags(command.Flags())
 	cmd.SetPflagExpiry(command.Flags(), &opts.expiry)
 	cmd.SetPflagPluginConfig(command.Flags(), &opts.pluginConfig)
 	cmd.SetPflagUserMetadata(command.Flags(), &opts.userMetadata, cmd.PflagUserMetadataSignUsage)
-	command.Flags().StringVar(&opts.tsaServerURL, "timestamp-url", "", "RFC 3161 Timestamping Authority (TSA) server URL")
+	command.Flags().BoolVar(&opts.timestamp, "timestamp", true, "timestamp the signature. Use --timestamp=false to sign without timestamping by the TSA servers configured in config.json")
+	command.Flags().StringVar(&opts.tsaServerURL, "timestamp-url", "", "RFC 3161 Timestamping Authority (TSA) server URL. If not specified, the TSA servers configured in config.json are tried in order")
 	command.Flags().StringVar(&opts.tsaRootCertificatePath, "timestamp-root-cert", "", "filepath of timestamp authority root certificate")
+	command.Flags().StringVar(&opts.tsaTrustStore, "timestamp-trust-store", "", "name of the trust store of type tsa containing the timestamp authority root certificates, for example \"myTSA\" for trust store \"tsa/myTSA\", or \"system\" to use the system root certificate bundle")
+	cmd.SetPflagTimestampTimeout(command.Flags(), &opts.tsaTimeout)
+	command.Flags().BoolVar(&opts.strictSigningCert, "strict-signing-cert", false, "fail signing instead of warning if the signing certificate is expired, revoked or expires within the expiry window")
+	command.Flags().StringVar(&opts.signingCertExpiry, "signing-cert-expiry-window", defaultSigningCertExpiryWindow, "duration before the expiry of the signing certificate within which the signing certificate is reported as expiring, in days(d), hours(h) and/or minutes(m). For example: 30d, 12h. Set to 0 to disable")
 	cmd.SetPflagReferrersTag(command.Flags(), &opts.forceReferrersTag, "force to store signatures using the referrers tag schema")
 	command.Flags().BoolVar(&opts.ociLayout, "oci-layout", false, "[Experimental] sign the artifact stored as OCI image layout")
 	command.MarkFlagsMutuallyExclusive("oci-layout", "force-referrers-tag")
-	command.MarkFlagsRequiredTogether("timestamp-url", "timestamp-root-cert")
+	command.MarkFlagsMutuallyExclusive("timestamp-root-cert", "timestamp-trust-store")
 	experimental.HideFlags(command, experimentalExamples, []string{"oci-layout"})
 	return command
 }
@@ -152,11 +170,11 @@ func runSign(command *cobra.Command, cmdOpts *signOpts) error {
 	ctx := cmdOpts.LoggingFlagOpts.InitializeLogger(command.Context())
 
 	// initialize
-	signer, err := signer.GetSigner(ctx, &cmdOpts.SignerFlagOpts)
+	signer, err := getSigner(ctx, cmdOpts)
 	if err != nil {
 		return err
 	}
-	sigRepo, err := getRepository(ctx, cmdOpts.inputType, cmdOpts.reference, &cmdOpts.SecureFlagOpts, cmdOpts.forceReferrersTag)
+	sigRepo, err := getRepository(ctx, cmdOpts.inputType, cmdOpts.reference, &cmdOpts.Secure, cmdOpts.forceReferrersTag)
 	if err != nil {
 		return err
 	}
@@ -188,6 +206,33 @@ func runSign(command *cobra.Command, cmdOpts *signOpts) error {
 	return nil
 }
 
+// getSigner returns a Signer after checking the expiry and the revocation
+// status of the signing certificate. For keys managed by plugins, the check is
+// performed after signing and before the signature is pushed.
+func getSigner(ctx context.Context, opts *signOpts) (signer.Signer, error) {
+	expiryWindow, err := cmd.ParseDuration(opts.signingCertExpiry)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signing certificate expiry window: %w", err)
+	}
+	s, err := signer.GetSigner(ctx, &opts.SignerFlagOpts)
+	if err != nil {
+		return nil, err
+	}
+	revocationValidator, err := clirev.NewRevocationValidator(ctx, purpose.CodeSigning)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create code signing revocation validator: %w", err)
+	}
+	certChain, err := signer.GetCertificateChain(&opts.SignerFlagOpts)
+	if err != nil {
+		return nil, err
+	}
+	return signer.WithCertificateCheck(ctx, s, certChain, signer.CertificateCheckOptions{
+		ExpiryWindow:        expiryWindow,
+		RevocationValidator: revocationValidator,
+		Strict:              opts.strictSigningCert,
+	})
+}
+
 func prepareSigningOpts(ctx context.Context, opts *signOpts) (notation.SignOptions, error) {
 	logger := log.GetLogger(ctx)
 
@@ -211,14 +256,16 @@ func prepareSigningOpts(ctx context.Context, opts *signOpts) (notation.SignOptio
 		},
 		UserMetadata: userMetadata,
 	}
-	if opts.tsaServerURL != "" {
+	tsaServers, err := timestamp.Servers(opts.timestamp, opts.tsaServerURL, opts.tsaRootCertificatePath, opts.tsaTrustStore)
+	if err != nil {
+		return notation.SignOptions{}, err
+	}
+	if len(tsaServers) > 0 {
 		// timestamping
-		logger.Infof("Configured to timestamp with TSA %q", opts.tsaServerURL)
-		signOpts.Timestamper, err = tspclient.NewHTTPTimestamper(httputil.NewClient(ctx, &http.Client{Timeout: timestampingTimeout}), opts.tsaServerURL)
-		if err != nil {
-			return notation.SignOptions{}, fmt.Errorf("cannot get http timestamper for timestamping: %w", err)
+		for _, server := range tsaServers {
+			logger.Infof("Configured to timestamp with TSA %q", server.URL)
 		}
-		signOpts.TSARootCAs, err = nx509.NewRootCertPool(opts.tsaRootCertificatePath)
+		signOpts.Timestamper, signOpts.TSARootCAs, err = timestamp.New(ctx, tsaServers, opts.tsaTimeout)
 		if err != nil {
 			return notation.SignOptions{}, err
 		}
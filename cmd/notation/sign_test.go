@@ -14,13 +14,17 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/notaryproject/notation/cmd/notation/internal/option"
 	"github.com/notaryproject/notation/internal/cmd"
 	"github.com/notaryproject/notation/internal/envelope"
+	"github.com/notaryproject/notation/pkg/configutil"
 )
 
 func TestSignCommand_BasicArgs(t *testing.T) {
@@ -28,7 +32,7 @@ func TestSignCommand_BasicArgs(t *testing.T) {
 	command := signCommand(opts)
 	expected := &signOpts{
 		reference: "ref",
-		SecureFlagOpts: SecureFlagOpts{
+		Secure: option.Secure{
 			Username: "user",
 			Password: "password",
 		},
@@ -37,6 +41,9 @@ func TestSignCommand_BasicArgs(t *testing.T) {
 			SignatureFormat: envelope.JWS,
 		},
 		forceReferrersTag: false,
+		signingCertExpiry: defaultSigningCertExpiryWindow,
+		timestamp:         true,
+		tsaTimeout:        configutil.DefaultTimestampTimeout,
 	}
 	if err := command.ParseFlags([]string{
 		expected.reference,
@@ -58,7 +65,7 @@ func TestSignCommand_MoreArgs(t *testing.T) {
 	command := signCommand(opts)
 	expected := &signOpts{
 		reference: "ref",
-		SecureFlagOpts: SecureFlagOpts{
+		Secure: option.Secure{
 			Username:         "user",
 			Password:         "password",
 			InsecureRegistry: true,
@@ -69,6 +76,9 @@ func TestSignCommand_MoreArgs(t *testing.T) {
 		},
 		expiry:            24 * time.Hour,
 		forceReferrersTag: true,
+		signingCertExpiry: defaultSigningCertExpiryWindow,
+		timestamp:         true,
+		tsaTimeout:        configutil.DefaultTimestampTimeout,
 	}
 	if err := command.ParseFlags([]string{
 		expected.reference,
@@ -102,6 +112,9 @@ func TestSignCommand_CorrectConfig(t *testing.T) {
 		expiry:            365 * 24 * time.Hour,
 		pluginConfig:      []string{"key0=val0", "key1=val1"},
 		forceReferrersTag: false,
+		signingCertExpiry: defaultSigningCertExpiryWindow,
+		timestamp:         true,
+		tsaTimeout:        configutil.DefaultTimestampTimeout,
 	}
 	if err := command.ParseFlags([]string{
 		expected.reference,
@@ -144,7 +157,7 @@ func TestSignCommmand_OnDemandKeyOptions(t *testing.T) {
 	command := signCommand(opts)
 	expected := &signOpts{
 		reference: "ref",
-		SecureFlagOpts: SecureFlagOpts{
+		Secure: option.Secure{
 			Username: "user",
 			Password: "password",
 		},
@@ -153,6 +166,9 @@ func TestSignCommmand_OnDemandKeyOptions(t *testing.T) {
 			PluginName:      "pluginName",
 			SignatureFormat: envelope.JWS,
 		},
+		signingCertExpiry: defaultSigningCertExpiryWindow,
+		timestamp:         true,
+		tsaTimeout:        configutil.DefaultTimestampTimeout,
 	}
 	if err := command.ParseFlags([]string{
 		expected.reference,
@@ -178,7 +194,7 @@ func TestSignCommmand_OnDemandKeyBadOptions(t *testing.T) {
 		command := signCommand(opts)
 		expected := &signOpts{
 			reference: "ref",
-			SecureFlagOpts: SecureFlagOpts{
+			Secure: option.Secure{
 				Username: "user",
 				Password: "password",
 			},
@@ -188,6 +204,9 @@ func TestSignCommmand_OnDemandKeyBadOptions(t *testing.T) {
 				Key:             "keyName",
 				SignatureFormat: envelope.JWS,
 			},
+			signingCertExpiry: defaultSigningCertExpiryWindow,
+			timestamp:         true,
+			tsaTimeout:        configutil.DefaultTimestampTimeout,
 		}
 		if err := command.ParseFlags([]string{
 			expected.reference,
@@ -216,7 +235,7 @@ func TestSignCommmand_OnDemandKeyBadOptions(t *testing.T) {
 		command := signCommand(opts)
 		expected := &signOpts{
 			reference: "ref",
-			SecureFlagOpts: SecureFlagOpts{
+			Secure: option.Secure{
 				Username: "user",
 				Password: "password",
 			},
@@ -225,6 +244,9 @@ func TestSignCommmand_OnDemandKeyBadOptions(t *testing.T) {
 				Key:             "keyName",
 				SignatureFormat: envelope.JWS,
 			},
+			signingCertExpiry: defaultSigningCertExpiryWindow,
+			timestamp:         true,
+			tsaTimeout:        configutil.DefaultTimestampTimeout,
 		}
 		if err := command.ParseFlags([]string{
 			expected.reference,
@@ -252,7 +274,7 @@ func TestSignCommmand_OnDemandKeyBadOptions(t *testing.T) {
 		command := signCommand(opts)
 		expected := &signOpts{
 			reference: "ref",
-			SecureFlagOpts: SecureFlagOpts{
+			Secure: option.Secure{
 				Username: "user",
 				Password: "password",
 			},
@@ -261,6 +283,9 @@ func TestSignCommmand_OnDemandKeyBadOptions(t *testing.T) {
 				Key:             "keyName",
 				SignatureFormat: envelope.JWS,
 			},
+			signingCertExpiry: defaultSigningCertExpiryWindow,
+			timestamp:         true,
+			tsaTimeout:        configutil.DefaultTimestampTimeout,
 		}
 		if err := command.ParseFlags([]string{
 			expected.reference,
@@ -288,7 +313,7 @@ func TestSignCommmand_OnDemandKeyBadOptions(t *testing.T) {
 		command := signCommand(opts)
 		expected := &signOpts{
 			reference: "ref",
-			SecureFlagOpts: SecureFlagOpts{
+			Secure: option.Secure{
 				Username: "user",
 				Password: "password",
 			},
@@ -296,6 +321,9 @@ func TestSignCommmand_OnDemandKeyBadOptions(t *testing.T) {
 				KeyID:           "keyID",
 				SignatureFormat: envelope.JWS,
 			},
+			signingCertExpiry: defaultSigningCertExpiryWindow,
+			timestamp:         true,
+			tsaTimeout:        configutil.DefaultTimestampTimeout,
 		}
 		if err := command.ParseFlags([]string{
 			expected.reference,
@@ -322,7 +350,7 @@ func TestSignCommmand_OnDemandKeyBadOptions(t *testing.T) {
 		command := signCommand(opts)
 		expected := &signOpts{
 			reference: "ref",
-			SecureFlagOpts: SecureFlagOpts{
+			Secure: option.Secure{
 				Username: "user",
 				Password: "password",
 			},
@@ -330,6 +358,9 @@ func TestSignCommmand_OnDemandKeyBadOptions(t *testing.T) {
 				PluginName:      "pluginName",
 				SignatureFormat: envelope.JWS,
 			},
+			signingCertExpiry: defaultSigningCertExpiryWindow,
+			timestamp:         true,
+			tsaTimeout:        configutil.DefaultTimestampTimeout,
 		}
 		if err := command.ParseFlags([]string{
 			expected.reference,
@@ -362,3 +393,26 @@ func TestSignCommand_MissingArgs(t *testing.T) {
 		t.Fatal("Parse Args expected error, but ok")
 	}
 }
+
+func TestSignCommand_SigningCertCheck(t *testing.T) {
+	opts := &signOpts{}
+	command := signCommand(opts)
+	if err := command.ParseFlags([]string{
+		"ref",
+		"--strict-signing-cert",
+		"--signing-cert-expiry-window", "30d"}); err != nil {
+		t.Fatalf("Parse Flag failed: %v", err)
+	}
+	if !opts.strictSigningCert || opts.signingCertExpiry != "30d" {
+		t.Fatalf("Expect strict signing certificate check with expiry window %q, got: %v", "30d", opts)
+	}
+}
+
+func TestGetSigner_InvalidExpiryWindow(t *testing.T) {
+	opts := &signOpts{
+		signingCertExpiry: "30x",
+	}
+	if _, err := getSigner(context.Background(), opts); err == nil || !strings.Contains(err.Error(), "invalid signing certificate expiry window") {
+		t.Fatalf("expected invalid expiry window error, got %v", err)
+	}
+}
@@ -0,0 +1,297 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/notaryproject/notation-core-go/revocation/purpose"
+	"github.com/notaryproject/notation-core-go/signature"
+	"github.com/notaryproject/notation-go/log"
+	notationregistry "github.com/notaryproject/notation-go/registry"
+	"github.com/notaryproject/notation/cmd/notation/internal/option"
+	"github.com/notaryproject/notation/cmd/notation/internal/registryutil"
+	"github.com/notaryproject/notation/cmd/notation/internal/timestamp"
+	"github.com/notaryproject/notation/internal/cmd"
+	"github.com/notaryproject/notation/internal/envelope"
+	clirev "github.com/notaryproject/notation/internal/revocation"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2/registry"
+)
+
+// errSignatureFound stops listing signatures once the requested signature is
+// found.
+var errSignatureFound = errors.New("signature found")
+
+type signatureTimestampOpts struct {
+	cmd.LoggingFlagOpts
+	option.Secure
+	reference              string
+	signatureDigest        string
+	tsaServerURL           string
+	tsaRootCertificatePath string
+	tsaTrustStore          string
+	tsaTimeout             time.Duration
+	deleteOriginal         bool
+}
+
+func signatureCommand() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "signature",
+		Short: "Manage signatures of artifacts",
+		Long: `Manage signatures of artifacts
+
+Example - Add a timestamp countersignature to an existing signature of an OCI artifact:
+  notation signature timestamp --signature <signature_digest> --timestamp-url <TSA_url> --timestamp-root-cert <TSA_root_certificate_filepath> <registry>/<repository>@<digest>
+`,
+	}
+	command.AddCommand(signatureTimestampCommand(nil))
+
+	return command
+}
+
+func signatureTimestampCommand(opts *signatureTimestampOpts) *cobra.Command {
+	if opts == nil {
+		opts = &signatureTimestampOpts{}
+	}
+	longMessage := `Add an RFC 3161 timestamp countersignature to an existing signature of an OCI artifact
+
+The signature envelope identified by the digest of its signature manifest is fetched from the registry, a timestamp countersignature over its signature value is obtained from the Timestamping Authority (TSA), and a new signature envelope with the timestamp countersignature in its unsigned attributes is pushed. The signed content of the signature is left intact. The original signature is kept unless "--delete-original" is specified.
+
+Example - Timestamp an existing signature:
+  notation signature timestamp --signature <signature_digest> --timestamp-url <TSA_url> --timestamp-root-cert <TSA_root_certificate_filepath> <registry>/<repository>@<digest>
+
+Example - Timestamp an existing signature and trust the TSA root certificates in the trust store "tsa/<store_name>":
+  notation signature timestamp --signature <signature_digest> --timestamp-url <TSA_url> --timestamp-trust-store <store_name> <registry>/<repository>@<digest>
+
+Example - Timestamp an existing signature with the TSA servers configured in config.json and delete the original signature:
+  notation signature timestamp --signature <signature_digest> --delete-original <registry>/<repository>@<digest>
+`
+	command := &cobra.Command{
+		Use:   "timestamp --signature <signature_digest> [flags] <reference>",
+		Short: "Add a timestamp countersignature to an existing signature",
+		Long:  longMessage,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return errors.New("missing reference to the artifact: use `notation signature timestamp --help` to see what parameters are required")
+			}
+			opts.reference = args[0]
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if _, err := digest.Parse(opts.signatureDigest); err != nil {
+				return fmt.Errorf("invalid signature digest %q: %w", opts.signatureDigest, err)
+			}
+			if err := timestamp.ValidateFlags(cmd.Flags(), opts.tsaServerURL, opts.tsaRootCertificatePath, opts.tsaTrustStore); err != nil {
+				return err
+			}
+			return runSignatureTimestamp(cmd, opts)
+		},
+	}
+	opts.LoggingFlagOpts.ApplyFlags(command.Flags())
+	opts.Secure.ApplyFlags(command.Flags())
+	command.Flags().StringVar(&opts.signatureDigest, "signature", "", "digest of the signature manifest to timestamp, as listed by \"notation list\"")
+	command.Flags().StringVar(&opts.tsaServerURL, "timestamp-url", "", "RFC 3161 Timestamping Authority (TSA) server URL. If not specified, the TSA servers configured in config.json are tried in order")
+	command.Flags().StringVar(&opts.tsaRootCertificatePath, "timestamp-root-cert", "", "filepath of timestamp authority root certificate")
+	command.Flags().StringVar(&opts.tsaTrustStore, "timestamp-trust-store", "", "name of the trust store of type tsa containing the timestamp authority root certificates, for example \"myTSA\" for trust store \"tsa/myTSA\", or \"system\" to use the system root certificate bundle")
+	cmd.SetPflagTimestampTimeout(command.Flags(), &opts.tsaTimeout)
+	command.Flags().BoolVar(&opts.deleteOriginal, "delete-original", false, "delete the original signature manifest after the timestamped signature is pushed")
+	command.MarkFlagRequired("signature")
+	command.MarkFlagsMutuallyExclusive("timestamp-root-cert", "timestamp-trust-store")
+	return command
+}
+
+func runSignatureTimestamp(command *cobra.Command, opts *signatureTimestampOpts) error {
+	// set log level
+	ctx := opts.LoggingFlagOpts.InitializeLogger(command.Context())
+	logger := log.GetLogger(ctx)
+
+	// prepare timestamping
+	tsaServers, err := timestamp.Servers(true, opts.tsaServerURL, opts.tsaRootCertificatePath, opts.tsaTrustStore)
+	if err != nil {
+		return err
+	}
+	if len(tsaServers) == 0 {
+		return errors.New("timestamping: no tsa server is specified: use --timestamp-url or configure the tsa servers in config.json")
+	}
+	for _, server := range tsaServers {
+		logger.Infof("Configured to timestamp with TSA %q", server.URL)
+	}
+	timestamper, tsaRootCAs, err := timestamp.New(ctx, tsaServers, opts.tsaTimeout)
+	if err != nil {
+		return err
+	}
+	tsaRevocationValidator, err := clirev.NewRevocationValidator(ctx, purpose.Timestamping)
+	if err != nil {
+		return fmt.Errorf("failed to create timestamping revocation validator: %w", err)
+	}
+
+	// find the signature
+	sigRepo, err := getRemoteRepository(ctx, &opts.Secure, opts.reference, false)
+	if err != nil {
+		return err
+	}
+	manifestDesc, resolvedRef, err := resolveReferenceWithWarning(ctx, inputTypeRegistry, opts.reference, sigRepo, "timestamp signatures of")
+	if err != nil {
+		return err
+	}
+	sigManifestDesc, err := findSignature(ctx, sigRepo, manifestDesc, digest.Digest(opts.signatureDigest))
+	if err != nil {
+		return err
+	}
+	sigBlob, sigDesc, err := sigRepo.FetchSignatureBlob(ctx, sigManifestDesc)
+	if err != nil {
+		return fmt.Errorf("failed to fetch signature %s: %w", sigManifestDesc.Digest, err)
+	}
+	sigEnv, err := signature.ParseEnvelope(sigDesc.MediaType, sigBlob)
+	if err != nil {
+		return fmt.Errorf("failed to parse signature %s: %w", sigManifestDesc.Digest, err)
+	}
+	envContent, err := sigEnv.Content()
+	if err != nil {
+		return fmt.Errorf("failed to parse signature %s: %w", sigManifestDesc.Digest, err)
+	}
+	if err := checkTimestampable(&envContent.SignerInfo, time.Now()); err != nil {
+		return fmt.Errorf("cannot timestamp signature %s: %w", sigManifestDesc.Digest, err)
+	}
+
+	// timestamp the signature
+	timestampToken, err := timestamp.Countersign(ctx, &envContent.SignerInfo, timestamp.CountersignOptions{
+		Timestamper:         timestamper,
+		RootCAs:             tsaRootCAs,
+		RevocationValidator: tsaRevocationValidator,
+	})
+	if err != nil {
+		return fmt.Errorf("timestamp: %w", err)
+	}
+	newSigBlob, err := envelope.AddTimestampSignature(sigDesc.MediaType, sigBlob, timestampToken)
+	if err != nil {
+		return err
+	}
+	if err := checkTimestampedEnvelope(sigDesc.MediaType, newSigBlob, timestampToken); err != nil {
+		return fmt.Errorf("failed to add the timestamp countersignature to signature %s: %w", sigManifestDesc.Digest, err)
+	}
+
+	// push the timestamped signature
+	annotations := make(map[string]string, len(sigManifestDesc.Annotations))
+	for k, v := range sigManifestDesc.Annotations {
+		annotations[k] = v
+	}
+	// the creation time of the new signature manifest is set on push
+	delete(annotations, ocispec.AnnotationCreated)
+	_, newSigManifestDesc, err := sigRepo.PushSignature(ctx, sigDesc.MediaType, newSigBlob, manifestDesc, annotations)
+	if err != nil {
+		return fmt.Errorf("failed to push the timestamped signature: %w", err)
+	}
+	fmt.Printf("Successfully timestamped signature %s of %s\n", sigManifestDesc.Digest, resolvedRef)
+	fmt.Println("Timestamped signature:", newSigManifestDesc.Digest)
+
+	if !opts.deleteOriginal {
+		return nil
+	}
+	if err := deleteSignatureManifest(ctx, opts, sigManifestDesc); err != nil {
+		return fmt.Errorf("failed to delete the original signature %s: %w", sigManifestDesc.Digest, err)
+	}
+	fmt.Println("Deleted original signature:", sigManifestDesc.Digest)
+	return nil
+}
+
+// findSignature returns the descriptor of the signature manifest with digest
+// sigDigest among the signatures of the artifact described by manifestDesc.
+func findSignature(ctx context.Context, sigRepo notationregistry.Repository, manifestDesc ocispec.Descriptor, sigDigest digest.Digest) (ocispec.Descriptor, error) {
+	var sigManifestDesc ocispec.Descriptor
+	err := sigRepo.ListSignatures(ctx, manifestDesc, func(signatureManifests []ocispec.Descriptor) error {
+		for _, desc := range signatureManifests {
+			if desc.Digest == sigDigest {
+				sigManifestDesc = desc
+				return errSignatureFound
+			}
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errSignatureFound) {
+		return ocispec.Descriptor{}, err
+	}
+	if sigManifestDesc.Digest == "" {
+		return ocispec.Descriptor{}, fmt.Errorf("signature %s is not found among the signatures of the artifact", sigDigest)
+	}
+	return sigManifestDesc, nil
+}
+
+// checkTimestampable returns an error if the signature of signerInfo already
+// has a timestamp countersignature, or if any certificate in its certificate
+// chain is not valid at time now, in which case a timestamp obtained now
+// cannot be used to verify the signature.
+func checkTimestampable(signerInfo *signature.SignerInfo, now time.Time) error {
+	if signerInfo.SignedAttributes.SigningScheme != signature.SigningSchemeX509 {
+		return fmt.Errorf("signing scheme %q does not support timestamping", signerInfo.SignedAttributes.SigningScheme)
+	}
+	if len(signerInfo.UnsignedAttributes.TimestampSignature) > 0 {
+		return errors.New("the signature already has a timestamp countersignature")
+	}
+	if len(signerInfo.CertificateChain) == 0 {
+		return errors.New("the signature has no certificate chain")
+	}
+	for _, cert := range signerInfo.CertificateChain {
+		if now.Before(cert.NotBefore) {
+			return fmt.Errorf("certificate with subject %q is not valid until %s, so the timestamp would be out of its validity period", cert.Subject, cert.NotBefore.Format(time.RFC3339))
+		}
+		if now.After(cert.NotAfter) {
+			return fmt.Errorf("certificate with subject %q expired at %s, so the timestamp would be out of its validity period", cert.Subject, cert.NotAfter.Format(time.RFC3339))
+		}
+	}
+	return nil
+}
+
+// checkTimestampedEnvelope checks that the timestamped signature envelope
+// sigBlob is intact and carries timestampToken.
+func checkTimestampedEnvelope(mediaType string, sigBlob, timestampToken []byte) error {
+	sigEnv, err := signature.ParseEnvelope(mediaType, sigBlob)
+	if err != nil {
+		return err
+	}
+	envContent, err := sigEnv.Verify()
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(envContent.SignerInfo.UnsignedAttributes.TimestampSignature, timestampToken) {
+		return errors.New("timestamp countersignature is missing from the signature envelope")
+	}
+	return nil
+}
+
+// deleteSignatureManifest deletes the signature manifest sigManifestDesc from
+// the repository of opts.reference.
+func deleteSignatureManifest(ctx context.Context, opts *signatureTimestampOpts, sigManifestDesc ocispec.Descriptor) error {
+	ref, err := registry.ParseReference(opts.reference)
+	if err != nil {
+		return err
+	}
+	remoteRepo, err := registryutil.NewRepositoryClient(ctx, &opts.Secure, ref)
+	if err != nil {
+		return err
+	}
+	if err := remoteRepo.Delete(ctx, sigManifestDesc); err != nil {
+		fmt.Fprintln(os.Stderr, "Warning: the timestamped signature was pushed, but the original signature is kept.")
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"crypto/x509"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/notaryproject/notation-core-go/signature"
+	"github.com/notaryproject/notation-core-go/testhelper"
+	"github.com/notaryproject/notation/cmd/notation/internal/option"
+	"github.com/notaryproject/notation/pkg/configutil"
+)
+
+func TestSignatureTimestampCommand_BasicArgs(t *testing.T) {
+	opts := &signatureTimestampOpts{}
+	command := signatureTimestampCommand(opts)
+	expected := &signatureTimestampOpts{
+		reference: "ref",
+		Secure: option.Secure{
+			Username: "user",
+			Password: "password",
+		},
+		signatureDigest:        "sha256:73c803930ea3ba1e54bc25c2bdc53edd0284c62ed651fe7b00369da519a3c333",
+		tsaServerURL:           "http://tsa.example",
+		tsaRootCertificatePath: "root.crt",
+		tsaTimeout:             configutil.DefaultTimestampTimeout,
+		deleteOriginal:         true,
+	}
+	if err := command.ParseFlags([]string{
+		expected.reference,
+		"-u", expected.Username,
+		"--password", expected.Password,
+		"--signature", expected.signatureDigest,
+		"--timestamp-url", expected.tsaServerURL,
+		"--timestamp-root-cert", expected.tsaRootCertificatePath,
+		"--delete-original"}); err != nil {
+		t.Fatalf("Parse Flag failed: %v", err)
+	}
+	if err := command.Args(command, command.Flags().Args()); err != nil {
+		t.Fatalf("Parse args failed: %v", err)
+	}
+	if !reflect.DeepEqual(*expected, *opts) {
+		t.Fatalf("Expect signature timestamp opts: %v, got: %v", expected, opts)
+	}
+}
+
+func TestSignatureTimestampCommand_MissingArgs(t *testing.T) {
+	command := signatureTimestampCommand(nil)
+	if err := command.ParseFlags(nil); err != nil {
+		t.Fatalf("Parse Flag failed: %v", err)
+	}
+	if err := command.Args(command, command.Flags().Args()); err == nil {
+		t.Fatal("Parse Args expected error, but ok")
+	}
+}
+
+func TestSignatureTimestampCommand_InvalidDigest(t *testing.T) {
+	command := signatureTimestampCommand(nil)
+	command.SetArgs([]string{"ref", "--signature", "invalid"})
+	if err := command.Execute(); err == nil || !strings.Contains(err.Error(), "invalid signature digest") {
+		t.Fatalf("expected invalid signature digest error, but got %v", err)
+	}
+}
+
+func TestCheckTimestampable(t *testing.T) {
+	leaf := testhelper.GetRSALeafCertificate().Cert
+	certChain := []*x509.Certificate{leaf, testhelper.GetRSARootCertificate().Cert}
+	now := leaf.NotAfter.Add(-time.Hour)
+
+	t.Run("timestampable", func(t *testing.T) {
+		signerInfo := &signature.SignerInfo{
+			SignedAttributes: signature.SignedAttributes{SigningScheme: signature.SigningSchemeX509},
+			CertificateChain: certChain,
+		}
+		if err := checkTimestampable(signerInfo, now); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("already timestamped", func(t *testing.T) {
+		signerInfo := &signature.SignerInfo{
+			SignedAttributes:   signature.SignedAttributes{SigningScheme: signature.SigningSchemeX509},
+			UnsignedAttributes: signature.UnsignedAttributes{TimestampSignature: []byte("token")},
+			CertificateChain:   certChain,
+		}
+		if err := checkTimestampable(signerInfo, now); err == nil {
+			t.Fatal("expected error for timestamped signature")
+		}
+	})
+
+	t.Run("signing authority scheme", func(t *testing.T) {
+		signerInfo := &signature.SignerInfo{
+			SignedAttributes: signature.SignedAttributes{SigningScheme: signature.SigningSchemeX509SigningAuthority},
+			CertificateChain: certChain,
+		}
+		if err := checkTimestampable(signerInfo, now); err == nil {
+			t.Fatal("expected error for signing authority scheme")
+		}
+	})
+
+	t.Run("expired signing certificate", func(t *testing.T) {
+		signerInfo := &signature.SignerInfo{
+			SignedAttributes: signature.SignedAttributes{SigningScheme: signature.SigningSchemeX509},
+			CertificateChain: certChain,
+		}
+		if err := checkTimestampable(signerInfo, leaf.NotAfter.Add(time.Hour)); err == nil {
+			t.Fatal("expected error for expired signing certificate")
+		}
+	})
+
+	t.Run("expired root certificate", func(t *testing.T) {
+		expiredRoot := *certChain[1]
+		expiredRoot.NotAfter = now.Add(-time.Minute)
+		signerInfo := &signature.SignerInfo{
+			SignedAttributes: signature.SignedAttributes{SigningScheme: signature.SigningSchemeX509},
+			CertificateChain: []*x509.Certificate{leaf, &expiredRoot},
+		}
+		if err := checkTimestampable(signerInfo, now); err == nil || !strings.Contains(err.Error(), "expired at") {
+			t.Fatalf("expected error for expired root certificate, but got %v", err)
+		}
+	})
+
+	t.Run("root certificate not yet valid", func(t *testing.T) {
+		futureRoot := *certChain[1]
+		futureRoot.NotBefore = now.Add(time.Minute)
+		signerInfo := &signature.SignerInfo{
+			SignedAttributes: signature.SignedAttributes{SigningScheme: signature.SigningSchemeX509},
+			CertificateChain: []*x509.Certificate{leaf, &futureRoot},
+		}
+		if err := checkTimestampable(signerInfo, now); err == nil || !strings.Contains(err.Error(), "is not valid until") {
+			t.Fatalf("expected error for root certificate not yet valid, but got %v", err)
+		}
+	})
+
+	t.Run("signing certificate not yet valid", func(t *testing.T) {
+		signerInfo := &signature.SignerInfo{
+			SignedAttributes: signature.SignedAttributes{SigningScheme: signature.SigningSchemeX509},
+			CertificateChain: certChain,
+		}
+		if err := checkTimestampable(signerInfo, leaf.NotBefore.Add(-time.Hour)); err == nil {
+			t.Fatal("expected error for signing certificate not yet valid")
+		}
+	})
+
+	t.Run("no certificate chain", func(t *testing.T) {
+		signerInfo := &signature.SignerInfo{
+			SignedAttributes: signature.SignedAttributes{SigningScheme: signature.SigningSchemeX509},
+		}
+		if err := checkTimestampable(signerInfo, now); err == nil {
+			t.Fatal("expected error for missing certificate chain")
+		}
+	})
+}
@@ -0,0 +1,118 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tsa
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/notaryproject/notation/internal/cmd"
+	"github.com/notaryproject/notation/internal/tsa"
+	"github.com/spf13/cobra"
+)
+
+// shutdownTimeout is the maximum duration to wait for in-flight requests when
+// the timestamping authority is stopped.
+const shutdownTimeout = 5 * time.Second
+
+type serveOpts struct {
+	cmd.LoggingFlagOpts
+	name string
+	addr string
+}
+
+func serveCommand(opts *serveOpts) *cobra.Command {
+	if opts == nil {
+		opts = &serveOpts{}
+	}
+	command := &cobra.Command{
+		Use:   "serve [flags] <tsa_name>",
+		Short: "Serve a local test timestamping authority over HTTP",
+		Long: `Serve a local test timestamping authority over HTTP
+
+The timestamping authority implements the RFC 3161 HTTP transport and signs
+timestamp tokens with the key and certificate generated by
+"notation certificate generate-test --tsa". Its root CA certificate is stored
+in the trust store of type tsa named <tsa_name>. For testing purpose only.
+
+Example - Serve the test timestamping authority "wabbit-networks-tsa" at localhost:8080:
+  notation tsa serve "wabbit-networks-tsa"
+
+Example - Sign with the local test timestamping authority:
+  notation tsa serve --addr localhost:9000 "wabbit-networks-tsa"
+  notation sign --timestamp-url http://localhost:9000 --timestamp-root-cert <root_cert_path> <registry>/<repository>@<digest>
+`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return errors.New("missing timestamping authority name")
+			}
+			opts.name = args[0]
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return serve(cmd.Context(), opts)
+		},
+	}
+	opts.LoggingFlagOpts.ApplyFlags(command.Flags())
+	command.Flags().StringVar(&opts.addr, "addr", "localhost:8080", "TCP address to listen on, in the form of host:port")
+	return command
+}
+
+func serve(ctx context.Context, opts *serveOpts) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	// set log level
+	ctx = opts.LoggingFlagOpts.InitializeLogger(ctx)
+
+	handler, err := tsa.Load(opts.name)
+	if err != nil {
+		return err
+	}
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+	server := &http.Server{
+		Addr:              opts.addr,
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+		BaseContext: func(net.Listener) context.Context {
+			return ctx
+		},
+	}
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.ListenAndServe()
+	}()
+	fmt.Printf("Serving timestamping authority %q at http://%s\n", opts.name, opts.addr)
+	fmt.Println("Press Ctrl+C to stop")
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	fmt.Printf("Stopped timestamping authority %q\n", opts.name)
+	return nil
+}
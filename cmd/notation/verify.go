@@ -16,21 +16,26 @@ package main
 import (
 	"errors"
 	"fmt"
+	"os"
+	"time"
 
 	"github.com/notaryproject/notation-go"
+	"github.com/notaryproject/notation-go/log"
 	"github.com/notaryproject/notation/cmd/notation/internal/display"
 	"github.com/notaryproject/notation/cmd/notation/internal/experimental"
 	"github.com/notaryproject/notation/cmd/notation/internal/option"
 	"github.com/notaryproject/notation/cmd/notation/internal/verifier"
 	"github.com/notaryproject/notation/internal/cmd"
 	"github.com/notaryproject/notation/internal/ioutil"
+	clirev "github.com/notaryproject/notation/internal/revocation"
+	clicrl "github.com/notaryproject/notation/internal/revocation/crl"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/spf13/cobra"
 )
 
 type verifyOpts struct {
 	cmd.LoggingFlagOpts
-	SecureFlagOpts
+	option.Secure
 	option.Common
 	reference            string
 	pluginConfig         []string
@@ -39,6 +44,10 @@ type verifyOpts struct {
 	trustPolicyScope     string
 	inputType            inputType
 	maxSignatureAttempts int
+	revocationMode       string
+	ocspTimeout          time.Duration
+	crlTimeout           time.Duration
+	at                   string
 }
 
 func verifyCommand(opts *verifyOpts) *cobra.Command {
@@ -56,6 +65,12 @@ Example - Verify a signature on an OCI artifact identified by a digest:
 
 Example - Verify a signature on an OCI artifact identified by a tag  (Notation will resolve tag to digest):
   notation verify <registry>/<repository>:<tag>
+
+Example - Verify a signature on an OCI artifact checking revocation only against the local CRL cache:
+  notation verify --revocation-mode offline <registry>/<repository>@<digest>
+
+Example - Verify whether a signature on an OCI artifact would have been verified at a point in time in the past:
+  notation verify --at 2024-01-02T15:04:05Z <registry>/<repository>@<digest>
 `
 	experimentalExamples := `
 Example - [Experimental] Verify a signature on an OCI artifact referenced in an OCI layout using trust policy statement specified by scope.
@@ -90,10 +105,14 @@ Example - [Experimental] Verify a signature on an OCI artifact identified by a t
 		},
 	}
 	opts.LoggingFlagOpts.ApplyFlags(command.Flags())
-	opts.SecureFlagOpts.ApplyFlags(command.Flags())
+	opts.Secure.ApplyFlags(command.Flags())
 	command.Flags().StringArrayVar(&opts.pluginConfig, "plugin-config", nil, "{key}={value} pairs that are passed as it is to a plugin, if the verification is associated with a verification plugin, refer plugin documentation to set appropriate values")
 	cmd.SetPflagUserMetadata(command.Flags(), &opts.userMetadata, cmd.PflagUserMetadataVerifyUsage)
 	command.Flags().IntVar(&opts.maxSignatureAttempts, "max-signatures", 100, "maximum number of signatures to evaluate or examine")
+	cmd.SetPflagRevocationMode(command.Flags(), &opts.revocationMode)
+	cmd.SetPflagOCSPTimeout(command.Flags(), &opts.ocspTimeout)
+	cmd.SetPflagCRLTimeout(command.Flags(), &opts.crlTimeout)
+	cmd.SetPflagVerifyAt(command.Flags(), &opts.at)
 	command.Flags().BoolVar(&opts.ociLayout, "oci-layout", false, "[Experimental] verify the artifact stored as OCI image layout")
 	command.Flags().StringVar(&opts.trustPolicyScope, "scope", "", "[Experimental] set trust policy scope for artifact verification, required and can only be used when flag \"--oci-layout\" is set")
 	command.MarkFlagsRequiredTogether("oci-layout", "scope")
@@ -107,7 +126,39 @@ func runVerify(command *cobra.Command, opts *verifyOpts) error {
 
 	// initialize
 	displayHandler := display.NewVerifyHandler(opts.Printer)
-	sigVerifier, err := verifier.GetVerifier(ctx)
+	var at time.Time
+	if command.Flags().Changed(cmd.PflagVerifyAt.Name) {
+		var err error
+		if at, err = cmd.ParseVerifyAt(opts.at); err != nil {
+			return err
+		}
+		displayHandler.OnHistoricalEvaluation(at)
+	}
+	revocationMode, err := clirev.ParseMode(opts.revocationMode)
+	if err != nil {
+		return err
+	}
+	revocationOpts := clirev.Options{
+		Mode:          revocationMode,
+		OCSPTimeout:   opts.ocspTimeout,
+		CRLTimeout:    opts.crlTimeout,
+		CRLCacheStats: &clicrl.Stats{},
+	}
+	defer func() {
+		log.GetLogger(ctx).Infof("CRL cache statistics: %s", revocationOpts.CRLCacheStats)
+	}()
+	if revocationMode == clirev.ModeOffline {
+		revocationOpts.Recorder = &clirev.Recorder{}
+		defer func() {
+			ioutil.PrintUncheckedCerts(os.Stderr, revocationOpts.Recorder.UncheckedCerts())
+		}()
+	}
+	var sigVerifier verifier.Verifier
+	if at.IsZero() {
+		sigVerifier, err = verifier.GetVerifier(ctx, revocationOpts)
+	} else {
+		sigVerifier, err = verifier.GetHistoricalVerifier(ctx, revocationOpts, at)
+	}
 	if err != nil {
 		return err
 	}
@@ -128,7 +179,7 @@ func runVerify(command *cobra.Command, opts *verifyOpts) error {
 	reference := opts.reference
 	// always use the Referrers API, if not supported, automatically fallback to
 	// the referrers tag schema
-	sigRepo, err := getRepository(ctx, opts.inputType, reference, &opts.SecureFlagOpts, false)
+	sigRepo, err := getRepository(ctx, opts.inputType, reference, &opts.Secure, false)
 	if err != nil {
 		return err
 	}
@@ -148,6 +199,9 @@ func runVerify(command *cobra.Command, opts *verifyOpts) error {
 	_, outcomes, err := notation.Verify(ctx, sigVerifier, sigRepo, verifyOpts)
 	err = ioutil.ComposeVerificationFailurePrintout(outcomes, resolvedRef, err)
 	if err != nil {
+		if !at.IsZero() {
+			return fmt.Errorf("historical evaluation at %s: %w", at.Format(time.RFC3339), err)
+		}
 		return err
 	}
 	displayHandler.OnVerifySucceeded(outcomes, resolvedRef)
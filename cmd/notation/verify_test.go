@@ -16,6 +16,9 @@ package main
 import (
 	"reflect"
 	"testing"
+
+	"github.com/notaryproject/notation/cmd/notation/internal/option"
+	"github.com/notaryproject/notation/pkg/configutil"
 )
 
 func TestVerifyCommand_BasicArgs(t *testing.T) {
@@ -23,12 +26,15 @@ func TestVerifyCommand_BasicArgs(t *testing.T) {
 	command := verifyCommand(opts)
 	expected := &verifyOpts{
 		reference: "ref",
-		SecureFlagOpts: SecureFlagOpts{
+		Secure: option.Secure{
 			Username: "user",
 			Password: "password",
 		},
 		pluginConfig:         []string{"key1=val1"},
 		maxSignatureAttempts: 100,
+		revocationMode:       "online",
+		ocspTimeout:          configutil.DefaultOCSPTimeout,
+		crlTimeout:           configutil.DefaultCRLTimeout,
 	}
 	if err := command.ParseFlags([]string{
 		expected.reference,
@@ -50,17 +56,23 @@ func TestVerifyCommand_MoreArgs(t *testing.T) {
 	command := verifyCommand(opts)
 	expected := &verifyOpts{
 		reference: "ref",
-		SecureFlagOpts: SecureFlagOpts{
+		Secure: option.Secure{
 			InsecureRegistry: true,
 		},
 		pluginConfig:         []string{"key1=val1", "key2=val2"},
 		maxSignatureAttempts: 100,
+		revocationMode:       "offline",
+		ocspTimeout:          configutil.DefaultOCSPTimeout,
+		crlTimeout:           configutil.DefaultCRLTimeout,
+		at:                   "2024-01-02T15:04:05Z",
 	}
 	if err := command.ParseFlags([]string{
 		expected.reference,
 		"--insecure-registry",
 		"--plugin-config", "key1=val1",
-		"--plugin-config", "key2=val2"}); err != nil {
+		"--plugin-config", "key2=val2",
+		"--revocation-mode", "offline",
+		"--at", expected.at}); err != nil {
 		t.Fatalf("Parse Flag failed: %v", err)
 	}
 	if err := command.Args(command, command.Flags().Args()); err != nil {
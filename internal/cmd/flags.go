@@ -16,6 +16,7 @@ package cmd
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -101,6 +102,56 @@ var (
 		fs.StringArrayVarP(p, PflagUserMetadata.Name, PflagUserMetadata.Shorthand, nil, usage)
 	}
 
+	PflagRevocationMode = &pflag.Flag{
+		Name:  "revocation-mode",
+		Usage: "revocation check mode, options: \"online\", \"offline\". In offline mode, only cached CRLs are used and no network connection is made for revocation checks",
+	}
+	SetPflagRevocationMode = func(fs *pflag.FlagSet, p *string) {
+		settings, err := configutil.LoadSettingsOnce()
+		if err != nil || settings.RevocationMode == "" {
+			fs.StringVar(p, PflagRevocationMode.Name, "online", PflagRevocationMode.Usage)
+			return
+		}
+
+		// set revocationMode from config
+		fs.StringVar(p, PflagRevocationMode.Name, settings.RevocationMode, PflagRevocationMode.Usage)
+	}
+
+	PflagOCSPTimeout = &pflag.Flag{
+		Name:  "ocsp-timeout",
+		Usage: "timeout of OCSP requests for revocation checks",
+	}
+	SetPflagOCSPTimeout = func(fs *pflag.FlagSet, p *time.Duration) {
+		*p = loadTimeouts().OCSPTimeout()
+		fs.Var((*timeoutValue)(p), PflagOCSPTimeout.Name, PflagOCSPTimeout.Usage)
+	}
+
+	PflagCRLTimeout = &pflag.Flag{
+		Name:  "crl-timeout",
+		Usage: "timeout of downloading CRLs for revocation checks",
+	}
+	SetPflagCRLTimeout = func(fs *pflag.FlagSet, p *time.Duration) {
+		*p = loadTimeouts().CRLTimeout()
+		fs.Var((*timeoutValue)(p), PflagCRLTimeout.Name, PflagCRLTimeout.Usage)
+	}
+
+	PflagTimestampTimeout = &pflag.Flag{
+		Name:  "timestamp-timeout",
+		Usage: "timeout of requesting the timestamp countersignature from the TSA server",
+	}
+	SetPflagTimestampTimeout = func(fs *pflag.FlagSet, p *time.Duration) {
+		*p = loadTimeouts().TimestampTimeout()
+		fs.Var((*timeoutValue)(p), PflagTimestampTimeout.Name, PflagTimestampTimeout.Usage)
+	}
+
+	PflagVerifyAt = &pflag.Flag{
+		Name:  "at",
+		Usage: "evaluate certificate validity, signature expiry and revocation at the given RFC 3339 time (e.g. 2024-01-02T15:04:05Z) instead of now",
+	}
+	SetPflagVerifyAt = func(fs *pflag.FlagSet, p *string) {
+		fs.StringVar(p, PflagVerifyAt.Name, "", PflagVerifyAt.Usage)
+	}
+
 	PflagReferrersTag = &pflag.Flag{
 		Name: "force-referrers-tag",
 	}
@@ -109,6 +160,43 @@ var (
 	}
 )
 
+// loadTimeouts returns the timeouts in the notation settings, or the default
+// timeouts if the settings cannot be loaded.
+func loadTimeouts() configutil.Timeouts {
+	settings, err := configutil.LoadSettingsOnce()
+	if err != nil {
+		return configutil.Timeouts{}
+	}
+	return settings.Timeouts
+}
+
+// timeoutValue is a duration flag value of a timeout, which must be greater
+// than 0.
+type timeoutValue time.Duration
+
+// Set parses s as a timeout.
+func (t *timeoutValue) Set(s string) error {
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	if d <= 0 {
+		return fmt.Errorf("timeout must be greater than 0, but got %s", d)
+	}
+	*t = timeoutValue(d)
+	return nil
+}
+
+// String returns the timeout as a string.
+func (t *timeoutValue) String() string {
+	return time.Duration(*t).String()
+}
+
+// Type returns the type of the flag value.
+func (t *timeoutValue) Type() string {
+	return "duration"
+}
+
 // KeyValueSlice is a flag with type int
 type KeyValueSlice interface {
 	Set(value string) error
@@ -126,3 +214,47 @@ func ParseFlagMap(c []string, flagName string) (map[string]string, error) {
 	}
 	return m, nil
 }
+
+// ParseDuration parses a duration string. In addition to the units accepted
+// by time.ParseDuration, a whole number of days can be given with the "d"
+// prefix unit, optionally followed by smaller units, for example "30d" or
+// "1d12h".
+func ParseDuration(s string) (time.Duration, error) {
+	days, rest, found := strings.Cut(s, "d")
+	if !found {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q: %w", s, err)
+		}
+		return d, nil
+	}
+	n, err := strconv.Atoi(days)
+	if err != nil || n < 0 || days[0] < '0' || days[0] > '9' {
+		return 0, fmt.Errorf("invalid duration %q: number of days must be a non-negative integer", s)
+	}
+	d := time.Duration(n) * 24 * time.Hour
+	if rest == "" {
+		return d, nil
+	}
+	remainder, err := time.ParseDuration(rest)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q: %w", s, err)
+	}
+	if remainder < 0 {
+		return 0, fmt.Errorf("invalid duration %q: units following days must be non-negative", s)
+	}
+	return d + remainder, nil
+}
+
+// ParseVerifyAt parses the value of the --at flag, which must be an RFC 3339
+// time not later than now.
+func ParseVerifyAt(s string) (time.Time, error) {
+	at, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q of flag --%s: time must be in RFC 3339 format, e.g. 2024-01-02T15:04:05Z", s, PflagVerifyAt.Name)
+	}
+	if at.After(time.Now()) {
+		return time.Time{}, fmt.Errorf("invalid time %q of flag --%s: time cannot be in the future", s, PflagVerifyAt.Name)
+	}
+	return at, nil
+}
@@ -0,0 +1,111 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected time.Duration
+		wantErr  bool
+	}{
+		{input: "30d", expected: 30 * 24 * time.Hour},
+		{input: "0d", expected: 0},
+		{input: "12h30m", expected: 12*time.Hour + 30*time.Minute},
+		{input: "1d12h", expected: 36 * time.Hour},
+		{input: "2d3h20m", expected: 51*time.Hour + 20*time.Minute},
+		{input: "1d-2h", wantErr: true},
+		{input: "1d2d", wantErr: true},
+		{input: "+1d", wantErr: true},
+		{input: "-1d", wantErr: true},
+		{input: "1.5d", wantErr: true},
+		{input: "d", wantErr: true},
+		{input: "abc", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseDuration(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error: %v, but got %v", tt.wantErr, err)
+			}
+			if got != tt.expected {
+				t.Fatalf("expected %v, but got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestParseVerifyAt(t *testing.T) {
+	t.Run("valid time", func(t *testing.T) {
+		got, err := ParseVerifyAt("2024-01-02T15:04:05+08:00")
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := time.Date(2024, 1, 2, 7, 4, 5, 0, time.UTC)
+		if !got.Equal(expected) {
+			t.Fatalf("expected %v, but got %v", expected, got)
+		}
+	})
+
+	t.Run("invalid format", func(t *testing.T) {
+		for _, input := range []string{"", "2024-01-02", "2024-01-02 15:04:05"} {
+			if _, err := ParseVerifyAt(input); err == nil {
+				t.Fatalf("expected error for %q", input)
+			}
+		}
+	})
+
+	t.Run("future time", func(t *testing.T) {
+		future := time.Now().Add(time.Hour).Format(time.RFC3339)
+		if _, err := ParseVerifyAt(future); err == nil {
+			t.Fatalf("expected error for future time %q", future)
+		}
+	})
+}
+
+func TestSetPflagTimeouts(t *testing.T) {
+	var ocspTimeout, crlTimeout, timestampTimeout time.Duration
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	SetPflagOCSPTimeout(fs, &ocspTimeout)
+	SetPflagCRLTimeout(fs, &crlTimeout)
+	SetPflagTimestampTimeout(fs, &timestampTimeout)
+	timeouts := loadTimeouts()
+	if ocspTimeout != timeouts.OCSPTimeout() || crlTimeout != timeouts.CRLTimeout() || timestampTimeout != timeouts.TimestampTimeout() {
+		t.Fatalf("expected default timeouts %s, %s and %s, but got %s, %s and %s", timeouts.OCSPTimeout(), timeouts.CRLTimeout(), timeouts.TimestampTimeout(), ocspTimeout, crlTimeout, timestampTimeout)
+	}
+	if err := fs.Parse([]string{"--ocsp-timeout", "3s", "--crl-timeout", "1m", "--timestamp-timeout", "30s"}); err != nil {
+		t.Fatal(err)
+	}
+	if ocspTimeout != 3*time.Second || crlTimeout != time.Minute || timestampTimeout != 30*time.Second {
+		t.Fatalf("expected timeouts 3s, 1m and 30s, but got %s, %s and %s", ocspTimeout, crlTimeout, timestampTimeout)
+	}
+
+	for _, arg := range []string{"--ocsp-timeout=0", "--ocsp-timeout=-1s", "--crl-timeout=0s", "--crl-timeout=-5m", "--crl-timeout=invalid", "--timestamp-timeout=0", "--timestamp-timeout=-1s"} {
+		fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		SetPflagOCSPTimeout(fs, &ocspTimeout)
+		SetPflagCRLTimeout(fs, &crlTimeout)
+		SetPflagTimestampTimeout(fs, &timestampTimeout)
+		if err := fs.Parse([]string{arg}); err == nil {
+			t.Fatalf("expected error for %s", arg)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package envelope
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/notaryproject/notation-core-go/signature/cose"
+	"github.com/notaryproject/notation-core-go/signature/jws"
+	gocose "github.com/veraison/go-cose"
+)
+
+// headerTimestampSignature is the name of the unsigned attribute holding the
+// RFC 3161 timestamp countersignature in both JWS and COSE envelopes.
+//
+// Reference: https://github.com/notaryproject/specifications/blob/v1.0.0/specs/signature-envelope-jws.md#unsigned-attributes
+const headerTimestampSignature = "io.cncf.notary.timestampSignature"
+
+// AddTimestampSignature returns a copy of the signature envelope envelopeBlob
+// of mediaType with the RFC 3161 timestamp token set as the timestamp
+// countersignature in its unsigned attributes. The signed content of the
+// envelope is left intact.
+func AddTimestampSignature(mediaType string, envelopeBlob, timestampToken []byte) ([]byte, error) {
+	if len(timestampToken) == 0 {
+		return nil, errors.New("timestamp token cannot be empty")
+	}
+	switch mediaType {
+	case jws.MediaTypeEnvelope:
+		return addJWSTimestampSignature(envelopeBlob, timestampToken)
+	case cose.MediaTypeEnvelope:
+		return addCOSETimestampSignature(envelopeBlob, timestampToken)
+	}
+	return nil, fmt.Errorf("signature envelope media type %q not supported", mediaType)
+}
+
+// addJWSTimestampSignature sets the timestamp token in the unprotected header
+// of the JWS envelope.
+func addJWSTimestampSignature(envelopeBlob, timestampToken []byte) ([]byte, error) {
+	var envelope map[string]json.RawMessage
+	if err := json.Unmarshal(envelopeBlob, &envelope); err != nil {
+		return nil, fmt.Errorf("failed to parse jws envelope: %w", err)
+	}
+	header := make(map[string]json.RawMessage)
+	if rawHeader, ok := envelope["header"]; ok {
+		if err := json.Unmarshal(rawHeader, &header); err != nil {
+			return nil, fmt.Errorf("failed to parse unprotected header of jws envelope: %w", err)
+		}
+	}
+	token, err := json.Marshal(timestampToken)
+	if err != nil {
+		return nil, err
+	}
+	header[headerTimestampSignature] = token
+	rawHeader, err := json.Marshal(header)
+	if err != nil {
+		return nil, err
+	}
+	envelope["header"] = rawHeader
+	return json.Marshal(envelope)
+}
+
+// addCOSETimestampSignature sets the timestamp token in the unprotected
+// header of the COSE_Sign1 envelope.
+func addCOSETimestampSignature(envelopeBlob, timestampToken []byte) ([]byte, error) {
+	var msg gocose.Sign1Message
+	if err := msg.UnmarshalCBOR(envelopeBlob); err != nil {
+		return nil, fmt.Errorf("failed to parse cose envelope: %w", err)
+	}
+	if msg.Headers.Unprotected == nil {
+		msg.Headers.Unprotected = gocose.UnprotectedHeader{}
+	}
+	msg.Headers.Unprotected[headerTimestampSignature] = timestampToken
+	// the raw unprotected header takes precedence over the decoded one when
+	// encoding, while the raw protected header is kept as it is signed.
+	msg.Headers.RawUnprotected = nil
+	return msg.MarshalCBOR()
+}
@@ -0,0 +1,100 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package envelope
+
+import (
+	"bytes"
+	"crypto/x509"
+	"testing"
+	"time"
+
+	"github.com/notaryproject/notation-core-go/signature"
+	"github.com/notaryproject/notation-core-go/signature/cose"
+	"github.com/notaryproject/notation-core-go/signature/jws"
+	"github.com/notaryproject/notation-core-go/testhelper"
+)
+
+func TestAddTimestampSignature(t *testing.T) {
+	leaf := testhelper.GetRSALeafCertificate()
+	certChain := []*x509.Certificate{leaf.Cert, testhelper.GetRSARootCertificate().Cert}
+	signer, err := signature.NewLocalSigner(certChain, leaf.PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token := []byte("timestamp token")
+
+	for _, mediaType := range []string{jws.MediaTypeEnvelope, cose.MediaTypeEnvelope} {
+		t.Run(mediaType, func(t *testing.T) {
+			env, err := signature.NewEnvelope(mediaType)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sigBlob, err := env.Sign(&signature.SignRequest{
+				Payload: signature.Payload{
+					ContentType: MediaTypePayloadV1,
+					Content:     []byte(`{"targetArtifact":{}}`),
+				},
+				Signer:        signer,
+				SigningTime:   time.Now(),
+				SigningScheme: signature.SigningSchemeX509,
+				SigningAgent:  "test",
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			timestamped, err := AddTimestampSignature(mediaType, sigBlob, token)
+			if err != nil {
+				t.Fatal(err)
+			}
+			timestampedEnv, err := signature.ParseEnvelope(mediaType, timestamped)
+			if err != nil {
+				t.Fatal(err)
+			}
+			content, err := timestampedEnv.Verify()
+			if err != nil {
+				t.Fatalf("expected the timestamped envelope to be intact, but got %v", err)
+			}
+			if !bytes.Equal(content.SignerInfo.UnsignedAttributes.TimestampSignature, token) {
+				t.Fatalf("expected timestamp signature %q, but got %q", token, content.SignerInfo.UnsignedAttributes.TimestampSignature)
+			}
+			if content.SignerInfo.UnsignedAttributes.SigningAgent != "test" {
+				t.Fatalf("expected signing agent to be kept, but got %q", content.SignerInfo.UnsignedAttributes.SigningAgent)
+			}
+			if len(content.SignerInfo.CertificateChain) != len(certChain) {
+				t.Fatalf("expected certificate chain to be kept, but got %d certificates", len(content.SignerInfo.CertificateChain))
+			}
+		})
+	}
+
+	t.Run("empty token", func(t *testing.T) {
+		if _, err := AddTimestampSignature(jws.MediaTypeEnvelope, []byte("{}"), nil); err == nil {
+			t.Fatal("expected error for empty timestamp token")
+		}
+	})
+
+	t.Run("unsupported media type", func(t *testing.T) {
+		if _, err := AddTimestampSignature("application/unknown", []byte("{}"), token); err == nil {
+			t.Fatal("expected error for unsupported media type")
+		}
+	})
+
+	t.Run("invalid envelope", func(t *testing.T) {
+		for _, mediaType := range []string{jws.MediaTypeEnvelope, cose.MediaTypeEnvelope} {
+			if _, err := AddTimestampSignature(mediaType, []byte("invalid"), token); err == nil {
+				t.Fatalf("expected error for invalid %s envelope", mediaType)
+			}
+		}
+	})
+}
@@ -0,0 +1,116 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httputil
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	nx509 "github.com/notaryproject/notation/internal/x509"
+	"github.com/notaryproject/notation/pkg/configutil"
+)
+
+// TransportOptions contains the options of creating an http.RoundTripper.
+type TransportOptions struct {
+	// Proxy is the URL of the HTTP(S) proxy. If nil, the proxy is taken from
+	// the environment variables HTTP_PROXY, HTTPS_PROXY and NO_PROXY.
+	Proxy *url.URL
+
+	// CABundle is the path of a PEM or DER certificate bundle trusted in
+	// addition to the system root certificates. Ignored if empty.
+	CABundle string
+}
+
+// NewTransport returns an http.RoundTripper cloned from
+// http.DefaultTransport with the proxy and the CA bundle in opts.
+func NewTransport(opts TransportOptions) (http.RoundTripper, error) {
+	if opts.Proxy == nil && opts.CABundle == "" {
+		return http.DefaultTransport, nil
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if opts.Proxy != nil {
+		transport.Proxy = http.ProxyURL(opts.Proxy)
+	}
+	if opts.CABundle != "" {
+		rootCAs, err := newRootCAs(opts.CABundle)
+		if err != nil {
+			return nil, err
+		}
+		if transport.TLSClientConfig == nil {
+			transport.TLSClientConfig = &tls.Config{}
+		}
+		transport.TLSClientConfig.RootCAs = rootCAs
+	}
+	return transport, nil
+}
+
+// NewTransportForEndpoint returns an http.RoundTripper with the proxy of the
+// endpoint class and the CA bundle configured in the notation settings.
+func NewTransportForEndpoint(class configutil.EndpointClass) (http.RoundTripper, error) {
+	settings, err := configutil.LoadSettingsOnce()
+	if err != nil {
+		return nil, err
+	}
+	proxy, err := settings.Proxies.Proxy(class)
+	if err != nil {
+		return nil, err
+	}
+	return NewTransport(TransportOptions{
+		Proxy:    proxy,
+		CABundle: settings.CABundle,
+	})
+}
+
+// NewClientForEndpoint returns an *http.Client with debug log, user agent,
+// timeout and the transport of the endpoint class set.
+func NewClientForEndpoint(ctx context.Context, class configutil.EndpointClass, timeout time.Duration) (*http.Client, error) {
+	transport, err := NewTransportForEndpoint(class)
+	if err != nil {
+		return nil, err
+	}
+	return NewClient(ctx, &http.Client{
+		Transport: transport,
+		Timeout:   timeout,
+	}), nil
+}
+
+// newRootCAs returns the system root certificates along with the
+// certificates in the CA bundle at path.
+func newRootCAs(path string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+	}
+	certs, err := nx509.ParseCertificates(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CA bundle %s: %w", path, err)
+	}
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("no certificate found in CA bundle %s", path)
+	}
+	rootCAs, err := x509.SystemCertPool()
+	if err != nil || rootCAs == nil {
+		rootCAs = x509.NewCertPool()
+	}
+	for _, cert := range certs {
+		rootCAs.AddCert(cert)
+	}
+	return rootCAs, nil
+}
@@ -0,0 +1,84 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httputil
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNewTransport(t *testing.T) {
+	t.Run("default transport", func(t *testing.T) {
+		transport, err := NewTransport(TransportOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if transport != http.DefaultTransport {
+			t.Fatal("expected http.DefaultTransport")
+		}
+	})
+
+	t.Run("proxy", func(t *testing.T) {
+		proxyURL, _ := url.Parse("http://proxy.example:3128")
+		transport, err := NewTransport(TransportOptions{Proxy: proxyURL})
+		if err != nil {
+			t.Fatal(err)
+		}
+		req := httptest.NewRequest(http.MethodGet, "http://ocsp.example", nil)
+		got, err := transport.(*http.Transport).Proxy(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.String() != proxyURL.String() {
+			t.Fatalf("expected proxy %s, but got %s", proxyURL, got)
+		}
+	})
+
+	t.Run("ca bundle", func(t *testing.T) {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		defer server.Close()
+		caBundle := filepath.Join(t.TempDir(), "ca.pem")
+		data := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+		if err := os.WriteFile(caBundle, data, 0600); err != nil {
+			t.Fatal(err)
+		}
+		transport, err := NewTransport(TransportOptions{CABundle: caBundle})
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	})
+
+	t.Run("invalid ca bundle", func(t *testing.T) {
+		caBundle := filepath.Join(t.TempDir(), "ca.pem")
+		if err := os.WriteFile(caBundle, nil, 0600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := NewTransport(TransportOptions{CABundle: caBundle}); err == nil {
+			t.Fatal("expected error for empty CA bundle")
+		}
+		if _, err := NewTransport(TransportOptions{CABundle: filepath.Join(t.TempDir(), "missing.pem")}); err == nil {
+			t.Fatal("expected error for missing CA bundle")
+		}
+	})
+}
@@ -14,16 +14,19 @@
 package ioutil
 
 import (
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"io"
 	"io/fs"
 	"path/filepath"
 	"text/tabwriter"
+	"time"
 
 	"github.com/notaryproject/notation-go"
 	"github.com/notaryproject/notation-go/config"
 	"github.com/notaryproject/notation-go/verifier/truststore"
+	"github.com/notaryproject/notation/internal/revocation"
 )
 
 func newTabWriter(w io.Writer) *tabwriter.Writer {
@@ -71,6 +74,38 @@ func PrintCertMap(w io.Writer, certPaths []string) error {
 	return tw.Flush()
 }
 
+// PrintSystemCerts prints out the certificates of the system root certificate
+// bundle at bundlePath. Nothing is printed if there is no certificate.
+func PrintSystemCerts(w io.Writer, bundlePath string, certs []*x509.Certificate) error {
+	if len(certs) == 0 {
+		return nil
+	}
+	fmt.Fprintf(w, "System root certificate bundle: %s\n", bundlePath)
+	tw := newTabWriter(w)
+	fmt.Fprintln(tw, "SUBJECT\tEXPIRY\t")
+	for _, cert := range certs {
+		fmt.Fprintf(tw, "%s\t%s\t\n", cert.Subject, cert.NotAfter.Format(time.RFC3339))
+	}
+	return tw.Flush()
+}
+
+// PrintUncheckedCerts prints out the certificates whose revocation status
+// could not be checked in offline revocation mode. Nothing is printed if there
+// is no such certificate.
+func PrintUncheckedCerts(w io.Writer, certs []revocation.UncheckedCert) {
+	if len(certs) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "Warning: revocation status of %d certificate(s) could not be checked in offline mode:\n", len(certs))
+	for _, cert := range certs {
+		fmt.Fprintf(w, "  - %s\n", cert.Subject)
+		for _, err := range cert.Errors {
+			fmt.Fprintf(w, "      %v\n", err)
+		}
+	}
+	fmt.Fprintln(w, "Run \"notation cache crl prefetch\" with network access to cache the CRLs.")
+}
+
 // ComposeVerificationFailurePrintout composes verification failure print out.
 func ComposeVerificationFailurePrintout(outcomes []*notation.VerificationOutcome, reference string, err error) error {
 	if verificationErr := parseErrorOnVerificationFailure(err); verificationErr != nil {
@@ -60,6 +60,39 @@ func WriteFileWithPermission(path string, data []byte, perm fs.FileMode, overwri
 	return file.Close()
 }
 
+// WriteFileAtomic writes data to path with all parent directories created by
+// renaming a temporary file in the same directory, so that concurrent readers
+// never see a partial file.
+func WriteFileAtomic(path string, data []byte, perm fs.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	tempFile, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	tempPath := tempFile.Name()
+	if _, err := tempFile.Write(data); err != nil {
+		tempFile.Close()
+		os.Remove(tempPath)
+		return err
+	}
+	if err := tempFile.Chmod(perm); err != nil {
+		tempFile.Close()
+		os.Remove(tempPath)
+		return err
+	}
+	if err := tempFile.Close(); err != nil {
+		os.Remove(tempPath)
+		return err
+	}
+	if err := os.Rename(tempPath, path); err != nil {
+		os.Remove(tempPath)
+		return err
+	}
+	return nil
+}
+
 // CopyToDir copies the src file to dst. Existing file will be overwritten.
 func CopyToDir(src, dst string) (int64, error) {
 	sourceFileStat, err := os.Stat(src)
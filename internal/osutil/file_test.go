@@ -69,6 +69,25 @@ func TestWriteFile(t *testing.T) {
 	})
 }
 
+func TestWriteFileAtomic(t *testing.T) {
+	tempDir := t.TempDir()
+	filename := filepath.Join(tempDir, "a", "file.txt")
+	if err := WriteFileAtomic(filename, []byte("old"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteFileAtomic(filename, []byte("new"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	validFileContent(t, filename, []byte("new"))
+	files, err := os.ReadDir(filepath.Dir(filename))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 {
+		t.Fatalf("expected no temporary file left, but got %d files", len(files))
+	}
+}
+
 func TestWriteFileWithPermission(t *testing.T) {
 	t.Run("write without override", func(t *testing.T) {
 		tempDir := t.TempDir()
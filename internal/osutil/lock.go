@@ -0,0 +1,108 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package osutil
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// staleLockAge is the age after which a lock file is considered abandoned by
+// a crashed process and is removed.
+const staleLockAge = 30 * time.Second
+
+// lockRetryInterval is the interval between attempts to acquire a lock.
+const lockRetryInterval = 10 * time.Millisecond
+
+// takeoverFileExt is the extension of the file serializing the takeovers of
+// a stale lock file.
+const takeoverFileExt = ".takeover"
+
+// ErrLockTimeout is returned by LockFile if the lock is not acquired within
+// the timeout.
+var ErrLockTimeout = errors.New("timed out acquiring file lock")
+
+// LockFile acquires an exclusive lock shared across processes by creating the
+// lock file at path exclusively, and returns the function to release the
+// lock. Lock files older than 30 seconds are considered abandoned and are
+// taken over, see removeStaleLock.
+func LockFile(path string, timeout time.Duration) (unlock func() error, err error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, err
+	}
+	deadline := time.Now().Add(timeout)
+	for {
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+		if err == nil {
+			file.Close()
+			return func() error {
+				if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+					return err
+				}
+				return nil
+			}, nil
+		}
+		if !errors.Is(err, fs.ErrExist) {
+			return nil, err
+		}
+		if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) > staleLockAge {
+			// the lock holder has crashed without releasing the lock
+			if removeStaleLock(path, info) {
+				continue
+			}
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("%w %s", ErrLockTimeout, path)
+		}
+		time.Sleep(lockRetryInterval)
+	}
+}
+
+// removeStaleLock removes the lock file at path if it is still the stale
+// lock file described by stale, and returns true if it is removed.
+//
+// Concurrent takers may all find the same lock file stale. Without
+// coordination, a taker could remove the fresh lock file created by another
+// taker that has just removed the stale one, letting both hold the lock. To
+// prevent it, the check and the removal are done while holding a takeover
+// file next to the lock file, and the lock file is only removed if it has the
+// same inode and modification time as the stale one.
+func removeStaleLock(path string, stale fs.FileInfo) bool {
+	takeoverPath := path + takeoverFileExt
+	file, err := os.OpenFile(takeoverPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		if info, err := os.Stat(takeoverPath); err == nil && time.Since(info.ModTime()) > staleLockAge {
+			// the taker has crashed while taking over the lock
+			os.Remove(takeoverPath)
+		}
+		return false
+	}
+	file.Close()
+	defer os.Remove(takeoverPath)
+
+	info, err := os.Stat(path)
+	if err != nil {
+		// removed by another taker
+		return errors.Is(err, fs.ErrNotExist)
+	}
+	if !os.SameFile(info, stale) || !info.ModTime().Equal(stale.ModTime()) {
+		// replaced by a fresh lock file
+		return false
+	}
+	return os.Remove(path) == nil
+}
@@ -0,0 +1,133 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package osutil
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLockFile(t *testing.T) {
+	lockPath := filepath.Join(t.TempDir(), "a", "file.lock")
+	unlock, err := LockFile(lockPath, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the lock is held
+	if _, err := LockFile(lockPath, 50*time.Millisecond); !errors.Is(err, ErrLockTimeout) {
+		t.Fatalf("expected ErrLockTimeout, but got %v", err)
+	}
+
+	// the lock is released
+	if err := unlock(); err != nil {
+		t.Fatal(err)
+	}
+	unlock, err = LockFile(lockPath, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer unlock()
+
+	// the lock is abandoned
+	past := time.Now().Add(-2 * staleLockAge)
+	if err := os.Chtimes(lockPath, past, past); err != nil {
+		t.Fatal(err)
+	}
+	unlock2, err := LockFile(lockPath, 50*time.Millisecond)
+	if err != nil {
+		t.Fatalf("expected abandoned lock to be taken over, but got %v", err)
+	}
+	if err := unlock2(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLockFileConcurrentTakeover(t *testing.T) {
+	lockPath := filepath.Join(t.TempDir(), "file.lock")
+	if err := os.WriteFile(lockPath, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	past := time.Now().Add(-2 * staleLockAge)
+	if err := os.Chtimes(lockPath, past, past); err != nil {
+		t.Fatal(err)
+	}
+
+	// concurrent takers of the stale lock hold the lock one at a time
+	const takers = 8
+	var holders, maxHolders atomic.Int32
+	var wg sync.WaitGroup
+	errs := make(chan error, takers)
+	for i := 0; i < takers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			unlock, err := LockFile(lockPath, 5*time.Second)
+			if err != nil {
+				errs <- err
+				return
+			}
+			n := holders.Add(1)
+			for {
+				m := maxHolders.Load()
+				if n <= m || maxHolders.CompareAndSwap(m, n) {
+					break
+				}
+			}
+			time.Sleep(20 * time.Millisecond)
+			holders.Add(-1)
+			if err := unlock(); err != nil {
+				errs <- err
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatal(err)
+	}
+	if got := maxHolders.Load(); got != 1 {
+		t.Fatalf("expected at most 1 holder of the lock, but got %d", got)
+	}
+	if _, err := os.Stat(lockPath + takeoverFileExt); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected takeover file to be removed, but got %v", err)
+	}
+}
+
+func TestLockFileStaleTakeover(t *testing.T) {
+	lockPath := filepath.Join(t.TempDir(), "file.lock")
+	past := time.Now().Add(-2 * staleLockAge)
+	for _, path := range []string{lockPath, lockPath + takeoverFileExt} {
+		if err := os.WriteFile(path, nil, 0600); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, past, past); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the takeover file abandoned by a crashed taker is removed as well
+	unlock, err := LockFile(lockPath, time.Second)
+	if err != nil {
+		t.Fatalf("expected abandoned lock to be taken over, but got %v", err)
+	}
+	if err := unlock(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package revocation
+
+import (
+	"crypto/x509"
+	"math/big"
+	"sync"
+)
+
+// CacheHits records the OCSP responses and the CRLs served from the cache,
+// per certificate and URL, so that a response served from the cache for one
+// certificate is not reported for another certificate checked against the
+// same URL. It is safe for concurrent use.
+type CacheHits struct {
+	mu   sync.Mutex
+	hits map[cacheHit]struct{}
+}
+
+// cacheHit identifies the certificates a cached response is served for,
+// either by the serial number for OCSP responses or by the issuer for CRLs,
+// along with the URL of the OCSP responder or the CRL.
+type cacheHit struct {
+	serialNumber string
+	issuer       string
+	url          string
+}
+
+// RecordOCSP records a cache hit of the OCSP responder at url for the
+// certificate with serialNumber.
+func (h *CacheHits) RecordOCSP(serialNumber *big.Int, url string) {
+	h.record(cacheHit{serialNumber: serialNumber.Text(16), url: url})
+}
+
+// RecordCRL records a cache hit of crl downloaded from url, which applies to
+// the certificates issued by the issuer of crl.
+func (h *CacheHits) RecordCRL(crl *x509.RevocationList, url string) {
+	h.record(cacheHit{issuer: string(crl.RawIssuer), url: url})
+}
+
+// Contains returns true if a cache hit of url is recorded for cert.
+func (h *CacheHits) Contains(cert *x509.Certificate, url string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.hits[cacheHit{serialNumber: cert.SerialNumber.Text(16), url: url}]; ok {
+		return true
+	}
+	_, ok := h.hits[cacheHit{issuer: string(cert.RawIssuer), url: url}]
+	return ok
+}
+
+func (h *CacheHits) record(hit cacheHit) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.hits == nil {
+		h.hits = make(map[cacheHit]struct{})
+	}
+	h.hits[hit] = struct{}{}
+}
@@ -0,0 +1,51 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package revocation
+
+import (
+	"crypto/x509"
+	"testing"
+
+	"github.com/notaryproject/notation-core-go/testhelper"
+)
+
+func TestCacheHits(t *testing.T) {
+	tuples := testhelper.GetRevokableRSAChainWithRevocations(3, true, true)
+	leaf, intermediate := tuples[0].Cert, tuples[1].Cert
+	const ocspURL = "http://ocsp.test"
+	const crlURL = "http://crl.test/ca.crl"
+
+	hits := &CacheHits{}
+	if hits.Contains(leaf, ocspURL) {
+		t.Fatal("expected no cache hit")
+	}
+
+	// the responder is shared by the leaf and the intermediate
+	hits.RecordOCSP(leaf.SerialNumber, ocspURL)
+	if !hits.Contains(leaf, ocspURL) {
+		t.Fatal("expected cache hit of the leaf")
+	}
+	if hits.Contains(intermediate, ocspURL) {
+		t.Fatal("expected no cache hit of the intermediate")
+	}
+
+	// the CRL applies to the certificates issued by the intermediate
+	hits.RecordCRL(&x509.RevocationList{RawIssuer: leaf.RawIssuer}, crlURL)
+	if !hits.Contains(leaf, crlURL) {
+		t.Fatal("expected CRL cache hit of the leaf")
+	}
+	if hits.Contains(intermediate, crlURL) {
+		t.Fatal("expected no CRL cache hit of the intermediate")
+	}
+}
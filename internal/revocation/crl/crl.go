@@ -19,11 +19,34 @@ import (
 	"fmt"
 	"os"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	corecrl "github.com/notaryproject/notation-core-go/revocation/crl"
 	"github.com/notaryproject/notation-go/log"
 )
 
+// Stats contains the statistics of CRL cache accesses. It is safe for
+// concurrent use.
+type Stats struct {
+	// Hits is the number of CRLs served from the cache.
+	Hits atomic.Int64
+
+	// Misses is the number of CRLs not found in the cache or expired.
+	Misses atomic.Int64
+
+	// Errors is the number of failed reads and writes of the cache.
+	Errors atomic.Int64
+
+	// Writes is the number of CRLs stored in the cache.
+	Writes atomic.Int64
+}
+
+// String returns the summary of the statistics.
+func (s *Stats) String() string {
+	return fmt.Sprintf("%d hit(s), %d miss(es), %d write(s), %d error(s)", s.Hits.Load(), s.Misses.Load(), s.Writes.Load(), s.Errors.Load())
+}
+
 // CacheWithLog implements corecrl.Cache with logging
 type CacheWithLog struct {
 	corecrl.Cache
@@ -32,6 +55,13 @@ type CacheWithLog struct {
 	//warning
 	DiscardCacheError bool
 
+	// OnCacheHit is called with the URL and the bundle of the CRL if a CRL
+	// not expired is served from the cache, if set.
+	OnCacheHit func(url string, bundle *corecrl.Bundle)
+
+	// Stats records the statistics of the cache accesses, if set.
+	Stats *Stats
+
 	// logDiscardCrlCacheErrorOnce guarantees the discard cache error
 	// warning is logged only once
 	logDiscardCrlCacheErrorOnce sync.Once
@@ -46,13 +76,23 @@ func (c *CacheWithLog) Get(ctx context.Context, url string) (*corecrl.Bundle, er
 
 	bundle, err := c.Cache.Get(ctx, url)
 	if err != nil && !errors.Is(err, corecrl.ErrCacheMiss) {
+		c.recordStats(func(s *Stats) { s.Errors.Add(1) })
 		if c.DiscardCacheError {
 			c.logDiscardCrlCacheErrorOnce.Do(c.logDiscardCrlCacheError)
 		}
 		logger.Debug(err.Error())
 		return nil, err
 	}
-	return bundle, err
+	if err != nil || bundle == nil || bundle.BaseCRL == nil || !time.Now().Before(bundle.BaseCRL.NextUpdate) {
+		// expired CRLs are downloaded again by the fetcher
+		c.recordStats(func(s *Stats) { s.Misses.Add(1) })
+		return bundle, err
+	}
+	c.recordStats(func(s *Stats) { s.Hits.Add(1) })
+	if c.OnCacheHit != nil {
+		c.OnCacheHit(url, bundle)
+	}
+	return bundle, nil
 }
 
 // Set stores the CRL bundle with the given url
@@ -64,15 +104,24 @@ func (c *CacheWithLog) Set(ctx context.Context, url string, bundle *corecrl.Bund
 
 	err := c.Cache.Set(ctx, url, bundle)
 	if err != nil {
+		c.recordStats(func(s *Stats) { s.Errors.Add(1) })
 		if c.DiscardCacheError {
 			c.logDiscardCrlCacheErrorOnce.Do(c.logDiscardCrlCacheError)
 		}
 		logger.Debug(err.Error())
 		return err
 	}
+	c.recordStats(func(s *Stats) { s.Writes.Add(1) })
 	return nil
 }
 
+// recordStats updates c.Stats with update if c.Stats is set.
+func (c *CacheWithLog) recordStats(update func(s *Stats)) {
+	if c.Stats != nil {
+		update(c.Stats)
+	}
+}
+
 // logDiscardCrlCacheError logs the warning when CRL cache error is
 // discarded
 func (c *CacheWithLog) logDiscardCrlCacheError() {
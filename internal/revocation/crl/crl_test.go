@@ -15,10 +15,12 @@ package crl
 
 import (
 	"context"
+	"crypto/x509"
 	"errors"
 	"os"
 	"sync"
 	"testing"
+	"time"
 
 	corecrl "github.com/notaryproject/notation-core-go/revocation/crl"
 )
@@ -79,6 +81,30 @@ func TestSet(t *testing.T) {
 	}
 }
 
+func TestStats(t *testing.T) {
+	stats := &Stats{}
+	var hits []string
+	onCacheHit := func(url string, _ *corecrl.Bundle) {
+		hits = append(hits, url)
+	}
+	for _, cache := range []*CacheWithLog{
+		{Cache: &dummyCache{}, Stats: stats, OnCacheHit: onCacheHit},
+		{Cache: &dummyCache{cacheMiss: true}, Stats: stats, OnCacheHit: onCacheHit},
+		{Cache: &dummyCache{bundle: &corecrl.Bundle{BaseCRL: &x509.RevocationList{NextUpdate: time.Now().Add(-time.Hour)}}}, Stats: stats, OnCacheHit: onCacheHit},
+		{Cache: &dummyCache{bundle: &corecrl.Bundle{BaseCRL: &x509.RevocationList{NextUpdate: time.Now().Add(time.Hour)}}, setSuccess: true}, Stats: stats, OnCacheHit: onCacheHit},
+	} {
+		cache.Get(context.Background(), "http://crl.example.com")
+		cache.Set(context.Background(), "http://crl.example.com", nil)
+	}
+	expected := "1 hit(s), 2 miss(es), 1 write(s), 4 error(s)"
+	if got := stats.String(); got != expected {
+		t.Fatalf("expected stats %q, but got %q", expected, got)
+	}
+	if len(hits) != 1 {
+		t.Fatalf("expected 1 cache hit, but got %d", len(hits))
+	}
+}
+
 func TestLogDiscardErrorOnce(t *testing.T) {
 	cache := &CacheWithLog{
 		Cache:             &dummyCache{},
@@ -118,9 +144,13 @@ func TestLogDiscardErrorOnce(t *testing.T) {
 type dummyCache struct {
 	cacheMiss  bool
 	setSuccess bool
+	bundle     *corecrl.Bundle
 }
 
 func (d *dummyCache) Get(ctx context.Context, url string) (*corecrl.Bundle, error) {
+	if d.bundle != nil {
+		return d.bundle, nil
+	}
 	if d.cacheMiss {
 		return nil, corecrl.ErrCacheMiss
 	}
@@ -0,0 +1,318 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crl
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	corecrl "github.com/notaryproject/notation-core-go/revocation/crl"
+	"github.com/notaryproject/notation-go/log"
+	"github.com/notaryproject/notation-go/verifier/crl"
+	"github.com/notaryproject/notation/internal/osutil"
+)
+
+const (
+	// urlFileExt is the extension of the file recording the URL of a cache
+	// entry next to the cache entry.
+	urlFileExt = ".url"
+
+	// lockFileExt is the extension of the lock file of a cache entry.
+	lockFileExt = ".lock"
+
+	// lockTimeout is the timeout of acquiring the lock of a cache entry.
+	lockTimeout = 5 * time.Second
+)
+
+// FileCache implements corecrl.Cache on top of the notation-go file cache.
+//
+// Since the file cache names the cache entries by the SHA-256 digests of the
+// CRL URLs, FileCache records the URL of each entry it stores in a sibling
+// file so that the cache entries can be listed by their URLs.
+//
+// FileCache is safe for concurrent use across processes sharing the cache
+// directory. Entries are replaced by atomic renames so that readers never see
+// partial files, and writers of the same entry are serialized by a lock file.
+// Corrupted entries are removed on read and reported as cache misses, so that
+// the CRLs are downloaded again.
+type FileCache struct {
+	*crl.FileCache
+
+	// root is the root directory of the cache
+	root string
+}
+
+// NewFileCache creates a FileCache with root as the root directory.
+func NewFileCache(root string) (*FileCache, error) {
+	fileCache, err := crl.NewFileCache(root)
+	if err != nil {
+		return nil, err
+	}
+	return &FileCache{
+		FileCache: fileCache,
+		root:      root,
+	}, nil
+}
+
+// Get retrieves the CRL bundle from c given url as key. If the key does not
+// exist or the content has expired, corecrl.ErrCacheMiss is returned. If the
+// entry is corrupted, it is removed and corecrl.ErrCacheMiss is returned.
+func (c *FileCache) Get(ctx context.Context, url string) (*corecrl.Bundle, error) {
+	bundle, err := c.FileCache.Get(ctx, url)
+	if err == nil || errors.Is(err, corecrl.ErrCacheMiss) || !c.isCorrupted(url) {
+		return bundle, err
+	}
+	logger := log.GetLogger(ctx)
+	logger.Warnf("Removing corrupted CRL file cache entry of %s: %v", url, err)
+	if err := c.heal(ctx, url); err != nil {
+		return nil, fmt.Errorf("failed to remove corrupted crl file cache entry: %w", err)
+	}
+	return nil, corecrl.ErrCacheMiss
+}
+
+// Set stores the CRL bundle in c with url as key along with url itself.
+func (c *FileCache) Set(ctx context.Context, url string, bundle *corecrl.Bundle) error {
+	unlock, err := c.lock(fileName(url))
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	if err := c.FileCache.Set(ctx, url, bundle); err != nil {
+		return err
+	}
+	if err := osutil.WriteFileAtomic(filepath.Join(c.root, fileName(url)+urlFileExt), []byte(url), 0600); err != nil {
+		return fmt.Errorf("failed to store crl url in file cache: %w", err)
+	}
+	return nil
+}
+
+// isCorrupted returns true if the entry with url as key is readable but
+// cannot be parsed.
+func (c *FileCache) isCorrupted(url string) bool {
+	if _, err := os.ReadFile(filepath.Join(c.root, fileName(url))); err != nil {
+		return false
+	}
+	return c.readEntry(fileName(url)).Err != nil
+}
+
+// heal removes the corrupted entry with url as key, unless it has been
+// replaced by another process in the meantime.
+func (c *FileCache) heal(ctx context.Context, url string) error {
+	name := fileName(url)
+	unlock, err := c.lock(name)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	if !c.isCorrupted(url) {
+		return nil
+	}
+	return c.remove(name)
+}
+
+// lock acquires the lock of the entry named name.
+func (c *FileCache) lock(name string) (func() error, error) {
+	unlock, err := osutil.LockFile(filepath.Join(c.root, name+lockFileExt), lockTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock crl file cache: %w", err)
+	}
+	return unlock, nil
+}
+
+// Entry is an entry of the CRL file cache.
+type Entry struct {
+	// Name is the file name of the entry in the cache.
+	Name string
+
+	// URL is the URL of the CRL. It is empty if the URL is not recorded.
+	URL string
+
+	// Issuer is the issuer of the base CRL.
+	Issuer string
+
+	// ThisUpdate is the issue time of the base CRL.
+	ThisUpdate time.Time
+
+	// NextUpdate is the time by which the next base CRL will be issued.
+	NextUpdate time.Time
+
+	// RevokedCount is the number of revoked certificates in the base CRL.
+	RevokedCount int
+
+	// HasDeltaCRL is true if the entry contains a delta CRL.
+	HasDeltaCRL bool
+
+	// Err is the error of parsing the entry, if any.
+	Err error
+}
+
+// IsStale returns true if the entry cannot be parsed or has no valid
+// NextUpdate, or the CRL has expired at time now.
+func (e *Entry) IsStale(now time.Time) bool {
+	return e.Err != nil || e.NextUpdate.IsZero() || now.After(e.NextUpdate)
+}
+
+// fileCacheContent is the content saved in the notation-go file cache.
+type fileCacheContent struct {
+	// BaseCRL is the ASN.1 encoded base CRL
+	BaseCRL []byte `json:"baseCRL"`
+
+	// DeltaCRL is the ASN.1 encoded delta CRL
+	DeltaCRL []byte `json:"deltaCRL,omitempty"`
+}
+
+// List returns the entries in the cache sorted by URL and then by name.
+// Entries failed to be parsed are returned with Err set.
+func (c *FileCache) List() ([]*Entry, error) {
+	files, err := os.ReadDir(c.root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list crl file cache: %w", err)
+	}
+	var entries []*Entry
+	for _, file := range files {
+		if !file.Type().IsRegular() || !isEntryName(file.Name()) {
+			continue
+		}
+		entries = append(entries, c.readEntry(file.Name()))
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].URL != entries[j].URL {
+			return entries[i].URL < entries[j].URL
+		}
+		return entries[i].Name < entries[j].Name
+	})
+	return entries, nil
+}
+
+// Entry returns the entry in the cache with url as key. If the key does not
+// exist, corecrl.ErrCacheMiss is returned.
+func (c *FileCache) Entry(url string) (*Entry, error) {
+	name := fileName(url)
+	if _, err := os.Stat(filepath.Join(c.root, name)); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, corecrl.ErrCacheMiss
+		}
+		return nil, err
+	}
+	entry := c.readEntry(name)
+	entry.URL = url
+	return entry, nil
+}
+
+// Bundle returns the CRL bundle in the cache with url as key, even if it has
+// expired, for evaluating the revocation status at a time in the past. If the
+// key does not exist, corecrl.ErrCacheMiss is returned.
+func (c *FileCache) Bundle(url string) (*corecrl.Bundle, error) {
+	bundle, err := c.readBundle(fileName(url))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, corecrl.ErrCacheMiss
+	}
+	return bundle, err
+}
+
+// Delete removes the entry named name from the cache along with its recorded
+// URL.
+func (c *FileCache) Delete(name string) error {
+	if !isEntryName(name) {
+		return fmt.Errorf("invalid crl file cache entry name %q", name)
+	}
+	unlock, err := c.lock(name)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return c.remove(name)
+}
+
+// remove removes the entry named name along with its recorded URL without
+// locking.
+func (c *FileCache) remove(name string) error {
+	if err := os.Remove(filepath.Join(c.root, name)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	if err := os.Remove(filepath.Join(c.root, name+urlFileExt)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// readEntry reads the entry named name from the cache.
+func (c *FileCache) readEntry(name string) *Entry {
+	entry := &Entry{
+		Name: name,
+	}
+	if url, err := os.ReadFile(filepath.Join(c.root, name+urlFileExt)); err == nil {
+		entry.URL = string(url)
+	}
+	bundle, err := c.readBundle(name)
+	if err != nil {
+		entry.Err = err
+		return entry
+	}
+	entry.Issuer = bundle.BaseCRL.Issuer.String()
+	entry.ThisUpdate = bundle.BaseCRL.ThisUpdate
+	entry.NextUpdate = bundle.BaseCRL.NextUpdate
+	entry.RevokedCount = len(bundle.BaseCRL.RevokedCertificateEntries)
+	entry.HasDeltaCRL = bundle.DeltaCRL != nil
+	return entry
+}
+
+// readBundle reads and parses the CRL bundle of the entry named name.
+func (c *FileCache) readBundle(name string) (*corecrl.Bundle, error) {
+	contentBytes, err := os.ReadFile(filepath.Join(c.root, name))
+	if err != nil {
+		return nil, err
+	}
+	var content fileCacheContent
+	if err := json.Unmarshal(contentBytes, &content); err != nil {
+		return nil, fmt.Errorf("failed to decode file retrieved from file cache: %w", err)
+	}
+	bundle := &corecrl.Bundle{}
+	if bundle.BaseCRL, err = x509.ParseRevocationList(content.BaseCRL); err != nil {
+		return nil, fmt.Errorf("failed to parse base CRL of file retrieved from file cache: %w", err)
+	}
+	if content.DeltaCRL != nil {
+		if bundle.DeltaCRL, err = x509.ParseRevocationList(content.DeltaCRL); err != nil {
+			return nil, fmt.Errorf("failed to parse delta CRL of file retrieved from file cache: %w", err)
+		}
+	}
+	return bundle, nil
+}
+
+// fileName returns the file name of the cache entry with url as key, which
+// is consistent with the notation-go file cache.
+func fileName(url string) string {
+	hash := sha256.Sum256([]byte(url))
+	return hex.EncodeToString(hash[:])
+}
+
+// isEntryName returns true if name is the file name of a cache entry, i.e. a
+// hex-encoded SHA-256 digest.
+func isEntryName(name string) bool {
+	if len(name) != sha256.Size*2 {
+		return false
+	}
+	_, err := hex.DecodeString(name)
+	return err == nil
+}
@@ -0,0 +1,258 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package crl
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	corecrl "github.com/notaryproject/notation-core-go/revocation/crl"
+)
+
+// newTestIssuer creates a self-signed CRL issuer.
+func newTestIssuer(t *testing.T) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test CRL Issuer"},
+		NotBefore:             time.Now().Add(-24 * time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	certBytes, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(certBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert, key
+}
+
+func newTestBundle(t *testing.T, thisUpdate, nextUpdate time.Time) *corecrl.Bundle {
+	t.Helper()
+	issuerCert, issuerKey := newTestIssuer(t)
+	crlBytes, err := x509.CreateRevocationList(rand.Reader, &x509.RevocationList{
+		Number:     big.NewInt(1),
+		ThisUpdate: thisUpdate,
+		NextUpdate: nextUpdate,
+		RevokedCertificateEntries: []x509.RevocationListEntry{
+			{SerialNumber: big.NewInt(2), RevocationTime: thisUpdate},
+		},
+	}, issuerCert, issuerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	baseCRL, err := x509.ParseRevocationList(crlBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &corecrl.Bundle{BaseCRL: baseCRL}
+}
+
+func TestFileCache(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	cache, err := NewFileCache(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	validURL := "http://crl.example.com/valid.crl"
+	staleURL := "http://crl.example.com/stale.crl"
+	if err := cache.Set(ctx, validURL, newTestBundle(t, now.Add(-time.Hour), now.Add(time.Hour))); err != nil {
+		t.Fatal(err)
+	}
+	if err := cache.Set(ctx, staleURL, newTestBundle(t, now.Add(-2*time.Hour), now.Add(-time.Hour))); err != nil {
+		t.Fatal(err)
+	}
+	corruptName := fileName("http://crl.example.com/corrupt.crl")
+	if err := os.WriteFile(filepath.Join(root, corruptName), []byte("corrupt"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("get", func(t *testing.T) {
+		bundle, err := cache.Get(ctx, validURL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(bundle.BaseCRL.RevokedCertificateEntries) != 1 {
+			t.Fatalf("expected 1 revoked certificate, but got %d", len(bundle.BaseCRL.RevokedCertificateEntries))
+		}
+	})
+
+	t.Run("list", func(t *testing.T) {
+		entries, err := cache.List()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(entries) != 3 {
+			t.Fatalf("expected 3 entries, but got %d", len(entries))
+		}
+		// the corrupt entry has no recorded URL and is sorted first
+		if entries[0].Name != corruptName || entries[0].Err == nil || !entries[0].IsStale(now) {
+			t.Fatalf("expected corrupt entry %s to be invalid, but got %+v", corruptName, entries[0])
+		}
+		if entries[1].URL != staleURL || !entries[1].IsStale(now) {
+			t.Fatalf("expected stale entry %s, but got %+v", staleURL, entries[1])
+		}
+		if entries[2].URL != validURL || entries[2].IsStale(now) || entries[2].RevokedCount != 1 {
+			t.Fatalf("expected valid entry %s, but got %+v", validURL, entries[2])
+		}
+	})
+
+	t.Run("entry", func(t *testing.T) {
+		entry, err := cache.Entry(validURL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if entry.Issuer != "CN=Test CRL Issuer" {
+			t.Fatalf("unexpected issuer %q", entry.Issuer)
+		}
+		if _, err := cache.Entry("http://crl.example.com/missing.crl"); !errors.Is(err, corecrl.ErrCacheMiss) {
+			t.Fatalf("expected ErrCacheMiss, but got %v", err)
+		}
+	})
+
+	t.Run("bundle", func(t *testing.T) {
+		if _, err := cache.Get(ctx, staleURL); !errors.Is(err, corecrl.ErrCacheMiss) {
+			t.Fatalf("expected ErrCacheMiss for the stale entry, but got %v", err)
+		}
+		bundle, err := cache.Bundle(staleURL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bundle.BaseCRL.NextUpdate.Before(now) {
+			t.Fatalf("expected the stale CRL, but got next update %v", bundle.BaseCRL.NextUpdate)
+		}
+		if _, err := cache.Bundle("http://crl.example.com/missing.crl"); !errors.Is(err, corecrl.ErrCacheMiss) {
+			t.Fatalf("expected ErrCacheMiss, but got %v", err)
+		}
+		if _, err := cache.Bundle("http://crl.example.com/corrupt.crl"); err == nil {
+			t.Fatal("expected error for corrupt entry, but got nil")
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		if err := cache.Delete(fileName(staleURL)); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := os.Stat(filepath.Join(root, fileName(staleURL)+urlFileExt)); !errors.Is(err, os.ErrNotExist) {
+			t.Fatalf("expected recorded URL to be deleted, but got %v", err)
+		}
+		if err := cache.Delete("../invalid"); err == nil {
+			t.Fatal("expected error for invalid entry name, but got nil")
+		}
+		entries, err := cache.List()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(entries) != 2 {
+			t.Fatalf("expected 2 entries, but got %d", len(entries))
+		}
+	})
+}
+
+func TestFileCache_SelfHealing(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	cache, err := NewFileCache(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	url := "http://crl.example.com/corrupt.crl"
+	if err := cache.Set(ctx, url, newTestBundle(t, time.Now().Add(-time.Hour), time.Now().Add(time.Hour))); err != nil {
+		t.Fatal(err)
+	}
+	// simulate a truncated write
+	if err := os.WriteFile(filepath.Join(root, fileName(url)), []byte(`{"baseCRL":"MII`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cache.Get(ctx, url); !errors.Is(err, corecrl.ErrCacheMiss) {
+		t.Fatalf("expected ErrCacheMiss, but got %v", err)
+	}
+	for _, name := range []string{fileName(url), fileName(url) + urlFileExt} {
+		if _, err := os.Stat(filepath.Join(root, name)); !errors.Is(err, os.ErrNotExist) {
+			t.Fatalf("expected corrupted file %s to be removed, but got %v", name, err)
+		}
+	}
+}
+
+func TestFileCache_Concurrent(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	url := "http://crl.example.com/concurrent.crl"
+	bundle := newTestBundle(t, time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+
+	// each goroutine simulates a process with its own cache instance
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cache, err := NewFileCache(root)
+			if err != nil {
+				errs <- err
+				return
+			}
+			for j := 0; j < 5; j++ {
+				if err := cache.Set(ctx, url, bundle); err != nil {
+					errs <- err
+					return
+				}
+				if _, err := cache.Get(ctx, url); err != nil {
+					errs <- err
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatal(err)
+	}
+
+	files, err := os.ReadDir(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// only the entry and its recorded URL are left
+	if len(files) != 2 {
+		var names []string
+		for _, file := range files {
+			names = append(names, file.Name())
+		}
+		t.Fatalf("expected 2 files in the cache, but got %v", names)
+	}
+}
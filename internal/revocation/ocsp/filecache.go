@@ -0,0 +1,307 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ocsp provides an on-disk cache of OCSP responses shared across
+// notation processes.
+package ocsp
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/notaryproject/notation/internal/osutil"
+	"golang.org/x/crypto/ocsp"
+)
+
+const (
+	// entryFileExt is the extension of the cache entry files.
+	entryFileExt = ".json"
+
+	// lockFileExt is the extension of the lock files of the cache entries.
+	lockFileExt = ".lock"
+
+	// lockTimeout is the timeout of acquiring the lock of a cache entry.
+	lockTimeout = 5 * time.Second
+
+	// DefaultMaxAge is the default maximum duration of caching a response,
+	// regardless of its next update time.
+	DefaultMaxAge = 24 * time.Hour
+)
+
+// ErrCacheMiss is returned when a cache entry is not found or has expired.
+var ErrCacheMiss = errors.New("ocsp response not found in cache")
+
+// Key identifies the certificate whose revocation status is queried, as in
+// the OCSP request.
+type Key struct {
+	// IssuerKeyHash is the hash of the public key of the issuer.
+	IssuerKeyHash []byte
+
+	// IssuerNameHash is the hash of the subject name of the issuer.
+	IssuerNameHash []byte
+
+	// SerialNumber is the serial number of the certificate.
+	SerialNumber *big.Int
+}
+
+// KeyFromRequest returns the cache key of the OCSP request.
+func KeyFromRequest(req *ocsp.Request) Key {
+	return Key{
+		IssuerKeyHash:  req.IssuerKeyHash,
+		IssuerNameHash: req.IssuerNameHash,
+		SerialNumber:   req.SerialNumber,
+	}
+}
+
+// name returns the file name of the cache entry with key k without extension.
+func (k Key) name() string {
+	hash := sha256.New()
+	hash.Write([]byte(hex.EncodeToString(k.IssuerNameHash)))
+	hash.Write([]byte{':'})
+	hash.Write([]byte(hex.EncodeToString(k.IssuerKeyHash)))
+	hash.Write([]byte{':'})
+	hash.Write([]byte(k.SerialNumber.Text(16)))
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// Entry is a cached OCSP response.
+type Entry struct {
+	// Name is the name of the entry in the cache.
+	Name string `json:"-"`
+
+	// URL is the URL of the OCSP responder.
+	URL string `json:"url"`
+
+	// IssuerKeyHash is the hex-encoded hash of the public key of the issuer.
+	IssuerKeyHash string `json:"issuerKeyHash"`
+
+	// SerialNumber is the hex-encoded serial number of the certificate.
+	SerialNumber string `json:"serialNumber"`
+
+	// Status is the revocation status of the certificate, one of "good",
+	// "revoked" and "unknown".
+	Status string `json:"status"`
+
+	// ThisUpdate is the time at which the status is known to be correct.
+	ThisUpdate time.Time `json:"thisUpdate"`
+
+	// NextUpdate is the time at or before which newer information will be
+	// available about the status of the certificate.
+	NextUpdate time.Time `json:"nextUpdate"`
+
+	// Expiry is the time after which the entry is not used, which is
+	// NextUpdate capped by the maximum age of the cache.
+	Expiry time.Time `json:"expiry"`
+
+	// Response is the DER encoded OCSP response.
+	Response []byte `json:"response"`
+
+	// Err is the error of reading the entry, if any.
+	Err error `json:"-"`
+}
+
+// IsStale returns true if the entry cannot be read or has expired at time now.
+func (e *Entry) IsStale(now time.Time) bool {
+	return e.Err != nil || e.Expiry.IsZero() || !now.Before(e.Expiry)
+}
+
+// FileCache is an on-disk cache of OCSP responses keyed by the issuer and the
+// serial number of the certificates. It is safe for concurrent use across
+// processes: entries are written atomically while holding a lock file.
+type FileCache struct {
+	// MaxAge is the maximum duration of caching a response. A response is
+	// not used after MaxAge even if its next update time is later.
+	MaxAge time.Duration
+
+	// root is the root directory of the cache
+	root string
+}
+
+// NewFileCache creates a FileCache with root as the root directory.
+func NewFileCache(root string) (*FileCache, error) {
+	if err := os.MkdirAll(root, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create ocsp file cache: %w", err)
+	}
+	return &FileCache{
+		MaxAge: DefaultMaxAge,
+		root:   root,
+	}, nil
+}
+
+// Get returns the DER encoded OCSP response cached with key, which is not
+// expired at time now. If the key does not exist or the response has expired,
+// ErrCacheMiss is returned.
+func (c *FileCache) Get(key Key, now time.Time) ([]byte, error) {
+	entry := c.readEntry(key.name())
+	if entry.Err != nil {
+		if errors.Is(entry.Err, fs.ErrNotExist) {
+			return nil, ErrCacheMiss
+		}
+		return nil, entry.Err
+	}
+	if entry.IsStale(now) {
+		return nil, ErrCacheMiss
+	}
+	return entry.Response, nil
+}
+
+// Entry returns the entry in the cache with key, even if it has expired, for
+// evaluating the revocation status at a time in the past. If the key does not
+// exist, ErrCacheMiss is returned.
+func (c *FileCache) Entry(key Key) (*Entry, error) {
+	entry := c.readEntry(key.name())
+	if entry.Err != nil {
+		if errors.Is(entry.Err, fs.ErrNotExist) {
+			return nil, ErrCacheMiss
+		}
+		return nil, entry.Err
+	}
+	return entry, nil
+}
+
+// Set stores the DER encoded OCSP response from the responder at url in c
+// with key, after verifying its signature against issuer. Responses without
+// NextUpdate or with unknown status are not cached.
+func (c *FileCache) Set(key Key, url string, response []byte, issuer *x509.Certificate) error {
+	if issuer == nil {
+		return errors.New("issuer is required to verify the ocsp response")
+	}
+	resp, err := ocsp.ParseResponse(response, issuer)
+	if err != nil {
+		return fmt.Errorf("failed to verify ocsp response: %w", err)
+	}
+	if resp.NextUpdate.IsZero() || resp.Status == ocsp.Unknown {
+		return nil
+	}
+	if resp.SerialNumber == nil || resp.SerialNumber.Cmp(key.SerialNumber) != 0 {
+		return errors.New("serial number of ocsp response does not match the request")
+	}
+	expiry := resp.NextUpdate
+	if maxExpiry := time.Now().Add(c.MaxAge); c.MaxAge > 0 && maxExpiry.Before(expiry) {
+		expiry = maxExpiry
+	}
+	entry := Entry{
+		URL:           url,
+		IssuerKeyHash: hex.EncodeToString(key.IssuerKeyHash),
+		SerialNumber:  key.SerialNumber.Text(16),
+		Status:        statusString(resp.Status),
+		ThisUpdate:    resp.ThisUpdate,
+		NextUpdate:    resp.NextUpdate,
+		Expiry:        expiry,
+		Response:      response,
+	}
+	content, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	name := key.name()
+	unlock, err := osutil.LockFile(filepath.Join(c.root, name+lockFileExt), lockTimeout)
+	if err != nil {
+		return fmt.Errorf("failed to lock ocsp file cache: %w", err)
+	}
+	defer unlock()
+	if err := osutil.WriteFileAtomic(filepath.Join(c.root, name+entryFileExt), content, 0600); err != nil {
+		return fmt.Errorf("failed to store ocsp response in file cache: %w", err)
+	}
+	return nil
+}
+
+// List returns the entries in the cache sorted by URL and then by serial
+// number. Entries failed to be read are returned with Err set.
+func (c *FileCache) List() ([]*Entry, error) {
+	files, err := os.ReadDir(c.root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ocsp file cache: %w", err)
+	}
+	var entries []*Entry
+	for _, file := range files {
+		name, ok := strings.CutSuffix(file.Name(), entryFileExt)
+		if !file.Type().IsRegular() || !ok || !isEntryName(name) {
+			continue
+		}
+		entries = append(entries, c.readEntry(name))
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].URL != entries[j].URL {
+			return entries[i].URL < entries[j].URL
+		}
+		return entries[i].SerialNumber < entries[j].SerialNumber
+	})
+	return entries, nil
+}
+
+// Delete removes the entry named name from the cache.
+func (c *FileCache) Delete(name string) error {
+	if !isEntryName(name) {
+		return fmt.Errorf("invalid ocsp file cache entry name %q", name)
+	}
+	unlock, err := osutil.LockFile(filepath.Join(c.root, name+lockFileExt), lockTimeout)
+	if err != nil {
+		return fmt.Errorf("failed to lock ocsp file cache: %w", err)
+	}
+	defer unlock()
+	if err := os.Remove(filepath.Join(c.root, name+entryFileExt)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// readEntry reads the entry named name from the cache. Since entries are
+// replaced atomically, no lock is required for reading.
+func (c *FileCache) readEntry(name string) *Entry {
+	entry := &Entry{
+		Name: name,
+	}
+	content, err := os.ReadFile(filepath.Join(c.root, name+entryFileExt))
+	if err != nil {
+		entry.Err = err
+		return entry
+	}
+	if err := json.Unmarshal(content, entry); err != nil {
+		entry.Err = fmt.Errorf("failed to decode file retrieved from ocsp file cache: %w", err)
+	}
+	return entry
+}
+
+// isEntryName returns true if name is the name of a cache entry, i.e. a
+// hex-encoded SHA-256 digest.
+func isEntryName(name string) bool {
+	if len(name) != sha256.Size*2 {
+		return false
+	}
+	_, err := hex.DecodeString(name)
+	return err == nil
+}
+
+// statusString returns the string representation of the OCSP status.
+func statusString(status int) string {
+	switch status {
+	case ocsp.Good:
+		return "good"
+	case ocsp.Revoked:
+		return "revoked"
+	default:
+		return "unknown"
+	}
+}
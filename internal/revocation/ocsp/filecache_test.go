@@ -0,0 +1,94 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ocsp
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFileCacheDelete(t *testing.T) {
+	root := t.TempDir()
+	cache, err := NewFileCache(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cache.Delete("invalid"); err == nil {
+		t.Fatal("expected error for invalid entry name")
+	}
+
+	// a corrupted entry is listed with error and can be deleted
+	name := Key{SerialNumber: big.NewInt(1)}.name()
+	if err := os.WriteFile(filepath.Join(root, name+entryFileExt), []byte("{"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := cache.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Err == nil || !entries[0].IsStale(time.Now()) {
+		t.Fatalf("expected 1 invalid entry, but got %+v", entries)
+	}
+	if err := cache.Delete(name); err != nil {
+		t.Fatal(err)
+	}
+	entries, err = cache.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Fatalf("expected no entry, but got %d", len(entries))
+	}
+}
+
+func TestFileCacheEntry(t *testing.T) {
+	root := t.TempDir()
+	cache, err := NewFileCache(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := Key{SerialNumber: big.NewInt(1)}
+	if _, err := cache.Entry(key); !errors.Is(err, ErrCacheMiss) {
+		t.Fatalf("expected ErrCacheMiss, but got %v", err)
+	}
+
+	// an expired entry is a cache miss but can still be read as an entry
+	content, err := json.Marshal(Entry{
+		SerialNumber: "1",
+		Status:       "revoked",
+		Expiry:       time.Now().Add(-time.Hour),
+		Response:     []byte("response"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, key.name()+entryFileExt), content, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cache.Get(key, time.Now()); !errors.Is(err, ErrCacheMiss) {
+		t.Fatalf("expected ErrCacheMiss for the expired entry, but got %v", err)
+	}
+	entry, err := cache.Entry(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.Status != "revoked" || string(entry.Response) != "response" {
+		t.Fatalf("unexpected entry %+v", entry)
+	}
+}
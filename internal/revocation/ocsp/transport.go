@@ -0,0 +1,166 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ocsp
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/notaryproject/notation-go/log"
+	"golang.org/x/crypto/ocsp"
+)
+
+// maxResponseSize is the maximum size of OCSP responses to be cached, which
+// is consistent with notation-core-go.
+const maxResponseSize = 20480 // 20 KB
+
+// CachingTransport is an http.RoundTripper answering OCSP requests from the
+// cache if a fresh response is cached, and caching the responses from the
+// OCSP responders otherwise. Cache errors are logged and discarded.
+//
+// The cache is only used for requests sent on behalf of a CachingValidator,
+// which knows the issuers to verify the responses against. Other requests
+// are passed to Base as is.
+type CachingTransport struct {
+	// Base is the underlying http.RoundTripper for cache misses.
+	Base http.RoundTripper
+
+	// Cache is the OCSP response cache.
+	Cache *FileCache
+
+	// OnCacheHit is called with the serial number of the certificate and the
+	// URL of the OCSP responder if a response is served from the cache, if
+	// set.
+	OnCacheHit func(serialNumber *big.Int, url string)
+}
+
+// RoundTrip answers the OCSP request req from the cache or Base.
+func (t *CachingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	logger := log.GetLogger(req.Context())
+	ocspRequest, responderURL, req, err := parseRequest(req)
+	if err != nil {
+		// not an OCSP request
+		logger.Debugf("Skipping OCSP cache: %v", err)
+		return t.Base.RoundTrip(req)
+	}
+	s, ok := sessionFromContext(req.Context())
+	if !ok {
+		logger.Debug("Skipping OCSP cache: no certificate chain under validation")
+		return t.Base.RoundTrip(req)
+	}
+	issuer, ok := s.issuer(ocspRequest)
+	if !ok {
+		logger.Debugf("Skipping OCSP cache: issuer of serial number %s not found in the certificate chain", ocspRequest.SerialNumber.Text(16))
+		return t.Base.RoundTrip(req)
+	}
+	key := KeyFromRequest(ocspRequest)
+	if response, err := t.Cache.Get(key, time.Now()); err == nil {
+		logger.Debugf("OCSP cache hit for serial number %s from %s", ocspRequest.SerialNumber.Text(16), responderURL)
+		s.recordServed(key)
+		if t.OnCacheHit != nil {
+			t.OnCacheHit(ocspRequest.SerialNumber, responderURL)
+		}
+		return newResponse(req, response), nil
+	} else if !errors.Is(err, ErrCacheMiss) {
+		logger.Warnf("Failed to read OCSP cache: %v", err)
+	}
+	logger.Debugf("OCSP cache miss for serial number %s from %s", ocspRequest.SerialNumber.Text(16), responderURL)
+
+	resp, err := t.Base.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return resp, err
+	}
+	defer resp.Body.Close()
+	response, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, err
+	}
+	if err := t.Cache.Set(key, responderURL, response, issuer); err != nil {
+		logger.Warnf("Failed to store OCSP response in cache: %v", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(response))
+	resp.ContentLength = int64(len(response))
+	return resp, nil
+}
+
+// parseRequest parses the OCSP request sent by GET or POST, and returns the
+// URL of the OCSP responder along with req, whose body is restored if read.
+func parseRequest(req *http.Request) (*ocsp.Request, string, *http.Request, error) {
+	var der []byte
+	var responderURL string
+	switch req.Method {
+	case http.MethodGet:
+		// the request is the last path segment, base64 and URL encoded
+		escapedPath := req.URL.EscapedPath()
+		index := strings.LastIndex(escapedPath, "/")
+		if index < 0 {
+			return nil, "", req, errors.New("missing OCSP request in URL")
+		}
+		encodedRequest, err := url.PathUnescape(escapedPath[index+1:])
+		if err != nil {
+			return nil, "", req, err
+		}
+		der, err = base64.StdEncoding.DecodeString(encodedRequest)
+		if err != nil {
+			return nil, "", req, err
+		}
+		responder := url.URL{
+			Scheme: req.URL.Scheme,
+			Host:   req.URL.Host,
+		}
+		responderURL = responder.String() + escapedPath[:index]
+	case http.MethodPost:
+		if req.Body == nil || req.Header.Get("Content-Type") != "application/ocsp-request" {
+			return nil, "", req, errors.New("not an OCSP request")
+		}
+		body, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, "", req, err
+		}
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		der = body
+		responderURL = req.URL.String()
+	default:
+		return nil, "", req, errors.New("unsupported method " + req.Method)
+	}
+	ocspRequest, err := ocsp.ParseRequest(der)
+	if err != nil {
+		return nil, "", req, err
+	}
+	return ocspRequest, responderURL, req, nil
+}
+
+// newResponse returns an HTTP response to req with body.
+func newResponse(req *http.Request, body []byte) *http.Response {
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/ocsp-response"}},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
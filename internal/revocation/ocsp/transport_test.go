@@ -0,0 +1,377 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ocsp
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/notaryproject/notation-core-go/revocation"
+	"github.com/notaryproject/notation-core-go/revocation/purpose"
+	"github.com/notaryproject/notation-core-go/revocation/result"
+	"github.com/notaryproject/notation-core-go/testhelper"
+	"golang.org/x/crypto/ocsp"
+)
+
+// responder is a fake OCSP responder counting the requests.
+type responder struct {
+	issuer     testhelper.RSACertTuple
+	status     int
+	nextUpdate time.Duration
+	requests   int
+}
+
+func (r *responder) RoundTrip(req *http.Request) (*http.Response, error) {
+	r.requests++
+	ocspRequest, _, _, err := parseRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().Add(-time.Minute)
+	template := ocsp.Response{
+		Status:       r.status,
+		SerialNumber: ocspRequest.SerialNumber,
+		ThisUpdate:   now,
+		RevokedAt:    now,
+	}
+	if r.nextUpdate != 0 {
+		template.NextUpdate = now.Add(r.nextUpdate)
+	}
+	response, err := ocsp.CreateResponse(r.issuer.Cert, r.issuer.Cert, template, r.issuer.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	return newResponse(req, response), nil
+}
+
+func newCachingValidator(t *testing.T, base http.RoundTripper, cache *FileCache) revocation.Validator {
+	validator, err := revocation.NewWithOptions(revocation.Options{
+		OCSPHTTPClient: &http.Client{
+			Transport: &CachingTransport{
+				Base:  base,
+				Cache: cache,
+			},
+		},
+		CertChainPurpose: purpose.CodeSigning,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &CachingValidator{
+		Validator: validator,
+		Cache:     cache,
+	}
+}
+
+func validate(t *testing.T, validator revocation.Validator, chain []*x509.Certificate) result.Result {
+	results, err := validator.ValidateContext(context.Background(), revocation.ValidateContextOptions{
+		CertChain: chain,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return results[0].Result
+}
+
+func TestCachingTransport(t *testing.T) {
+	tuples := testhelper.GetRevokableRSAChainWithRevocations(2, true, false)
+	chain := []*x509.Certificate{tuples[0].Cert, tuples[1].Cert}
+
+	t.Run("cache hit", func(t *testing.T) {
+		cache, err := NewFileCache(t.TempDir())
+		if err != nil {
+			t.Fatal(err)
+		}
+		base := &responder{issuer: tuples[1], status: ocsp.Good, nextUpdate: time.Hour}
+		validator := newCachingValidator(t, base, cache)
+		for i := 0; i < 2; i++ {
+			if got := validate(t, validator, chain); got != result.ResultOK {
+				t.Fatalf("expected result %s, but got %s", result.ResultOK, got)
+			}
+		}
+		if base.requests != 1 {
+			t.Fatalf("expected 1 request to the responder, but got %d", base.requests)
+		}
+
+		// the cache is shared by another validator
+		validator = newCachingValidator(t, base, cache)
+		if got := validate(t, validator, chain); got != result.ResultOK {
+			t.Fatalf("expected result %s, but got %s", result.ResultOK, got)
+		}
+		if base.requests != 1 {
+			t.Fatalf("expected 1 request to the responder, but got %d", base.requests)
+		}
+
+		entries, err := cache.List()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(entries) != 1 {
+			t.Fatalf("expected 1 cache entry, but got %d", len(entries))
+		}
+		entry := entries[0]
+		if entry.Err != nil || entry.Status != "good" || entry.SerialNumber != tuples[0].Cert.SerialNumber.Text(16) || entry.URL != tuples[0].Cert.OCSPServer[0] {
+			t.Fatalf("unexpected cache entry %+v", entry)
+		}
+	})
+
+	t.Run("revoked response cached", func(t *testing.T) {
+		cache, err := NewFileCache(t.TempDir())
+		if err != nil {
+			t.Fatal(err)
+		}
+		base := &responder{issuer: tuples[1], status: ocsp.Revoked, nextUpdate: time.Hour}
+		validator := newCachingValidator(t, base, cache)
+		for i := 0; i < 2; i++ {
+			if got := validate(t, validator, chain); got != result.ResultRevoked {
+				t.Fatalf("expected result %s, but got %s", result.ResultRevoked, got)
+			}
+		}
+		if base.requests != 1 {
+			t.Fatalf("expected 1 request to the responder, but got %d", base.requests)
+		}
+	})
+
+	t.Run("expired response not used", func(t *testing.T) {
+		cache, err := NewFileCache(t.TempDir())
+		if err != nil {
+			t.Fatal(err)
+		}
+		base := &responder{issuer: tuples[1], status: ocsp.Good, nextUpdate: 2 * time.Minute}
+		validator := newCachingValidator(t, base, cache)
+		validate(t, validator, chain)
+		entries, err := cache.List()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(entries) != 1 || !entries[0].IsStale(time.Now().Add(5*time.Minute)) {
+			t.Fatalf("expected 1 stale entry, but got %+v", entries)
+		}
+		der, err := ocsp.CreateRequest(tuples[0].Cert, tuples[1].Cert, &ocsp.RequestOptions{Hash: crypto.SHA1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ocspRequest, err := ocsp.ParseRequest(der)
+		if err != nil {
+			t.Fatal(err)
+		}
+		key := KeyFromRequest(ocspRequest)
+		if _, err := cache.Get(key, time.Now()); err != nil {
+			t.Fatalf("expected cache hit, but got %v", err)
+		}
+		if _, err := cache.Get(key, time.Now().Add(5*time.Minute)); err != ErrCacheMiss {
+			t.Fatalf("expected ErrCacheMiss, but got %v", err)
+		}
+	})
+
+	t.Run("response without next update not cached", func(t *testing.T) {
+		cache, err := NewFileCache(t.TempDir())
+		if err != nil {
+			t.Fatal(err)
+		}
+		base := &responder{issuer: tuples[1], status: ocsp.Good}
+		validator := newCachingValidator(t, base, cache)
+		for i := 0; i < 2; i++ {
+			validate(t, validator, chain)
+		}
+		if base.requests != 2 {
+			t.Fatalf("expected 2 requests to the responder, but got %d", base.requests)
+		}
+	})
+}
+
+func TestCachingValidator(t *testing.T) {
+	tuples := testhelper.GetRevokableRSAChainWithRevocations(2, true, false)
+	chain := []*x509.Certificate{tuples[0].Cert, tuples[1].Cert}
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	forger := testhelper.RSACertTuple{Cert: tuples[1].Cert, PrivateKey: otherKey}
+
+	t.Run("unverified response not cached", func(t *testing.T) {
+		cache, err := NewFileCache(t.TempDir())
+		if err != nil {
+			t.Fatal(err)
+		}
+		// the response is not signed by the issuer
+		base := &responder{issuer: forger, status: ocsp.Good, nextUpdate: time.Hour}
+		validator := newCachingValidator(t, base, cache)
+		if got := validate(t, validator, chain); got != result.ResultUnknown {
+			t.Fatalf("expected result %s, but got %s", result.ResultUnknown, got)
+		}
+		entries, err := cache.List()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(entries) != 0 {
+			t.Fatalf("expected no cache entry, but got %d", len(entries))
+		}
+	})
+
+	t.Run("rejected response evicted", func(t *testing.T) {
+		root := t.TempDir()
+		cache, err := NewFileCache(root)
+		if err != nil {
+			t.Fatal(err)
+		}
+		base := &responder{issuer: tuples[1], status: ocsp.Good, nextUpdate: time.Hour}
+		validator := newCachingValidator(t, base, cache)
+		validate(t, validator, chain)
+
+		// replace the cached response with a forged one
+		entries, err := cache.List()
+		if err != nil || len(entries) != 1 {
+			t.Fatalf("expected 1 cache entry, but got %d, %v", len(entries), err)
+		}
+		forged, err := ocsp.CreateResponse(forger.Cert, forger.Cert, ocsp.Response{
+			Status:       ocsp.Good,
+			SerialNumber: tuples[0].Cert.SerialNumber,
+			ThisUpdate:   time.Now().Add(-time.Minute),
+			NextUpdate:   time.Now().Add(time.Hour),
+		}, forger.PrivateKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		entry := entries[0]
+		entry.Response = forged
+		content, err := json.Marshal(entry)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(root, entry.Name+entryFileExt), content, 0600); err != nil {
+			t.Fatal(err)
+		}
+
+		if got := validate(t, validator, chain); got != result.ResultOK {
+			t.Fatalf("expected result %s, but got %s", result.ResultOK, got)
+		}
+		if base.requests != 2 {
+			t.Fatalf("expected 2 requests to the responder, but got %d", base.requests)
+		}
+		entries, err = cache.List()
+		if err != nil || len(entries) != 1 || bytes.Equal(entries[0].Response, forged) {
+			t.Fatalf("expected the forged response to be replaced, but got %+v, %v", entries, err)
+		}
+	})
+
+	t.Run("max age", func(t *testing.T) {
+		cache, err := NewFileCache(t.TempDir())
+		if err != nil {
+			t.Fatal(err)
+		}
+		cache.MaxAge = time.Minute
+		base := &responder{issuer: tuples[1], status: ocsp.Good, nextUpdate: time.Hour}
+		validator := newCachingValidator(t, base, cache)
+		validate(t, validator, chain)
+		entries, err := cache.List()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(entries) != 1 || entries[0].IsStale(time.Now()) || !entries[0].IsStale(time.Now().Add(2*time.Minute)) {
+			t.Fatalf("expected 1 entry expiring after 1 minute, but got %+v", entries)
+		}
+	})
+
+	t.Run("request without chain not cached", func(t *testing.T) {
+		cache, err := NewFileCache(t.TempDir())
+		if err != nil {
+			t.Fatal(err)
+		}
+		base := &responder{issuer: tuples[1], status: ocsp.Good, nextUpdate: time.Hour}
+		validator, err := revocation.NewWithOptions(revocation.Options{
+			OCSPHTTPClient: &http.Client{
+				Transport: &CachingTransport{
+					Base:  base,
+					Cache: cache,
+				},
+			},
+			CertChainPurpose: purpose.CodeSigning,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < 2; i++ {
+			validate(t, validator, chain)
+		}
+		if base.requests != 2 {
+			t.Fatalf("expected 2 requests to the responder, but got %d", base.requests)
+		}
+	})
+}
+
+func TestParseRequest(t *testing.T) {
+	tuples := testhelper.GetRevokableRSAChainWithRevocations(2, true, false)
+	der, err := ocsp.CreateRequest(tuples[0].Cert, tuples[1].Cert, &ocsp.RequestOptions{Hash: crypto.SHA1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("post", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodPost, "http://localhost.test/ocsp", bytes.NewReader(der))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Content-Type", "application/ocsp-request")
+		ocspRequest, responderURL, req, err := parseRequest(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ocspRequest.SerialNumber.Cmp(tuples[0].Cert.SerialNumber) != 0 || responderURL != "http://localhost.test/ocsp" {
+			t.Fatalf("unexpected request %v from %s", ocspRequest.SerialNumber, responderURL)
+		}
+		body, err := io.ReadAll(req.Body)
+		if err != nil || !bytes.Equal(body, der) {
+			t.Fatal("expected request body to be restored")
+		}
+	})
+
+	t.Run("get with path", func(t *testing.T) {
+		encoded := strings.NewReplacer("+", "%2B", "/", "%2F", "=", "%3D").Replace(base64.StdEncoding.EncodeToString(der))
+		req, err := http.NewRequest(http.MethodGet, "http://localhost.test/a/ocsp/"+encoded, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, responderURL, _, err := parseRequest(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if responderURL != "http://localhost.test/a/ocsp" {
+			t.Fatalf("expected responder url http://localhost.test/a/ocsp, but got %s", responderURL)
+		}
+	})
+
+	t.Run("not ocsp request", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, "http://localhost.test/crl", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, _, _, err := parseRequest(req); err == nil {
+			t.Fatal("expected error for non-OCSP request")
+		}
+	})
+}
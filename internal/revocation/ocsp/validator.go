@@ -0,0 +1,152 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ocsp
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/notaryproject/notation-core-go/revocation"
+	"github.com/notaryproject/notation-core-go/revocation/result"
+	"github.com/notaryproject/notation-go/log"
+	"golang.org/x/crypto/ocsp"
+)
+
+// CachingValidator is a revocation.Validator providing the certificate chain
+// under validation to the CachingTransport of its OCSP HTTP client, so that
+// OCSP responses are verified against the issuers of the certificates before
+// being cached. Cached responses rejected by the underlying Validator are
+// evicted from the cache and the chain is validated again.
+type CachingValidator struct {
+	revocation.Validator
+
+	// Cache is the OCSP response cache shared with the CachingTransport.
+	Cache *FileCache
+}
+
+// Validate checks the revocation status of certChain at signingTime.
+func (v *CachingValidator) Validate(certChain []*x509.Certificate, signingTime time.Time) ([]*result.CertRevocationResult, error) {
+	return v.ValidateContext(context.Background(), revocation.ValidateContextOptions{
+		CertChain:            certChain,
+		AuthenticSigningTime: signingTime,
+	})
+}
+
+// ValidateContext checks the revocation status of the certificate chain in
+// opts.
+func (v *CachingValidator) ValidateContext(ctx context.Context, opts revocation.ValidateContextOptions) ([]*result.CertRevocationResult, error) {
+	s := &session{certChain: opts.CertChain}
+	certResults, err := v.Validator.ValidateContext(context.WithValue(ctx, sessionKey{}, s), opts)
+	if err != nil || !v.evictRejected(ctx, s, certResults) {
+		return certResults, err
+	}
+	// validate again with fresh responses from the OCSP responders
+	return v.Validator.ValidateContext(context.WithValue(ctx, sessionKey{}, &session{certChain: opts.CertChain}), opts)
+}
+
+// evictRejected removes the cached responses served in s but rejected in
+// certResults from the cache, and returns true if any is removed.
+func (v *CachingValidator) evictRejected(ctx context.Context, s *session, certResults []*result.CertRevocationResult) bool {
+	logger := log.GetLogger(ctx)
+	var evicted bool
+	for i, certResult := range certResults {
+		if i >= len(s.certChain) || certResult == nil {
+			break
+		}
+		key, ok := s.servedKey(s.certChain[i].SerialNumber)
+		if !ok {
+			continue
+		}
+		for _, serverResult := range certResult.ServerResults {
+			if serverResult.RevocationMethod != result.RevocationMethodOCSP || serverResult.Error == nil || serverResult.Result == result.ResultRevoked {
+				continue
+			}
+			logger.Debugf("Evicting cached OCSP response for serial number %s rejected by %s: %v", key.SerialNumber.Text(16), serverResult.Server, serverResult.Error)
+			if err := v.Cache.Delete(key.name()); err != nil {
+				logger.Warnf("Failed to evict OCSP response from cache: %v", err)
+				continue
+			}
+			evicted = true
+			break
+		}
+	}
+	return evicted
+}
+
+// sessionKey is the context key of the session.
+type sessionKey struct{}
+
+// session is the state of a revocation check shared between the
+// CachingValidator and the CachingTransport through the request context.
+type session struct {
+	// certChain is the certificate chain under validation.
+	certChain []*x509.Certificate
+
+	mu sync.Mutex
+	// served is the keys of the responses served from the cache, keyed by
+	// the serial numbers of the certificates.
+	served map[string]Key
+}
+
+// sessionFromContext returns the session of the revocation check in ctx.
+func sessionFromContext(ctx context.Context) (*session, bool) {
+	s, ok := ctx.Value(sessionKey{}).(*session)
+	return s, ok
+}
+
+// issuer returns the issuer of the certificate queried by req if the
+// certificate is in the chain.
+func (s *session) issuer(req *ocsp.Request) (*x509.Certificate, bool) {
+	for i := 0; i < len(s.certChain)-1; i++ {
+		cert, issuer := s.certChain[i], s.certChain[i+1]
+		if cert.SerialNumber.Cmp(req.SerialNumber) != 0 {
+			continue
+		}
+		der, err := ocsp.CreateRequest(cert, issuer, &ocsp.RequestOptions{Hash: req.HashAlgorithm})
+		if err != nil {
+			continue
+		}
+		expected, err := ocsp.ParseRequest(der)
+		if err != nil {
+			continue
+		}
+		if bytes.Equal(expected.IssuerKeyHash, req.IssuerKeyHash) && bytes.Equal(expected.IssuerNameHash, req.IssuerNameHash) {
+			return issuer, true
+		}
+	}
+	return nil, false
+}
+
+// recordServed records that the response with key is served from the cache.
+func (s *session) recordServed(key Key) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.served == nil {
+		s.served = make(map[string]Key)
+	}
+	s.served[key.SerialNumber.Text(16)] = key
+}
+
+// servedKey returns the key of the response served from the cache for the
+// certificate with serialNumber, if any.
+func (s *session) servedKey(serialNumber *big.Int) (Key, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key, ok := s.served[serialNumber.Text(16)]
+	return key, ok
+}
@@ -0,0 +1,156 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package revocation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/notaryproject/notation-core-go/revocation"
+	corecrl "github.com/notaryproject/notation-core-go/revocation/crl"
+	"github.com/notaryproject/notation-core-go/revocation/result"
+)
+
+// Mode is the mode of revocation checks.
+type Mode string
+
+const (
+	// ModeOnline checks revocation with OCSP and CRL, fetching from the
+	// network when the cached CRLs are missing or expired.
+	ModeOnline Mode = "online"
+
+	// ModeOffline checks revocation only with the local CRL cache and never
+	// opens network connections.
+	ModeOffline Mode = "offline"
+)
+
+// ParseMode parses the revocation mode. An empty mode is parsed as
+// ModeOnline.
+func ParseMode(mode string) (Mode, error) {
+	switch Mode(mode) {
+	case "", ModeOnline:
+		return ModeOnline, nil
+	case ModeOffline:
+		return ModeOffline, nil
+	}
+	return "", fmt.Errorf("unsupported revocation mode %q, options: %q, %q", mode, ModeOnline, ModeOffline)
+}
+
+// errOffline is returned for any network access in offline mode.
+var errOffline = errors.New("network access is disabled in offline revocation mode")
+
+// offlineTransport is an http.RoundTripper failing all requests.
+type offlineTransport struct{}
+
+// RoundTrip fails the request without network access.
+func (offlineTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return nil, errOffline
+}
+
+// offlineFetcher implements corecrl.Fetcher fetching CRLs only from cache.
+type offlineFetcher struct {
+	cache corecrl.Cache
+}
+
+// Fetch retrieves the CRL of url from the cache. An error is returned if the
+// CRL is not cached or has expired.
+func (f *offlineFetcher) Fetch(ctx context.Context, url string) (*corecrl.Bundle, error) {
+	if f.cache == nil {
+		return nil, fmt.Errorf("CRL %s is not cached: %w", url, errOffline)
+	}
+	bundle, err := f.cache.Get(ctx, url)
+	if err != nil {
+		if errors.Is(err, corecrl.ErrCacheMiss) {
+			return nil, fmt.Errorf("CRL %s is not cached or has expired: %w", url, errOffline)
+		}
+		return nil, err
+	}
+	return bundle, nil
+}
+
+// UncheckedCert is a certificate whose revocation status could not be
+// checked.
+type UncheckedCert struct {
+	// Subject is the subject of the certificate.
+	Subject string
+
+	// Errors are the errors of checking the revocation servers.
+	Errors []error
+}
+
+// Recorder records the certificates whose revocation status could not be
+// checked. It is safe for concurrent use.
+type Recorder struct {
+	mu    sync.Mutex
+	seen  map[string]bool
+	certs []UncheckedCert
+}
+
+// UncheckedCerts returns the recorded certificates in the order they are
+// recorded. Each certificate is recorded only once.
+func (r *Recorder) UncheckedCerts() []UncheckedCert {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.certs
+}
+
+// record records the certificates of opts with unknown revocation results.
+func (r *Recorder) record(opts revocation.ValidateContextOptions, certResults []*result.CertRevocationResult) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, certResult := range certResults {
+		if certResult == nil || certResult.Result != result.ResultUnknown || i >= len(opts.CertChain) {
+			continue
+		}
+		cert := opts.CertChain[i]
+		key := string(cert.Raw)
+		if r.seen[key] {
+			continue
+		}
+		if r.seen == nil {
+			r.seen = make(map[string]bool)
+		}
+		r.seen[key] = true
+		var errs []error
+		for _, serverResult := range certResult.ServerResults {
+			if serverResult.Error != nil {
+				errs = append(errs, serverResult.Error)
+			}
+		}
+		r.certs = append(r.certs, UncheckedCert{
+			Subject: cert.Subject.String(),
+			Errors:  errs,
+		})
+	}
+}
+
+// recordingValidator is a revocation.Validator recording the certificates
+// whose revocation status could not be checked.
+type recordingValidator struct {
+	revocation.Validator
+	recorder *Recorder
+}
+
+// ValidateContext checks the revocation status and records the certificates
+// with unknown revocation results.
+func (v *recordingValidator) ValidateContext(ctx context.Context, opts revocation.ValidateContextOptions) ([]*result.CertRevocationResult, error) {
+	certResults, err := v.Validator.ValidateContext(ctx, opts)
+	if err == nil {
+		v.recorder.record(opts, certResults)
+	}
+	return certResults, err
+}
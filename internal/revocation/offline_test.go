@@ -0,0 +1,119 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package revocation
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/notaryproject/notation-core-go/revocation"
+	"github.com/notaryproject/notation-core-go/revocation/purpose"
+	"github.com/notaryproject/notation-core-go/revocation/result"
+	"github.com/notaryproject/notation-core-go/testhelper"
+	"github.com/notaryproject/notation-go/dir"
+)
+
+func TestParseMode(t *testing.T) {
+	for _, tt := range []struct {
+		mode    string
+		want    Mode
+		wantErr bool
+	}{
+		{mode: "", want: ModeOnline},
+		{mode: "online", want: ModeOnline},
+		{mode: "offline", want: ModeOffline},
+		{mode: "invalid", wantErr: true},
+	} {
+		got, err := ParseMode(tt.mode)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseMode(%q) = %q, %v, want %q, error %v", tt.mode, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestOfflineTransport(t *testing.T) {
+	client := &http.Client{Transport: offlineTransport{}}
+	if _, err := client.Get("http://localhost.test/ocsp"); !errors.Is(err, errOffline) {
+		t.Fatalf("expected error %v, but got %v", errOffline, err)
+	}
+}
+
+func TestOfflineFetcher(t *testing.T) {
+	fetcher := &offlineFetcher{}
+	if _, err := fetcher.Fetch(context.Background(), "http://localhost.test/crl"); !errors.Is(err, errOffline) {
+		t.Fatalf("expected error %v, but got %v", errOffline, err)
+	}
+
+	dir.UserCacheDir = t.TempDir()
+	defer func() {
+		dir.UserCacheDir = ""
+	}()
+	fileCache, err := NewCRLFileCache()
+	if err != nil {
+		t.Fatal(err)
+	}
+	fetcher = &offlineFetcher{cache: fileCache}
+	if _, err := fetcher.Fetch(context.Background(), "http://localhost.test/crl"); !errors.Is(err, errOffline) {
+		t.Fatalf("expected error %v, but got %v", errOffline, err)
+	}
+}
+
+func TestNewRevocationValidatorWithOptions_Offline(t *testing.T) {
+	dir.UserCacheDir = t.TempDir()
+	defer func() {
+		dir.UserCacheDir = ""
+	}()
+
+	recorder := &Recorder{}
+	validator, err := NewRevocationValidatorWithOptions(context.Background(), purpose.CodeSigning, Options{
+		Mode:     ModeOffline,
+		Recorder: recorder,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	chain := testhelper.GetRevokableRSAChainWithRevocations(3, true, true)
+	certChain := []*x509.Certificate{chain[0].Cert, chain[1].Cert, chain[2].Cert}
+	for i := 0; i < 2; i++ {
+		certResults, err := validator.ValidateContext(context.Background(), revocation.ValidateContextOptions{
+			CertChain: certChain,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for j, certResult := range certResults[:len(certResults)-1] {
+			if certResult.Result != result.ResultUnknown {
+				t.Fatalf("expected unknown result for certificate %d, but got %s", j, certResult.Result)
+			}
+		}
+	}
+
+	// each certificate is recorded once across validations
+	uncheckedCerts := recorder.UncheckedCerts()
+	if len(uncheckedCerts) != 2 {
+		t.Fatalf("expected 2 unchecked certificates, but got %d", len(uncheckedCerts))
+	}
+	if uncheckedCerts[0].Subject != chain[0].Cert.Subject.String() || len(uncheckedCerts[0].Errors) == 0 {
+		t.Fatalf("unexpected unchecked certificate: %+v", uncheckedCerts[0])
+	}
+}
+
+func TestNewRevocationValidatorWithOptions_InvalidMode(t *testing.T) {
+	if _, err := NewRevocationValidatorWithOptions(context.Background(), purpose.CodeSigning, Options{Mode: "invalid"}); err == nil {
+		t.Fatal("expected error for invalid mode, but got nil")
+	}
+}
@@ -24,31 +24,168 @@ import (
 	corecrl "github.com/notaryproject/notation-core-go/revocation/crl"
 	"github.com/notaryproject/notation-core-go/revocation/purpose"
 	"github.com/notaryproject/notation-go/dir"
-	"github.com/notaryproject/notation-go/verifier/crl"
 	"github.com/notaryproject/notation/internal/httputil"
 	clicrl "github.com/notaryproject/notation/internal/revocation/crl"
+	cliocsp "github.com/notaryproject/notation/internal/revocation/ocsp"
+	"github.com/notaryproject/notation/pkg/configutil"
 )
 
+// PathOCSPCache is the OCSP response file cache directory relative path.
+const PathOCSPCache = "ocsp"
+
+// Options contains the options of creating a revocation validator.
+type Options struct {
+	// Mode is the revocation mode. Default to ModeOnline.
+	Mode Mode
+
+	// Recorder records the certificates whose revocation status could not be
+	// checked, if set.
+	Recorder *Recorder
+
+	// CacheHits records the OCSP responses and the CRLs served from the
+	// cache, if set.
+	CacheHits *CacheHits
+
+	// CRLCacheStats records the statistics of the CRL cache accesses, if set.
+	CRLCacheStats *clicrl.Stats
+
+	// OCSPTimeout is the timeout of OCSP requests. Default to the timeout in
+	// the notation settings if 0.
+	OCSPTimeout time.Duration
+
+	// CRLTimeout is the timeout of downloading CRLs. Default to the timeout
+	// in the notation settings if 0.
+	CRLTimeout time.Duration
+}
+
 // NewRevocationValidator returns a revocation.Validator given the certificate
 // purpose
 func NewRevocationValidator(ctx context.Context, purpose purpose.Purpose) (revocation.Validator, error) {
-	// err is always nil
-	crlFetcher, _ := corecrl.NewHTTPFetcher(httputil.NewClient(ctx, &http.Client{Timeout: 5 * time.Second}))
-	crlFetcher.DiscardCacheError = true                     // discard crl cache error
-	cacheRoot, _ := dir.CacheFS().SysPath(dir.PathCRLCache) // err is always nil
-	fileCache, err := crl.NewFileCache(cacheRoot)
+	return NewRevocationValidatorWithOptions(ctx, purpose, Options{})
+}
+
+// NewRevocationValidatorWithOptions returns a revocation.Validator given the
+// certificate purpose and opts.
+func NewRevocationValidatorWithOptions(ctx context.Context, purpose purpose.Purpose, opts Options) (revocation.Validator, error) {
+	var cache corecrl.Cache
+	fileCache, err := NewCRLFileCache()
 	if err != nil {
-		// discard NewFileCache error as cache errors are not critical
+		// discard NewCRLFileCache error as cache errors are not critical
 		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
 	} else {
-		crlFetcher.Cache = &clicrl.CacheWithLog{
+		cacheWithLog := &clicrl.CacheWithLog{
 			Cache:             fileCache,
-			DiscardCacheError: crlFetcher.DiscardCacheError,
+			DiscardCacheError: true,
+			Stats:             opts.CRLCacheStats,
 		}
+		if opts.CacheHits != nil {
+			cacheWithLog.OnCacheHit = func(url string, bundle *corecrl.Bundle) {
+				opts.CacheHits.RecordCRL(bundle.BaseCRL, url)
+			}
+		}
+		cache = cacheWithLog
+	}
+
+	ocspCache, err := NewOCSPFileCache()
+	if err != nil {
+		// discard NewOCSPFileCache error as cache errors are not critical
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
 	}
-	return revocation.NewWithOptions(revocation.Options{
-		OCSPHTTPClient:   httputil.NewClient(ctx, &http.Client{Timeout: 2 * time.Second}),
-		CRLFetcher:       crlFetcher,
-		CertChainPurpose: purpose,
-	})
+
+	var validatorOpts revocation.Options
+	switch opts.Mode {
+	case "", ModeOnline:
+		settings, err := configutil.LoadSettingsOnce()
+		if err != nil {
+			return nil, err
+		}
+		crlTimeout := opts.CRLTimeout
+		if crlTimeout == 0 {
+			crlTimeout = settings.Timeouts.CRLTimeout()
+		}
+		crlHTTPClient, err := NewCRLHTTPClient(ctx, crlTimeout)
+		if err != nil {
+			return nil, err
+		}
+		// err is always nil
+		crlFetcher, _ := corecrl.NewHTTPFetcher(crlHTTPClient)
+		crlFetcher.DiscardCacheError = true // discard crl cache error
+		crlFetcher.Cache = cache
+		ocspTimeout := opts.OCSPTimeout
+		if ocspTimeout == 0 {
+			ocspTimeout = settings.Timeouts.OCSPTimeout()
+		}
+		transport, err := httputil.NewTransportForEndpoint(configutil.EndpointRevocation)
+		if err != nil {
+			return nil, err
+		}
+		validatorOpts = revocation.Options{
+			OCSPHTTPClient: httputil.NewClient(ctx, &http.Client{
+				Transport: withOCSPCache(transport, ocspCache, opts.CacheHits),
+				Timeout:   ocspTimeout,
+			}),
+			CRLFetcher: crlFetcher,
+		}
+	case ModeOffline:
+		validatorOpts = revocation.Options{
+			OCSPHTTPClient: &http.Client{Transport: withOCSPCache(offlineTransport{}, ocspCache, opts.CacheHits)},
+			CRLFetcher:     &offlineFetcher{cache: cache},
+		}
+	default:
+		return nil, fmt.Errorf("unsupported revocation mode %q", opts.Mode)
+	}
+	validatorOpts.CertChainPurpose = purpose
+	validator, err := revocation.NewWithOptions(validatorOpts)
+	if err != nil {
+		return nil, err
+	}
+	if ocspCache != nil {
+		validator = &cliocsp.CachingValidator{
+			Validator: validator,
+			Cache:     ocspCache,
+		}
+	}
+	if opts.Recorder != nil {
+		validator = &recordingValidator{
+			Validator: validator,
+			recorder:  opts.Recorder,
+		}
+	}
+	return validator, nil
+}
+
+// NewCRLHTTPClient returns the HTTP client for fetching CRLs with timeout.
+func NewCRLHTTPClient(ctx context.Context, timeout time.Duration) (*http.Client, error) {
+	return httputil.NewClientForEndpoint(ctx, configutil.EndpointRevocation, timeout)
+}
+
+// NewOCSPFileCache creates the OCSP response file cache under the notation
+// cache directory.
+func NewOCSPFileCache() (*cliocsp.FileCache, error) {
+	cacheRoot, _ := dir.CacheFS().SysPath(PathOCSPCache) // err is always nil
+	return cliocsp.NewFileCache(cacheRoot)
+}
+
+// withOCSPCache returns base answering OCSP requests from fileCache,
+// recording cache hits to hits if set. If fileCache is nil, base is returned
+// as is.
+func withOCSPCache(base http.RoundTripper, fileCache *cliocsp.FileCache, hits *CacheHits) http.RoundTripper {
+	if fileCache == nil {
+		return base
+	}
+	transport := &cliocsp.CachingTransport{
+		Base:  base,
+		Cache: fileCache,
+	}
+	if hits != nil {
+		transport.OnCacheHit = hits.RecordOCSP
+	}
+	return transport
+}
+
+// NewCRLFileCache creates the CRL file cache under the notation cache
+// directory.
+func NewCRLFileCache() (*clicrl.FileCache, error) {
+	cacheRoot, _ := dir.CacheFS().SysPath(dir.PathCRLCache) // err is always nil
+	return clicrl.NewFileCache(cacheRoot)
 }
@@ -0,0 +1,404 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tsa implements a minimal RFC 3161 timestamping authority for local
+// testing purpose only.
+package tsa
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	corex509 "github.com/notaryproject/notation-core-go/x509"
+	"github.com/notaryproject/notation-go/dir"
+	"github.com/notaryproject/notation-go/log"
+	"github.com/notaryproject/tspclient-go"
+	"github.com/notaryproject/tspclient-go/pki"
+)
+
+// LocalTSADir is the directory under the Notation configuration directory
+// where the keys and certificate chains of local test TSAs are stored.
+const LocalTSADir = "localtsa"
+
+// maxRequestBodyLength is the maximum size of a timestamp request body.
+const maxRequestBodyLength = 1024 * 1024
+
+var (
+	oidSignedData           = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 2}
+	oidContentType          = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 3}
+	oidMessageDigest        = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 4}
+	oidSigningTime          = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 5}
+	oidTSTInfo              = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 16, 1, 4}
+	oidSigningCertificateV2 = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 16, 2, 47}
+	oidSHA256               = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 1}
+	oidSHA384               = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 2}
+	oidSHA512               = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 3}
+	oidRSA                  = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 1}
+	oidECDSAWithSHA256      = asn1.ObjectIdentifier{1, 2, 840, 10045, 4, 3, 2}
+	oidECDSAWithSHA384      = asn1.ObjectIdentifier{1, 2, 840, 10045, 4, 3, 3}
+	oidECDSAWithSHA512      = asn1.ObjectIdentifier{1, 2, 840, 10045, 4, 3, 4}
+
+	// oidTestPolicy is the TSA policy of the local test TSA. It is under the
+	// example arc 2.999 reserved for documentation and testing.
+	oidTestPolicy = asn1.ObjectIdentifier{2, 999, 1}
+)
+
+// contentInfo is the CMS ContentInfo defined in RFC 5652 3.
+type contentInfo struct {
+	ContentType asn1.ObjectIdentifier
+	Content     signedData `asn1:"explicit,tag:0"`
+}
+
+// signedData is the CMS SignedData defined in RFC 5652 5.1.
+type signedData struct {
+	Version                    int
+	DigestAlgorithmIdentifiers []pkix.AlgorithmIdentifier `asn1:"set"`
+	EncapsulatedContentInfo    encapsulatedContentInfo
+	Certificates               asn1.RawValue `asn1:"optional,tag:0"`
+	SignerInfos                []signerInfo  `asn1:"set"`
+}
+
+// encapsulatedContentInfo is the CMS EncapsulatedContentInfo defined in
+// RFC 5652 5.2.
+type encapsulatedContentInfo struct {
+	ContentType asn1.ObjectIdentifier
+	Content     []byte `asn1:"explicit,optional,tag:0"`
+}
+
+// signerInfo is the CMS SignerInfo defined in RFC 5652 5.3.
+type signerInfo struct {
+	Version            int
+	SignerIdentifier   issuerAndSerialNumber
+	DigestAlgorithm    pkix.AlgorithmIdentifier
+	SignedAttributes   asn1.RawValue `asn1:"optional,tag:0"`
+	SignatureAlgorithm pkix.AlgorithmIdentifier
+	Signature          []byte
+}
+
+// issuerAndSerialNumber is the CMS IssuerAndSerialNumber defined in
+// RFC 5652 5.3.
+type issuerAndSerialNumber struct {
+	Issuer       asn1.RawValue
+	SerialNumber *big.Int
+}
+
+// attribute is the CMS Attribute defined in RFC 5652 5.3.
+type attribute struct {
+	Type   asn1.ObjectIdentifier
+	Values asn1.RawValue `asn1:"set"`
+}
+
+// signingCertificateV2 is the SigningCertificateV2 attribute defined in
+// RFC 5035 3.
+type signingCertificateV2 struct {
+	Certificates []essCertIDv2
+}
+
+// essCertIDv2 is the ESSCertIDv2 defined in RFC 5035 4. The hash algorithm is
+// omitted as SHA-256 is the default value.
+type essCertIDv2 struct {
+	CertHash []byte
+}
+
+// Server is a minimal RFC 3161 timestamping authority which signs timestamp
+// tokens with a local key. It MUST only be used for testing.
+type Server struct {
+	key       crypto.Signer
+	certChain []*x509.Certificate
+	hash      crypto.Hash
+	digestAlg asn1.ObjectIdentifier
+	sigAlg    asn1.ObjectIdentifier
+}
+
+// New creates a timestamping authority signing with key. certChain is ordered
+// from the TSA signing certificate to the root certificate, and must be a
+// valid timestamping certificate chain.
+func New(key crypto.Signer, certChain []*x509.Certificate) (*Server, error) {
+	if len(certChain) == 0 {
+		return nil, errors.New("certificate chain of the timestamping authority cannot be empty")
+	}
+	if err := corex509.ValidateTimestampingCertChain(certChain); err != nil {
+		return nil, err
+	}
+	s := &Server{
+		key:       key,
+		certChain: certChain,
+	}
+	switch pub := key.Public().(type) {
+	case *rsa.PublicKey:
+		s.hash, s.digestAlg, s.sigAlg = crypto.SHA256, oidSHA256, oidRSA
+	case *ecdsa.PublicKey:
+		switch pub.Curve {
+		case elliptic.P256():
+			s.hash, s.digestAlg, s.sigAlg = crypto.SHA256, oidSHA256, oidECDSAWithSHA256
+		case elliptic.P384():
+			s.hash, s.digestAlg, s.sigAlg = crypto.SHA384, oidSHA384, oidECDSAWithSHA384
+		case elliptic.P521():
+			s.hash, s.digestAlg, s.sigAlg = crypto.SHA512, oidSHA512, oidECDSAWithSHA512
+		default:
+			return nil, fmt.Errorf("unsupported ECDSA curve %s", pub.Curve.Params().Name)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", pub)
+	}
+	return s, nil
+}
+
+// Load creates a timestamping authority from the local test TSA name
+// generated by `notation certificate generate-test --tsa`.
+func Load(name string) (*Server, error) {
+	relativeKeyPath, relativeCertPath := LocalTSAPath(name)
+	configFS := dir.ConfigFS()
+	keyPath, err := configFS.SysPath(relativeKeyPath)
+	if err != nil {
+		return nil, err
+	}
+	certPath, err := configFS.SysPath(relativeCertPath)
+	if err != nil {
+		return nil, err
+	}
+	keyPEM, err := os.ReadFile(keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the key of timestamping authority %q: %w", name, err)
+	}
+	block, _ := pem.Decode(keyPEM)
+	if block == nil {
+		return nil, fmt.Errorf("failed to decode the key of timestamping authority %q: no PEM block found", name)
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the key of timestamping authority %q: %w", name, err)
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported key type %T", key)
+	}
+	certChain, err := corex509.ReadCertificateFile(certPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the certificate chain of timestamping authority %q: %w", name, err)
+	}
+	return New(signer, certChain)
+}
+
+// LocalTSAPath returns the key path and the certificate chain path of the
+// local test TSA name, relative to the Notation configuration directory.
+func LocalTSAPath(name string) (keyPath, certPath string) {
+	basePath := filepath.Join(LocalTSADir, name)
+	return basePath + dir.LocalKeyExtension, basePath + dir.LocalCertificateExtension
+}
+
+// ServeHTTP handles timestamp requests as defined in RFC 3161 3.4.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if contentType := r.Header.Get("Content-Type"); contentType != tspclient.MediaTypeTimestampQuery {
+		http.Error(w, fmt.Sprintf("unsupported content type %q", contentType), http.StatusUnsupportedMediaType)
+		return
+	}
+	reqBytes, err := io.ReadAll(io.LimitReader(r.Body, maxRequestBodyLength))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	logger := log.GetLogger(r.Context())
+	var resp *tspclient.Response
+	var req tspclient.Request
+	if err := req.UnmarshalBinary(reqBytes); err != nil {
+		logger.Warnf("Rejected malformed timestamp request: %v", err)
+		resp = rejection(pki.FailureInfoBadDataFormat)
+	} else if err := req.Validate(); err != nil {
+		logger.Warnf("Rejected invalid timestamp request: %v", err)
+		resp = rejection(pki.FailureInfoBadRequest)
+	} else if resp, err = s.Timestamp(&req); err != nil {
+		logger.Errorf("Failed to issue timestamp token: %v", err)
+		resp = rejection(pki.FailureInfoSystemFailure)
+	} else {
+		logger.Infof("Issued timestamp token for message imprint %x", req.MessageImprint.HashedMessage)
+	}
+	respBytes, err := resp.MarshalBinary()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", tspclient.MediaTypeTimestampReply)
+	w.Write(respBytes)
+}
+
+// Timestamp issues a timestamp token for req with the current time.
+func (s *Server) Timestamp(req *tspclient.Request) (*tspclient.Response, error) {
+	if req.ReqPolicy != nil && !req.ReqPolicy.Equal(oidTestPolicy) {
+		return rejection(pki.FailureInfoUnacceptedPolicy), nil
+	}
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, err
+	}
+	// encoding/asn1 encodes GeneralizedTime with a precision of seconds.
+	// The accuracy is omitted so that the lower limit of the timestamp is not
+	// earlier than the signing time of a signature signed in the same second.
+	genTime := time.Now().UTC().Truncate(time.Second)
+	info, err := asn1.Marshal(tspclient.TSTInfo{
+		Version:        1,
+		Policy:         oidTestPolicy,
+		MessageImprint: req.MessageImprint,
+		SerialNumber:   serialNumber,
+		GenTime:        genTime,
+		Nonce:          req.Nonce,
+	})
+	if err != nil {
+		return nil, err
+	}
+	token, err := s.sign(info, genTime, req.CertReq)
+	if err != nil {
+		return nil, err
+	}
+	return &tspclient.Response{
+		Status:         pki.StatusInfo{Status: pki.StatusGranted},
+		TimestampToken: asn1.RawValue{FullBytes: token},
+	}, nil
+}
+
+// sign wraps the encoded TSTInfo into a CMS SignedData signed by the TSA
+// signing certificate.
+//
+// Reference: RFC 3161 2.4.2
+func (s *Server) sign(info []byte, signingTime time.Time, certReq bool) ([]byte, error) {
+	signingCert := s.certChain[0]
+	h := s.hash.New()
+	h.Write(info)
+	contentDigest := h.Sum(nil)
+	certHash := sha256.Sum256(signingCert.Raw)
+	signedAttributes := make([]attribute, 0, 4)
+	for _, attr := range []struct {
+		oid   asn1.ObjectIdentifier
+		value any
+	}{
+		{oidContentType, oidTSTInfo},
+		{oidMessageDigest, contentDigest},
+		{oidSigningTime, signingTime},
+		{oidSigningCertificateV2, signingCertificateV2{Certificates: []essCertIDv2{{CertHash: certHash[:]}}}},
+	} {
+		value, err := asn1.Marshal(attr.value)
+		if err != nil {
+			return nil, err
+		}
+		signedAttributes = append(signedAttributes, attribute{
+			Type: attr.oid,
+			Values: asn1.RawValue{
+				Class:      asn1.ClassUniversal,
+				Tag:        asn1.TagSet,
+				IsCompound: true,
+				Bytes:      value,
+			},
+		})
+	}
+
+	// the signature is computed over the DER encoding of the signed
+	// attributes as a SET OF.
+	// Reference: RFC 5652 5.4
+	encodedAttributes, err := asn1.MarshalWithParams(signedAttributes, "set")
+	if err != nil {
+		return nil, err
+	}
+	// the signed attributes are embedded with the IMPLICIT [0] tag in place of
+	// the SET OF tag, keeping the content octets identical to the signed ones.
+	var rawAttributes asn1.RawValue
+	if _, err := asn1.Unmarshal(encodedAttributes, &rawAttributes); err != nil {
+		return nil, err
+	}
+	h = s.hash.New()
+	h.Write(encodedAttributes)
+	signature, err := s.key.Sign(rand.Reader, h.Sum(nil), s.hash)
+	if err != nil {
+		return nil, err
+	}
+
+	digestAlgorithm := pkix.AlgorithmIdentifier{Algorithm: s.digestAlg}
+	signed := signedData{
+		Version:                    3,
+		DigestAlgorithmIdentifiers: []pkix.AlgorithmIdentifier{digestAlgorithm},
+		EncapsulatedContentInfo: encapsulatedContentInfo{
+			ContentType: oidTSTInfo,
+			Content:     info,
+		},
+		SignerInfos: []signerInfo{{
+			Version: 1,
+			SignerIdentifier: issuerAndSerialNumber{
+				Issuer:       asn1.RawValue{FullBytes: signingCert.RawIssuer},
+				SerialNumber: signingCert.SerialNumber,
+			},
+			DigestAlgorithm: digestAlgorithm,
+			SignedAttributes: asn1.RawValue{
+				Class:      asn1.ClassContextSpecific,
+				Tag:        0,
+				IsCompound: true,
+				Bytes:      rawAttributes.Bytes,
+			},
+			SignatureAlgorithm: pkix.AlgorithmIdentifier{Algorithm: s.sigAlg},
+			Signature:          signature,
+		}},
+	}
+	if certReq {
+		var certs []byte
+		for _, cert := range s.certChain {
+			certs = append(certs, cert.Raw...)
+		}
+		signed.Certificates = asn1.RawValue{
+			Class:      asn1.ClassContextSpecific,
+			Tag:        0,
+			IsCompound: true,
+			Bytes:      certs,
+		}
+	}
+	return asn1.Marshal(contentInfo{
+		ContentType: oidSignedData,
+		Content:     signed,
+	})
+}
+
+// rejection returns a timestamp response rejecting the request.
+func rejection(failureInfo pki.FailureInfo) *tspclient.Response {
+	return &tspclient.Response{
+		Status: pki.StatusInfo{
+			Status:   pki.StatusRejection,
+			FailInfo: failureInfoBitString(failureInfo),
+		},
+	}
+}
+
+// failureInfoBitString encodes failureInfo as a PKIFailureInfo BIT STRING
+// with the bit of failureInfo set.
+func failureInfoBitString(failureInfo pki.FailureInfo) asn1.BitString {
+	bitLength := int(failureInfo) + 1
+	bytes := make([]byte, (bitLength+7)/8)
+	bytes[int(failureInfo)/8] = 0x80 >> (int(failureInfo) % 8)
+	return asn1.BitString{Bytes: bytes, BitLength: bitLength}
+}
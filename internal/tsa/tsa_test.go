@@ -0,0 +1,191 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tsa
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/asn1"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/notaryproject/notation-core-go/testhelper"
+	"github.com/notaryproject/notation/internal/tsa/tsatest"
+	"github.com/notaryproject/tspclient-go"
+)
+
+func TestServer(t *testing.T) {
+	key, certChain := tsatest.GenerateCertChain(t)
+	server, err := New(key, certChain)
+	if err != nil {
+		t.Fatalf("New() failed: %v", err)
+	}
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+	timestamper, err := tspclient.NewHTTPTimestamper(nil, ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	roots := x509.NewCertPool()
+	roots.AddCert(certChain[1])
+	message := []byte("notation")
+	digest := sha256.Sum256(message)
+
+	for _, certReq := range []bool{true, false} {
+		req, err := tspclient.NewRequest(tspclient.RequestOptions{
+			Content:       message,
+			HashAlgorithm: crypto.SHA256,
+			NoCert:        !certReq,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Timestamp validates the response against the request
+		resp, err := timestamper.Timestamp(context.Background(), req)
+		if err != nil {
+			t.Fatalf("Timestamp() with certReq %v failed: %v", certReq, err)
+		}
+		if !certReq {
+			continue
+		}
+		token, err := resp.SignedToken()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := token.Verify(context.Background(), x509.VerifyOptions{Roots: roots}); err != nil {
+			t.Fatalf("Verify() failed: %v", err)
+		}
+		info, err := token.Info()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := info.Validate(message); err != nil {
+			t.Fatalf("Validate() failed: %v", err)
+		}
+		if string(info.MessageImprint.HashedMessage) != string(digest[:]) {
+			t.Fatal("unexpected message imprint")
+		}
+	}
+}
+
+func TestServer_SignedAttributes(t *testing.T) {
+	key, certChain := tsatest.GenerateCertChain(t)
+	server, err := New(key, certChain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req, err := tspclient.NewRequest(tspclient.RequestOptions{
+		Content:       []byte("notation"),
+		HashAlgorithm: crypto.SHA256,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := server.Timestamp(req)
+	if err != nil {
+		t.Fatalf("Timestamp() failed: %v", err)
+	}
+	var token contentInfo
+	if _, err := asn1.Unmarshal(resp.TimestampToken.FullBytes, &token); err != nil {
+		t.Fatal(err)
+	}
+	if len(token.Content.SignerInfos) != 1 {
+		t.Fatalf("expected 1 signer info, but got %d", len(token.Content.SignerInfos))
+	}
+	signer := token.Content.SignerInfos[0]
+	embedded := signer.SignedAttributes.FullBytes
+	if len(embedded) == 0 || embedded[0] != 0xa0 {
+		t.Fatalf("expected the signed attributes to be tagged with IMPLICIT [0], but got %x", embedded)
+	}
+
+	// the signature is computed over the embedded signed attributes with the
+	// SET OF tag.
+	// Reference: RFC 5652 5.4
+	signedBytes := append([]byte{0x31}, embedded[1:]...)
+	var attributes []attribute
+	if rest, err := asn1.UnmarshalWithParams(signedBytes, &attributes, "set"); err != nil || len(rest) != 0 {
+		t.Fatalf("failed to parse the signed attributes: %v", err)
+	}
+	if len(attributes) != 4 {
+		t.Fatalf("expected 4 signed attributes, but got %d", len(attributes))
+	}
+	reencoded, err := asn1.MarshalWithParams(attributes, "set")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(reencoded, signedBytes) {
+		t.Fatal("expected the embedded signed attributes to be DER encoded")
+	}
+	digest := sha512.Sum384(signedBytes)
+	if !ecdsa.VerifyASN1(certChain[0].PublicKey.(*ecdsa.PublicKey), digest[:], signer.Signature) {
+		t.Fatal("expected the signature to be computed over the embedded signed attributes")
+	}
+}
+
+func TestServer_Rejection(t *testing.T) {
+	server, err := New(tsatest.GenerateCertChain(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("malformed request", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("malformed"))
+		r.Header.Set("Content-Type", tspclient.MediaTypeTimestampQuery)
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, r)
+		var resp tspclient.Response
+		if err := resp.UnmarshalBinary(w.Body.Bytes()); err != nil {
+			t.Fatal(err)
+		}
+		expectedErrMsg := "invalid response with status code 2: rejected. Failure info: the data submitted has the wrong format"
+		if err := resp.Status.Err(); err == nil || err.Error() != expectedErrMsg {
+			t.Fatalf("expected %s, but got %v", expectedErrMsg, err)
+		}
+	})
+
+	t.Run("unsupported content type", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("malformed"))
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, r)
+		if w.Code != http.StatusUnsupportedMediaType {
+			t.Fatalf("expected status code %d, but got %d", http.StatusUnsupportedMediaType, w.Code)
+		}
+	})
+
+	t.Run("unsupported method", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, r)
+		if w.Code != http.StatusMethodNotAllowed {
+			t.Fatalf("expected status code %d, but got %d", http.StatusMethodNotAllowed, w.Code)
+		}
+	})
+}
+
+func TestNew_InvalidCertChain(t *testing.T) {
+	chain := testhelper.GetRevokableRSAChain(2)
+	if _, err := New(chain[0].PrivateKey, []*x509.Certificate{chain[0].Cert, chain[1].Cert}); err == nil {
+		t.Fatal("expected error for a code signing certificate chain, but got nil")
+	}
+	if _, err := New(chain[0].PrivateKey, nil); err == nil {
+		t.Fatal("expected error for an empty certificate chain, but got nil")
+	}
+}
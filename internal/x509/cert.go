@@ -15,7 +15,11 @@ package x509
 
 import (
 	"bytes"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
 	"crypto/x509"
+	"encoding/pem"
 	"fmt"
 
 	corex509 "github.com/notaryproject/notation-core-go/x509"
@@ -30,9 +34,52 @@ func IsRootCertificate(cert *x509.Certificate) (bool, error) {
 	return bytes.Equal(cert.RawSubject, cert.RawIssuer), nil
 }
 
+// ParseCertificates parses certificates from data in either PEM or DER format.
+// An empty list is returned if no certificate is found.
+func ParseCertificates(data []byte) ([]*x509.Certificate, error) {
+	block, rest := pem.Decode(data)
+	if block == nil {
+		// data may be in DER format
+		return x509.ParseCertificates(data)
+	}
+	var certs []*x509.Certificate
+	for block != nil {
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+		block, rest = pem.Decode(rest)
+	}
+	return certs, nil
+}
+
 // NewRootCertPool returns a new x509 CertPool containing the root certificate
 // from rootCertificatePath.
 func NewRootCertPool(rootCertificatePath string) (*x509.CertPool, error) {
+	return NewRootCertPoolFromFiles(rootCertificatePath)
+}
+
+// NewRootCertPoolFromFiles returns a new x509 CertPool containing the root
+// certificates from rootCertificatePaths, each of which contains a single
+// root certificate.
+func NewRootCertPoolFromFiles(rootCertificatePaths ...string) (*x509.CertPool, error) {
+	rootCAs := x509.NewCertPool()
+	for _, path := range rootCertificatePaths {
+		rootCerts, err := ReadRootCertificate(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, rootCert := range rootCerts {
+			rootCAs.AddCert(rootCert)
+		}
+	}
+	return rootCAs, nil
+}
+
+// ReadRootCertificate reads the single root certificate from
+// rootCertificatePath.
+func ReadRootCertificate(rootCertificatePath string) ([]*x509.Certificate, error) {
 	rootCerts, err := corex509.ReadCertificateFile(rootCertificatePath)
 	if err != nil {
 		return nil, err
@@ -51,7 +98,79 @@ func NewRootCertPool(rootCertificatePath string) (*x509.CertPool, error) {
 	if !isRoot {
 		return nil, fmt.Errorf("certificate from %q is not a root certificate. Expecting single x509 root certificate in PEM or DER format from the file", rootCertificatePath)
 	}
-	rootCAs := x509.NewCertPool()
-	rootCAs.AddCert(rootCert)
-	return rootCAs, nil
+	return rootCerts, nil
+}
+
+// extKeyUsageNames maps extended key usages to their names defined in
+// RFC 5280 4.2.1.12.
+var extKeyUsageNames = map[x509.ExtKeyUsage]string{
+	x509.ExtKeyUsageAny:             "any",
+	x509.ExtKeyUsageServerAuth:      "serverAuth",
+	x509.ExtKeyUsageClientAuth:      "clientAuth",
+	x509.ExtKeyUsageCodeSigning:     "codeSigning",
+	x509.ExtKeyUsageEmailProtection: "emailProtection",
+	x509.ExtKeyUsageTimeStamping:    "timeStamping",
+	x509.ExtKeyUsageOCSPSigning:     "OCSPSigning",
+}
+
+// ExtKeyUsageNames returns the names of the extended key usages of cert.
+// Extended key usages without a well-known name are returned as object
+// identifiers.
+func ExtKeyUsageNames(cert *x509.Certificate) []string {
+	var names []string
+	for _, usage := range cert.ExtKeyUsage {
+		if name, ok := extKeyUsageNames[usage]; ok {
+			names = append(names, name)
+		} else {
+			names = append(names, fmt.Sprintf("unknown(%d)", usage))
+		}
+	}
+	for _, usage := range cert.UnknownExtKeyUsage {
+		names = append(names, usage.String())
+	}
+	return names
+}
+
+// keyUsageNames lists the key usages in the order of the bits defined in
+// RFC 5280 4.2.1.3 along with their names.
+var keyUsageNames = []struct {
+	usage x509.KeyUsage
+	name  string
+}{
+	{x509.KeyUsageDigitalSignature, "digitalSignature"},
+	{x509.KeyUsageContentCommitment, "contentCommitment"},
+	{x509.KeyUsageKeyEncipherment, "keyEncipherment"},
+	{x509.KeyUsageDataEncipherment, "dataEncipherment"},
+	{x509.KeyUsageKeyAgreement, "keyAgreement"},
+	{x509.KeyUsageCertSign, "keyCertSign"},
+	{x509.KeyUsageCRLSign, "cRLSign"},
+	{x509.KeyUsageEncipherOnly, "encipherOnly"},
+	{x509.KeyUsageDecipherOnly, "decipherOnly"},
+}
+
+// KeyUsageNames returns the names of the key usages of cert defined in
+// RFC 5280 4.2.1.3.
+func KeyUsageNames(cert *x509.Certificate) []string {
+	var names []string
+	for _, keyUsage := range keyUsageNames {
+		if cert.KeyUsage&keyUsage.usage != 0 {
+			names = append(names, keyUsage.name)
+		}
+	}
+	return names
+}
+
+// PublicKeyAlgorithm returns the algorithm and the size in bits of the public
+// key of cert. The size is 0 if the algorithm is not supported.
+func PublicKeyAlgorithm(cert *x509.Certificate) (string, int) {
+	switch key := cert.PublicKey.(type) {
+	case *rsa.PublicKey:
+		return "RSA", key.N.BitLen()
+	case *ecdsa.PublicKey:
+		return "ECDSA", key.Curve.Params().BitSize
+	case ed25519.PublicKey:
+		return "Ed25519", 256
+	default:
+		return cert.PublicKeyAlgorithm.String(), 0
+	}
 }
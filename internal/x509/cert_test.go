@@ -14,6 +14,10 @@
 package x509
 
 import (
+	"crypto/x509"
+	"encoding/asn1"
+	"os"
+	"reflect"
 	"testing"
 
 	corex509 "github.com/notaryproject/notation-core-go/x509"
@@ -65,3 +69,92 @@ func TestIsRootCertificate(t *testing.T) {
 		t.Fatal("expected IsRootCertificate to return false")
 	}
 }
+
+func TestNewRootCertPoolFromFiles(t *testing.T) {
+	rootCAs, err := NewRootCertPoolFromFiles("../testdata/tsaRootCA.cer", "../testdata/GlobalSignRootCA.crt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := len(rootCAs.Subjects()); got != 2 {
+		t.Fatalf("expected 2 root certificates, but got %d", got)
+	}
+
+	if _, err := NewRootCertPoolFromFiles("../testdata/tsaRootCA.cer", "../testdata/CertChain.pem"); err == nil {
+		t.Fatal("expected error for certificate chain file")
+	}
+	if _, err := NewRootCertPoolFromFiles("../testdata/tsaRootCA.cer", "../testdata/intermediate.pem"); err == nil {
+		t.Fatal("expected error for non-root certificate")
+	}
+}
+
+func TestParseCertificates(t *testing.T) {
+	for _, path := range []string{"../testdata/intermediate.pem", "../testdata/tsaRootCA.cer"} {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected, err := corex509.ReadCertificateFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		certs, err := ParseCertificates(data)
+		if err != nil {
+			t.Fatalf("ParseCertificates(%q) failed: %v", path, err)
+		}
+		if !reflect.DeepEqual(certs, expected) {
+			t.Fatalf("ParseCertificates(%q) returned %d certificate(s), expected %d", path, len(certs), len(expected))
+		}
+	}
+
+	if _, err := ParseCertificates([]byte("not a certificate")); err == nil {
+		t.Fatal("expected error for invalid certificate data, but ok")
+	}
+}
+
+func TestExtKeyUsageNames(t *testing.T) {
+	cert := &x509.Certificate{
+		ExtKeyUsage:        []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning, x509.ExtKeyUsageTimeStamping},
+		UnknownExtKeyUsage: []asn1.ObjectIdentifier{{1, 2, 3, 4}},
+	}
+	expected := []string{"codeSigning", "timeStamping", "1.2.3.4"}
+	if names := ExtKeyUsageNames(cert); !reflect.DeepEqual(names, expected) {
+		t.Fatalf("expected %v, but got %v", expected, names)
+	}
+	if names := ExtKeyUsageNames(&x509.Certificate{}); len(names) != 0 {
+		t.Fatalf("expected no names, but got %v", names)
+	}
+}
+
+func TestKeyUsageNames(t *testing.T) {
+	cert := &x509.Certificate{
+		KeyUsage: x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+	}
+	want := []string{"digitalSignature", "keyCertSign", "cRLSign"}
+	if got := KeyUsageNames(cert); !reflect.DeepEqual(got, want) {
+		t.Fatalf("KeyUsageNames() = %v, want %v", got, want)
+	}
+	if got := KeyUsageNames(&x509.Certificate{}); got != nil {
+		t.Fatalf("KeyUsageNames() = %v, want nil", got)
+	}
+}
+
+func TestPublicKeyAlgorithm(t *testing.T) {
+	tests := []struct {
+		path          string
+		wantAlgorithm string
+		wantSize      int
+	}{
+		{path: "../testdata/tsaRootCA.cer", wantAlgorithm: "RSA", wantSize: 4096},
+		{path: "../testdata/intermediate.pem", wantAlgorithm: "RSA", wantSize: 2048},
+	}
+	for _, tt := range tests {
+		certs, err := corex509.ReadCertificateFile(tt.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		algorithm, size := PublicKeyAlgorithm(certs[0])
+		if algorithm != tt.wantAlgorithm || size != tt.wantSize {
+			t.Errorf("PublicKeyAlgorithm(%q) = %s %d, want %s %d", tt.path, algorithm, size, tt.wantAlgorithm, tt.wantSize)
+		}
+	}
+}
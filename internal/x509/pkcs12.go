@@ -0,0 +1,83 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package x509
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"slices"
+
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+// publicKeyEqualer is implemented by all public key types of crypto/rsa,
+// crypto/ecdsa and crypto/ed25519.
+type publicKeyEqualer interface {
+	Equal(x crypto.PublicKey) bool
+}
+
+// ParsePKCS12 decodes a PKCS#12 bundle protected by password and returns the
+// private key along with the certificate chain ordered from the leaf
+// certificate to the root certificate.
+//
+// CA certificates in the bundle that are not part of the leaf certificate's
+// chain are dropped.
+func ParsePKCS12(data []byte, password string) (crypto.PrivateKey, []*x509.Certificate, error) {
+	key, leaf, caCerts, err := pkcs12.DecodeChain(data, password)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decode PKCS#12 bundle: %w", err)
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, nil, fmt.Errorf("unsupported private key type %T in PKCS#12 bundle", key)
+	}
+	pub, ok := leaf.PublicKey.(publicKeyEqualer)
+	if !ok || !pub.Equal(signer.Public()) {
+		return nil, nil, errors.New("private key in PKCS#12 bundle does not match the public key of the leaf certificate")
+	}
+	return key, buildCertChain(leaf, caCerts), nil
+}
+
+// buildCertChain orders caCerts into a chain starting from leaf by following
+// issuer relationships. The walk stops at a self-issued certificate or when no
+// issuer can be found.
+func buildCertChain(leaf *x509.Certificate, caCerts []*x509.Certificate) []*x509.Certificate {
+	chain := []*x509.Certificate{leaf}
+	remaining := slices.Clone(caCerts)
+	current := leaf
+	for !bytes.Equal(current.RawSubject, current.RawIssuer) {
+		index := slices.IndexFunc(remaining, func(candidate *x509.Certificate) bool {
+			return bytes.Equal(candidate.RawSubject, current.RawIssuer) && current.CheckSignatureFrom(candidate) == nil
+		})
+		if index < 0 {
+			break
+		}
+		current = remaining[index]
+		chain = append(chain, current)
+		remaining = slices.Delete(remaining, index, index+1)
+	}
+	return chain
+}
+
+// ValidateCodeSigningEKU returns nil if cert has the code signing extended key
+// usage.
+func ValidateCodeSigningEKU(cert *x509.Certificate) error {
+	if !slices.Contains(cert.ExtKeyUsage, x509.ExtKeyUsageCodeSigning) {
+		return fmt.Errorf("certificate with subject %q does not have the code signing extended key usage", cert.Subject)
+	}
+	return nil
+}
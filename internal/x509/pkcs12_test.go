@@ -0,0 +1,78 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package x509
+
+import (
+	"crypto/x509"
+	"testing"
+
+	"github.com/notaryproject/notation-core-go/testhelper"
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+func TestParsePKCS12(t *testing.T) {
+	chain := testhelper.GetRevokableRSAChain(3)
+	leaf := chain[0]
+	// put the CA certificates in reverse order to make sure the chain is
+	// rebuilt from issuer relationships
+	caCerts := []*x509.Certificate{chain[2].Cert, chain[1].Cert}
+	data, err := pkcs12.Modern.Encode(leaf.PrivateKey, leaf.Cert, caCerts, "password")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("valid bundle", func(t *testing.T) {
+		key, certs, err := ParsePKCS12(data, "password")
+		if err != nil {
+			t.Fatalf("expected no error, but got %v", err)
+		}
+		if key == nil {
+			t.Fatal("expected private key")
+		}
+		if len(certs) != 3 {
+			t.Fatalf("expected 3 certificates, but got %d", len(certs))
+		}
+		for i, cert := range certs {
+			if !cert.Equal(chain[i].Cert) {
+				t.Fatalf("certificate %d is out of order", i)
+			}
+		}
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		if _, _, err := ParsePKCS12(data, "wrong"); err == nil {
+			t.Fatal("expected error, but got nil")
+		}
+	})
+
+	t.Run("mismatched private key", func(t *testing.T) {
+		data, err := pkcs12.Modern.Encode(testhelper.GetRSARootCertificate().PrivateKey, leaf.Cert, nil, "password")
+		if err != nil {
+			t.Fatal(err)
+		}
+		expectedErrMsg := "private key in PKCS#12 bundle does not match the public key of the leaf certificate"
+		if _, _, err := ParsePKCS12(data, "password"); err == nil || err.Error() != expectedErrMsg {
+			t.Fatalf("expected %s, but got %v", expectedErrMsg, err)
+		}
+	})
+}
+
+func TestValidateCodeSigningEKU(t *testing.T) {
+	if err := ValidateCodeSigningEKU(testhelper.GetRSALeafCertificate().Cert); err != nil {
+		t.Fatalf("expected no error, but got %v", err)
+	}
+	if err := ValidateCodeSigningEKU(testhelper.GetRSALeafCertificateWithoutEKU().Cert); err == nil {
+		t.Fatal("expected error, but got nil")
+	}
+}
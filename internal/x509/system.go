@@ -0,0 +1,78 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package x509
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	corex509 "github.com/notaryproject/notation-core-go/x509"
+)
+
+// SystemStoreName is the reserved name of the named store of type tsa
+// resolved from the system root certificate bundle instead of the notation
+// trust store.
+const SystemStoreName = "system"
+
+// systemCertFileEnv is the environment variable overriding the path of the
+// system root certificate bundle, consistent with the Go standard library.
+const systemCertFileEnv = "SSL_CERT_FILE"
+
+// systemCertFiles lists the well-known paths of the system root certificate
+// bundle on Linux distributions.
+var systemCertFiles = []string{
+	"/etc/ssl/certs/ca-certificates.crt",                // Debian/Ubuntu/Gentoo etc.
+	"/etc/pki/tls/certs/ca-bundle.crt",                  // Fedora/RHEL 6
+	"/etc/ssl/ca-bundle.pem",                            // OpenSUSE
+	"/etc/pki/tls/cacert.pem",                           // OpenELEC
+	"/etc/pki/ca-trust/extracted/pem/tls-ca-bundle.pem", // CentOS/RHEL 7
+	"/etc/ssl/cert.pem",                                 // Alpine Linux
+}
+
+// SystemCertFile returns the path of the system root certificate bundle.
+// The SSL_CERT_FILE environment variable takes precedence over the well-known
+// paths.
+func SystemCertFile() (string, error) {
+	if path := os.Getenv(systemCertFileEnv); path != "" {
+		return path, nil
+	}
+	for _, path := range systemCertFiles {
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return "", err
+		}
+	}
+	return "", errors.New("cannot find the system root certificate bundle")
+}
+
+// ReadSystemCertificates reads the certificates of the system root
+// certificate bundle along with the path of the bundle.
+func ReadSystemCertificates() (string, []*x509.Certificate, error) {
+	path, err := SystemCertFile()
+	if err != nil {
+		return "", nil, err
+	}
+	certs, err := corex509.ReadCertificateFile(path)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read the system root certificate bundle %s: %w", path, err)
+	}
+	if len(certs) == 0 {
+		return "", nil, fmt.Errorf("no valid certificate found in the system root certificate bundle %s", path)
+	}
+	return path, certs, nil
+}
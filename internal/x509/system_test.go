@@ -0,0 +1,51 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package x509
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadSystemCertificates(t *testing.T) {
+	t.Run("SSL_CERT_FILE", func(t *testing.T) {
+		t.Setenv(systemCertFileEnv, "../testdata/tsaRootCA.cer")
+		path, certs, err := ReadSystemCertificates()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if path != "../testdata/tsaRootCA.cer" || len(certs) != 1 {
+			t.Fatalf("expected 1 certificate from ../testdata/tsaRootCA.cer, but got %d from %s", len(certs), path)
+		}
+	})
+
+	t.Run("empty bundle", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "empty.pem")
+		if err := os.WriteFile(path, nil, 0600); err != nil {
+			t.Fatal(err)
+		}
+		t.Setenv(systemCertFileEnv, path)
+		if _, _, err := ReadSystemCertificates(); err == nil {
+			t.Fatal("expected error for empty system root certificate bundle, but got nil")
+		}
+	})
+
+	t.Run("missing bundle", func(t *testing.T) {
+		t.Setenv(systemCertFileEnv, filepath.Join(t.TempDir(), "missing.pem"))
+		if _, _, err := ReadSystemCertificates(); err == nil {
+			t.Fatal("expected error for missing system root certificate bundle, but got nil")
+		}
+	})
+}
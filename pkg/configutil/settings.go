@@ -0,0 +1,237 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configutil
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/notaryproject/notation-go/dir"
+)
+
+// Settings contains the settings of the notation CLI in config.json that are
+// not defined by notation-go.
+type Settings struct {
+	// RevocationMode is the default revocation mode for verification,
+	// options: "online", "offline".
+	RevocationMode string `json:"revocationMode,omitempty"`
+
+	// Timeouts are the timeouts of requests to the endpoints.
+	Timeouts Timeouts `json:"timeouts"`
+
+	// Proxies are the HTTP(S) proxies of the endpoint classes.
+	Proxies Proxies `json:"proxies"`
+
+	// CABundle is the path of a PEM or DER certificate bundle trusted in
+	// addition to the system root certificates for TLS connections to all
+	// endpoints.
+	CABundle string `json:"caBundle,omitempty"`
+
+	// Timestamp contains the default timestamping settings for signing.
+	Timestamp TimestampSettings `json:"timestamp"`
+}
+
+// TimestampSettings contains the default timestamping settings for signing.
+type TimestampSettings struct {
+	// Servers are the RFC 3161 Timestamping Authority (TSA) servers tried in
+	// order until a timestamp countersignature is obtained. If not empty,
+	// signatures are timestamped by default.
+	Servers []TimestampServer `json:"servers,omitempty"`
+}
+
+// TimestampServer is an RFC 3161 Timestamping Authority (TSA) server.
+type TimestampServer struct {
+	// URL is the URL of the TSA server.
+	URL string `json:"url"`
+
+	// RootCert is the filepath of the root certificate of the TSA. It is
+	// mutually exclusive with TrustStore.
+	RootCert string `json:"rootCert,omitempty"`
+
+	// TrustStore is the name of the trust store of type tsa containing the
+	// root certificates of the TSA, or "system" to use the system root
+	// certificate bundle. It is mutually exclusive with RootCert.
+	TrustStore string `json:"trustStore,omitempty"`
+}
+
+// Default timeouts of requests to the endpoints.
+const (
+	DefaultOCSPTimeout      = 2 * time.Second
+	DefaultCRLTimeout       = 5 * time.Second
+	DefaultTimestampTimeout = 15 * time.Second
+)
+
+// Timeouts contains the timeouts of requests to the endpoints. Zero values
+// mean the default timeouts.
+type Timeouts struct {
+	// OCSP is the timeout of OCSP requests.
+	OCSP Duration `json:"ocsp,omitempty"`
+
+	// CRL is the timeout of downloading CRLs.
+	CRL Duration `json:"crl,omitempty"`
+
+	// Timestamp is the timeout of requesting timestamp countersignatures.
+	Timestamp Duration `json:"timestamp,omitempty"`
+}
+
+// OCSPTimeout returns the timeout of OCSP requests, or DefaultOCSPTimeout if
+// not set.
+func (t Timeouts) OCSPTimeout() time.Duration {
+	return t.OCSP.OrDefault(DefaultOCSPTimeout)
+}
+
+// CRLTimeout returns the timeout of downloading CRLs, or DefaultCRLTimeout if
+// not set.
+func (t Timeouts) CRLTimeout() time.Duration {
+	return t.CRL.OrDefault(DefaultCRLTimeout)
+}
+
+// TimestampTimeout returns the timeout of requesting timestamp
+// countersignatures, or DefaultTimestampTimeout if not set.
+func (t Timeouts) TimestampTimeout() time.Duration {
+	return t.Timestamp.OrDefault(DefaultTimestampTimeout)
+}
+
+// Duration is a time.Duration encoded as a string in JSON, such as "5s".
+type Duration time.Duration
+
+// UnmarshalJSON parses the duration from a JSON string.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("duration must be a string, such as \"5s\": %w", err)
+	}
+	duration, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	if duration < 0 {
+		return fmt.Errorf("duration %q cannot be negative", s)
+	}
+	*d = Duration(duration)
+	return nil
+}
+
+// OrDefault returns d as a time.Duration, or defaultDuration if d is zero.
+func (d Duration) OrDefault(defaultDuration time.Duration) time.Duration {
+	if d == 0 {
+		return defaultDuration
+	}
+	return time.Duration(d)
+}
+
+// EndpointClass is a class of endpoints notation connects to.
+type EndpointClass string
+
+const (
+	// EndpointRegistry is the class of OCI registries.
+	EndpointRegistry EndpointClass = "registry"
+
+	// EndpointTimestamp is the class of timestamp authorities.
+	EndpointTimestamp EndpointClass = "timestamp"
+
+	// EndpointRevocation is the class of OCSP responders and CRL
+	// distribution points.
+	EndpointRevocation EndpointClass = "revocation"
+)
+
+// Proxies contains the URLs of the HTTP(S) proxies of the endpoint classes.
+// Empty values mean the proxies from the environment variables HTTP_PROXY,
+// HTTPS_PROXY and NO_PROXY.
+type Proxies struct {
+	// Registry is the proxy of OCI registries.
+	Registry string `json:"registry,omitempty"`
+
+	// Timestamp is the proxy of timestamp authorities.
+	Timestamp string `json:"timestamp,omitempty"`
+
+	// Revocation is the proxy of OCSP responders and CRL distribution
+	// points.
+	Revocation string `json:"revocation,omitempty"`
+}
+
+// Proxy returns the URL of the proxy of the endpoint class, or nil if not
+// set.
+func (p Proxies) Proxy(class EndpointClass) (*url.URL, error) {
+	var proxy string
+	switch class {
+	case EndpointRegistry:
+		proxy = p.Registry
+	case EndpointTimestamp:
+		proxy = p.Timestamp
+	case EndpointRevocation:
+		proxy = p.Revocation
+	default:
+		return nil, fmt.Errorf("unknown endpoint class %q", class)
+	}
+	if proxy == "" {
+		return nil, nil
+	}
+	proxyURL, err := url.Parse(proxy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s proxy %q: %w", class, proxy, err)
+	}
+	switch proxyURL.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, fmt.Errorf("invalid %s proxy %q: scheme must be http, https or socks5", class, proxy)
+	}
+	return proxyURL, nil
+}
+
+// loadSettingsOnce is a function that invokes loadSettings only once.
+var loadSettingsOnce = sync.OnceValues(loadSettings)
+
+// LoadSettingsOnce returns the previously read CLI settings from config.json.
+// If config.json does not exist, empty settings are returned.
+// The returned settings are only suitable for read only scenarios for
+// short-lived processes.
+func LoadSettingsOnce() (*Settings, error) {
+	return loadSettingsOnce()
+}
+
+// loadSettings reads the CLI settings from config.json or returns empty
+// settings if not found.
+func loadSettings() (*Settings, error) {
+	path, err := dir.ConfigFS().SysPath(dir.PathConfigFile)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return &Settings{}, nil
+		}
+		return nil, err
+	}
+	var settings Settings
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	for i, server := range settings.Timestamp.Servers {
+		if server.URL == "" {
+			return nil, fmt.Errorf("failed to parse %s: timestamp server %d must have url", path, i+1)
+		}
+		if (server.RootCert == "") == (server.TrustStore == "") {
+			return nil, fmt.Errorf("failed to parse %s: timestamp server %d must have exactly one of rootCert and trustStore", path, i+1)
+		}
+	}
+	return &settings, nil
+}
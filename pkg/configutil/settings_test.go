@@ -0,0 +1,194 @@
+// Copyright The Notary Project Authors.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configutil
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/notaryproject/notation-go/dir"
+)
+
+func TestLoadSettingsOnce(t *testing.T) {
+	dir.UserConfigDir = t.TempDir()
+	defer func() {
+		dir.UserConfigDir = ""
+		loadSettingsOnce = sync.OnceValues(loadSettings)
+	}()
+
+	t.Run("config file not exist", func(t *testing.T) {
+		defer func() {
+			loadSettingsOnce = sync.OnceValues(loadSettings)
+		}()
+		settings, err := LoadSettingsOnce()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(*settings, Settings{}) {
+			t.Fatalf("expected empty settings, but got %+v", settings)
+		}
+	})
+
+	t.Run("revocation mode", func(t *testing.T) {
+		defer func() {
+			loadSettingsOnce = sync.OnceValues(loadSettings)
+		}()
+		if err := os.WriteFile(filepath.Join(dir.UserConfigDir, dir.PathConfigFile), []byte(`{"insecureRegistries":[],"revocationMode":"offline"}`), 0600); err != nil {
+			t.Fatal(err)
+		}
+		settings, err := LoadSettingsOnce()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if settings.RevocationMode != "offline" {
+			t.Fatalf("expected revocation mode offline, but got %q", settings.RevocationMode)
+		}
+		settings2, err := LoadSettingsOnce()
+		if err != nil || settings != settings2 {
+			t.Fatal("LoadSettingsOnce should return the same settings.")
+		}
+	})
+
+	t.Run("timeouts, proxies and ca bundle", func(t *testing.T) {
+		defer func() {
+			loadSettingsOnce = sync.OnceValues(loadSettings)
+		}()
+		content := `{"timeouts":{"ocsp":"3s","crl":"1m"},"proxies":{"revocation":"http://proxy.example:3128"},"caBundle":"/etc/ca.pem"}`
+		if err := os.WriteFile(filepath.Join(dir.UserConfigDir, dir.PathConfigFile), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+		settings, err := LoadSettingsOnce()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := settings.Timeouts.OCSPTimeout(); got != 3*time.Second {
+			t.Fatalf("expected OCSP timeout 3s, but got %v", got)
+		}
+		if got := settings.Timeouts.CRLTimeout(); got != time.Minute {
+			t.Fatalf("expected CRL timeout 1m, but got %v", got)
+		}
+		if got := settings.Timeouts.TimestampTimeout(); got != DefaultTimestampTimeout {
+			t.Fatalf("expected default timestamp timeout, but got %v", got)
+		}
+		proxy, err := settings.Proxies.Proxy(EndpointRevocation)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if proxy == nil || proxy.Host != "proxy.example:3128" {
+			t.Fatalf("expected revocation proxy proxy.example:3128, but got %v", proxy)
+		}
+		proxy, err = settings.Proxies.Proxy(EndpointRegistry)
+		if err != nil || proxy != nil {
+			t.Fatalf("expected no registry proxy, but got %v, %v", proxy, err)
+		}
+		if settings.CABundle != "/etc/ca.pem" {
+			t.Fatalf("expected ca bundle /etc/ca.pem, but got %q", settings.CABundle)
+		}
+	})
+
+	t.Run("invalid timeout", func(t *testing.T) {
+		defer func() {
+			loadSettingsOnce = sync.OnceValues(loadSettings)
+		}()
+		for _, content := range []string{`{"timeouts":{"ocsp":"3"}}`, `{"timeouts":{"crl":"-1s"}}`, `{"timeouts":{"timestamp":3}}`} {
+			if err := os.WriteFile(filepath.Join(dir.UserConfigDir, dir.PathConfigFile), []byte(content), 0600); err != nil {
+				t.Fatal(err)
+			}
+			loadSettingsOnce = sync.OnceValues(loadSettings)
+			if _, err := LoadSettingsOnce(); err == nil {
+				t.Fatalf("LoadSettingsOnce should fail for %s.", content)
+			}
+		}
+	})
+
+	t.Run("timestamp servers", func(t *testing.T) {
+		defer func() {
+			loadSettingsOnce = sync.OnceValues(loadSettings)
+		}()
+		content := `{"timestamp":{"servers":[{"url":"http://tsa1.example","rootCert":"/tsa1.crt"},{"url":"http://tsa2.example","rootCert":"/tsa2.crt"},{"url":"http://tsa3.example","trustStore":"tsa3"}]}}`
+		if err := os.WriteFile(filepath.Join(dir.UserConfigDir, dir.PathConfigFile), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+		settings, err := LoadSettingsOnce()
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := []TimestampServer{
+			{URL: "http://tsa1.example", RootCert: "/tsa1.crt"},
+			{URL: "http://tsa2.example", RootCert: "/tsa2.crt"},
+			{URL: "http://tsa3.example", TrustStore: "tsa3"},
+		}
+		if !reflect.DeepEqual(settings.Timestamp.Servers, expected) {
+			t.Fatalf("expected timestamp servers %v, but got %v", expected, settings.Timestamp.Servers)
+		}
+	})
+
+	t.Run("timestamp server without root cert", func(t *testing.T) {
+		defer func() {
+			loadSettingsOnce = sync.OnceValues(loadSettings)
+		}()
+		content := `{"timestamp":{"servers":[{"url":"http://tsa1.example"}]}}`
+		if err := os.WriteFile(filepath.Join(dir.UserConfigDir, dir.PathConfigFile), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := LoadSettingsOnce(); err == nil {
+			t.Fatal("LoadSettingsOnce should fail.")
+		}
+	})
+
+	t.Run("timestamp server with both root cert and trust store", func(t *testing.T) {
+		defer func() {
+			loadSettingsOnce = sync.OnceValues(loadSettings)
+		}()
+		content := `{"timestamp":{"servers":[{"url":"http://tsa1.example","rootCert":"/tsa1.crt","trustStore":"tsa1"}]}}`
+		if err := os.WriteFile(filepath.Join(dir.UserConfigDir, dir.PathConfigFile), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := LoadSettingsOnce(); err == nil {
+			t.Fatal("LoadSettingsOnce should fail.")
+		}
+	})
+
+	t.Run("invalid json", func(t *testing.T) {
+		defer func() {
+			loadSettingsOnce = sync.OnceValues(loadSettings)
+		}()
+		if err := os.WriteFile(filepath.Join(dir.UserConfigDir, dir.PathConfigFile), []byte("invalid json"), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := LoadSettingsOnce(); err == nil {
+			t.Fatal("LoadSettingsOnce should fail.")
+		}
+	})
+}
+
+func TestProxiesProxy(t *testing.T) {
+	proxies := Proxies{
+		Registry:  "ftp://proxy.example",
+		Timestamp: "://invalid",
+	}
+	if _, err := proxies.Proxy(EndpointRegistry); err == nil {
+		t.Fatal("expected error for unsupported proxy scheme")
+	}
+	if _, err := proxies.Proxy(EndpointTimestamp); err == nil {
+		t.Fatal("expected error for invalid proxy url")
+	}
+	if _, err := proxies.Proxy("unknown"); err == nil {
+		t.Fatal("expected error for unknown endpoint class")
+	}
+}
@@ -170,17 +170,25 @@ var _ = Describe("notation blob sign", func() {
 		})
 	})
 
+	It("with timestamping and tsa trust store", func() {
+		HostWithBlob(BaseBlobOptions(), func(notation *utils.ExecOpts, blobPath string, vhost *utils.VirtualHost) {
+			notation.WithWorkDir(vhost.AbsolutePath()).Exec("blob", "sign", "--timestamp-url", tsaURL, "--timestamp-trust-store", "e2e", blobPath).
+				MatchKeyWords(SignSuccessfully).
+				MatchKeyWords("Signature file written to")
+		})
+	})
+
 	It("with timestamp-root-cert but no timestamp-url", func() {
 		HostWithBlob(BaseOptions(), func(notation *utils.ExecOpts, blobPath string, vhost *utils.VirtualHost) {
 			notation.ExpectFailure().Exec("blob", "sign", "--timestamp-root-cert", filepath.Join(NotationE2EConfigPath, "timestamp", "DigiCertTSARootSHA384.cer"), blobPath).
-				MatchErrKeyWords("Error: if any flags in the group [timestamp-url timestamp-root-cert] are set they must all be set; missing [timestamp-url]")
+				MatchErrKeyWords("Error: timestamping: --timestamp-url is required when --timestamp-root-cert or --timestamp-trust-store is specified")
 		})
 	})
 
 	It("with timestamp-url but no timestamp-root-cert", func() {
 		HostWithBlob(BaseOptions(), func(notation *utils.ExecOpts, blobPath string, vhost *utils.VirtualHost) {
 			notation.ExpectFailure().Exec("blob", "sign", "--timestamp-url", tsaURL, blobPath).
-				MatchErrKeyWords("Error: if any flags in the group [timestamp-url timestamp-root-cert] are set they must all be set; missing [timestamp-root-cert]")
+				MatchErrKeyWords("Error: timestamping: either --timestamp-root-cert or --timestamp-trust-store is required when --timestamp-url is specified")
 		})
 	})
 
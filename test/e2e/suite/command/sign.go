@@ -205,17 +205,38 @@ var _ = Describe("notation sign", func() {
 		})
 	})
 
+	It("with timestamping and tsa trust store", func() {
+		Host(TimestampOptions(""), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+			notation.Exec("sign", "--timestamp-url", "http://timestamp.digicert.com", "--timestamp-trust-store", "e2e", artifact.ReferenceWithDigest()).
+				MatchKeyWords(SignSuccessfully)
+		})
+	})
+
+	It("with timestamping and both tsa root cert and tsa trust store", func() {
+		Host(TimestampOptions(""), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+			notation.ExpectFailure().Exec("sign", "--timestamp-url", "http://timestamp.digicert.com", "--timestamp-root-cert", filepath.Join(NotationE2EConfigPath, "timestamp", "DigiCertTSARootSHA384.cer"), "--timestamp-trust-store", "e2e", artifact.ReferenceWithDigest()).
+				MatchErrKeyWords("Error: if any flags in the group [timestamp-root-cert timestamp-trust-store] are set none of the others can be")
+		})
+	})
+
+	It("with timestamping and non-existent tsa trust store", func() {
+		Host(BaseOptions(), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
+			notation.ExpectFailure().Exec("sign", "--timestamp-url", "http://timestamp.digicert.com", "--timestamp-trust-store", "e2e", artifact.ReferenceWithDigest()).
+				MatchErrKeyWords(`failed to get tsa root certificates from trust store "e2e"`)
+		})
+	})
+
 	It("with timestamp-root-cert but no timestamp-url", func() {
 		Host(BaseOptions(), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
 			notation.ExpectFailure().Exec("sign", "--timestamp-root-cert", filepath.Join(NotationE2EConfigPath, "timestamp", "globalsignTSARoot.cer"), artifact.ReferenceWithDigest()).
-				MatchErrKeyWords("Error: if any flags in the group [timestamp-url timestamp-root-cert] are set they must all be set; missing [timestamp-url]")
+				MatchErrKeyWords("Error: timestamping: --timestamp-url is required when --timestamp-root-cert or --timestamp-trust-store is specified")
 		})
 	})
 
 	It("with timestamp-url but no timestamp-root-cert", func() {
 		Host(BaseOptions(), func(notation *utils.ExecOpts, artifact *Artifact, vhost *utils.VirtualHost) {
 			notation.ExpectFailure().Exec("sign", "--timestamp-url", "http://rfc3161timestamp.globalsign.com/advanced", artifact.ReferenceWithDigest()).
-				MatchErrKeyWords("Error: if any flags in the group [timestamp-url timestamp-root-cert] are set they must all be set; missing [timestamp-root-cert]")
+				MatchErrKeyWords("Error: timestamping: either --timestamp-root-cert or --timestamp-trust-store is required when --timestamp-url is specified")
 		})
 	})
 